@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
@@ -13,12 +16,19 @@ import (
 	"github.com/kitae0522/gommunity/internal/config"
 	"github.com/kitae0522/gommunity/internal/controller"
 	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/exception"
 )
 
 const port = ":8080"
 
 func main() {
-	app := fiber.New()
+	// 업로드 용량 제한은 서비스에서 확인하므로 multipart 헤더만큼 여유를 둠
+	// 클라이언트 IP(IP 정지, 감사 로그, 조회수)는 fiber의 ProxyHeader 대신 controller.requestMeta에서 TRUSTED_PROXIES를 기준으로 구함
+	app := fiber.New(fiber.Config{
+		BodyLimit:    int(config.Envs.UploadMaxBytes) + 1024*1024,
+		ErrorHandler: exception.ErrorHandler,
+	})
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
@@ -47,6 +57,26 @@ func main() {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 
-	controller.EnrollRouter(app, dbconn, rdconn)
-	log.Fatal(app.Listen(port))
+	workers := controller.EnrollRouter(app, dbconn, rdconn)
+	// 요청 처리 중에 쌓인 감사 로그까지 저장하도록 워커는 서버가 요청을 다 처리한 뒤에 멈춤
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	wait := service.StartWorkers(workerCtx, workers...)
+	defer func() {
+		cancelWorkers()
+		wait()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Failed to shutdown server: %v", err)
+		}
+	}()
+
+	// log.Fatal은 defer를 실행하지 않으므로 에러만 남기고 정상적으로 정리함
+	if err := app.Listen(port); err != nil {
+		log.Printf("Failed to listen: %v", err)
+	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,37 @@ type Config struct {
 	RedisDB                int64
 	RedisInteractionAmount int64
 	RedisInteractionCount  int64
+	ReportAutoHideCount    int64
+	StorageDriver          string
+	StorageLocalDir        string
+	S3Endpoint             string
+	S3Region               string
+	S3Bucket               string
+	S3AccessKey            string
+	S3SecretKey            string
+	S3PathStyle            bool
+	UploadMaxBytes         int64
+	UploadMaxDimension     int64
+	UploadMaxPixels        int64
+	UploadThumbnailSize    int64
+	UploadQuotaBytes       int64
+	UploadOrphanTTLHours   int64
+	UnfurlTimeoutSeconds   int64
+	UnfurlMaxBytes         int64
+	UnfurlMaxRedirects     int64
+	ViewWindowMinutes      int64
+	CacheThreadListTTL     int64
+	CacheThreadHandleTTL   int64
+	CacheThreadTTL         int64
+	CacheAttachmentTTL     int64
+	CacheContentTTL        int64
+	CacheStaleSeconds      int64
+	CacheJitterPercent     int64
+	CacheDriver            string
+	CacheLocalCapacity     int64
+	CacheLocalTTLSeconds   int64
+	ProxyHeader            string
+	TrustedProxies         []string
 }
 
 var Envs = initConfig()
@@ -30,6 +62,37 @@ func initConfig() Config {
 		RedisDB:                getEnvAsInt("REDIS_DB", 0),
 		RedisInteractionAmount: getEnvAsInt("REDIS_ITR_AMOUNT", 10),
 		RedisInteractionCount:  getEnvAsInt("REDIS_ITR_COUNT", 5),
+		ReportAutoHideCount:    getEnvAsInt("REPORT_AUTO_HIDE_COUNT", 5),
+		StorageDriver:          getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:        getEnv("STORAGE_LOCAL_DIR", "./data/uploads"),
+		S3Endpoint:             getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:               getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:               getEnv("S3_BUCKET", "gommunity"),
+		S3AccessKey:            getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:            getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:            getEnv("S3_PATH_STYLE", "true") == "true",
+		UploadMaxBytes:         getEnvAsInt("UPLOAD_MAX_BYTES", 10*1024*1024),
+		UploadMaxDimension:     getEnvAsInt("UPLOAD_MAX_DIMENSION", 6000),
+		UploadMaxPixels:        getEnvAsInt("UPLOAD_MAX_PIXELS", 6000*6000),
+		UploadThumbnailSize:    getEnvAsInt("UPLOAD_THUMBNAIL_SIZE", 320),
+		UploadQuotaBytes:       getEnvAsInt("UPLOAD_QUOTA_BYTES", 200*1024*1024),
+		UploadOrphanTTLHours:   getEnvAsInt("UPLOAD_ORPHAN_TTL_HOURS", 24),
+		UnfurlTimeoutSeconds:   getEnvAsInt("UNFURL_TIMEOUT_SECONDS", 5),
+		UnfurlMaxBytes:         getEnvAsInt("UNFURL_MAX_BYTES", 512*1024),
+		UnfurlMaxRedirects:     getEnvAsInt("UNFURL_MAX_REDIRECTS", 5),
+		ViewWindowMinutes:      getEnvAsPositiveInt("VIEW_WINDOW_MINUTES", 30),
+		CacheThreadListTTL:     getEnvAsInt("CACHE_THREAD_LIST_TTL_SECONDS", 5*60),
+		CacheThreadHandleTTL:   getEnvAsInt("CACHE_THREAD_HANDLE_TTL_SECONDS", 5*60),
+		CacheThreadTTL:         getEnvAsInt("CACHE_THREAD_TTL_SECONDS", 5*60),
+		CacheAttachmentTTL:     getEnvAsInt("CACHE_ATTACHMENT_TTL_SECONDS", 5*60),
+		CacheContentTTL:        getEnvAsInt("CACHE_CONTENT_TTL_SECONDS", 24*60*60),
+		CacheStaleSeconds:      getEnvAsInt("CACHE_STALE_SECONDS", 60),
+		CacheJitterPercent:     getEnvAsInt("CACHE_JITTER_PERCENT", 10),
+		CacheDriver:            getEnv("CACHE_DRIVER", "redis"),
+		CacheLocalCapacity:     getEnvAsInt("CACHE_LOCAL_CAPACITY", 10000),
+		CacheLocalTTLSeconds:   getEnvAsInt("CACHE_LOCAL_TTL_SECONDS", 5),
+		ProxyHeader:            getEnv("PROXY_HEADER", "X-Forwarded-For"),
+		TrustedProxies:         getEnvAsList("TRUSTED_PROXIES"),
 	}
 }
 
@@ -52,3 +115,22 @@ func getEnvAsInt(key string, fallback int64) int64 {
 
 	return fallback
 }
+
+// 0으로 나누거나 만료 시간이 없는 키가 생기지 않도록 1보다 작은 값은 기본값으로 대체함
+func getEnvAsPositiveInt(key string, fallback int64) int64 {
+	if i := getEnvAsInt(key, fallback); i >= 1 {
+		return i
+	}
+	return fallback
+}
+
+// 쉼표로 구분된 값을 목록으로 읽음 (ex. TRUSTED_PROXIES=10.0.0.0/8,172.16.0.1)
+func getEnvAsList(key string) []string {
+	var list []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
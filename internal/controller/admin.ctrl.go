@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type AdminController struct {
+	banService   *service.BanService
+	authService  *service.AuthService
+	auditService *service.AuditService
+	cacheService *service.CacheService
+}
+
+func NewAdminController(banService *service.BanService, authService *service.AuthService, auditService *service.AuditService, cacheService *service.CacheService) *AdminController {
+	return &AdminController{
+		banService:   banService,
+		authService:  authService,
+		auditService: auditService,
+		cacheService: cacheService,
+	}
+}
+
+func initAdminDI(dbconn *model.PrismaClient, stores stores, rdconn *redis.Client, cacheStore cache.Cache, auditService *service.AuditService) *AdminController {
+	banService := service.NewBanService(repository.NewModerationRepository(dbconn), auditService, cacheStore, rdconn)
+	authService := service.NewAuthService(stores.auth, auditService, cacheStore, rdconn)
+	cacheService := service.NewCacheService(cacheStore)
+	handler := NewAdminController(banService, authService, auditService, cacheService)
+	return handler
+}
+
+func initAdminRouter(router fiber.Router, handler *AdminController) {
+	adminRouter := router.Group("/admin")
+	handler.Admin(adminRouter)
+}
+
+func (c *AdminController) Admin(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware, middleware.RoleMiddleware(model.UserRolesAdmin))
+	router.Get("/bans/users", c.ListBans)
+	router.Post("/bans/users/:handle", c.BanUser)
+	router.Delete("/bans/users/:handle", c.UnbanUser)
+	router.Get("/bans/ips", c.ListIPBans)
+	router.Post("/bans/ips", c.BanIP)
+	router.Delete("/bans/ips/:banID", c.UnbanIP)
+	router.Patch("/users/:handle/role", c.ChangeRole)
+	router.Get("/audit", c.ListAuditLogs)
+	router.Delete("/cache", c.PurgeCache)
+}
+
+func (c *AdminController) BanUser(ctx *fiber.Ctx) error {
+	var banPayload dto.BanUserRequest
+	banPayload.AdminID = middleware.GetIdFromMiddleware(ctx)
+	// durationHours 같은 필드 이름을 잘못 보내면 영구 정지가 되므로 모르는 필드는 거부함
+	if err := utils.Bind(ctx, &banPayload, "유저 정지", utils.DisallowUnknownFields()); err != nil {
+		return err
+	}
+
+	if err := c.banService.BanUser(ctx.Context(), requestMeta(ctx), &banPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 유저 정지 완료"),
+	})
+}
+
+func (c *AdminController) UnbanUser(ctx *fiber.Ctx) error {
+	var unbanPayload dto.UnbanUserRequest
+	unbanPayload.AdminID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &unbanPayload, "유저 정지 해제"); err != nil {
+		return err
+	}
+
+	if err := c.banService.UnbanUser(ctx.Context(), requestMeta(ctx), &unbanPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 유저 정지 해제 완료"),
+	})
+}
+
+func (c *AdminController) ListBans(ctx *fiber.Ctx) error {
+	bans, err := c.banService.ListBans(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListBanResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 정지 목록 조회 완료"),
+		Bans:       bans,
+	})
+}
+
+func (c *AdminController) BanIP(ctx *fiber.Ctx) error {
+	var banPayload dto.BanIPRequest
+	banPayload.AdminID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &banPayload, "IP 차단", utils.DisallowUnknownFields()); err != nil {
+		return err
+	}
+
+	if err := c.banService.BanIP(ctx.Context(), requestMeta(ctx), &banPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ IP 차단 완료"),
+	})
+}
+
+func (c *AdminController) UnbanIP(ctx *fiber.Ctx) error {
+	var unbanPayload dto.UnbanIPRequest
+	unbanPayload.AdminID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &unbanPayload, "IP 차단 해제"); err != nil {
+		return err
+	}
+
+	if err := c.banService.UnbanIP(ctx.Context(), requestMeta(ctx), &unbanPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ IP 차단 해제 완료"),
+	})
+}
+
+func (c *AdminController) ListIPBans(ctx *fiber.Ctx) error {
+	bans, err := c.banService.ListIPBans(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListIPBanResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ IP 차단 목록 조회 완료"),
+		IPBans:     bans,
+	})
+}
+
+func (c *AdminController) ChangeRole(ctx *fiber.Ctx) error {
+	var rolePayload dto.ChangeRoleRequest
+	rolePayload.AdminID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &rolePayload, "권한 변경", utils.DisallowUnknownFields()); err != nil {
+		return err
+	}
+
+	if err := c.authService.ChangeRole(ctx.Context(), requestMeta(ctx), &rolePayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 권한 변경 완료"),
+	})
+}
+
+func (c *AdminController) ListAuditLogs(ctx *fiber.Ctx) error {
+	var listAuditPayload dto.ListAuditLogRequest
+	if err := utils.Bind(ctx, &listAuditPayload, "감사 로그 조회"); err != nil {
+		return err
+	}
+
+	filter, err := c.auditService.AuditFilter(&listAuditPayload)
+	if err != nil {
+		return err
+	}
+
+	switch listAuditPayload.Format {
+	case "csv", "ndjson":
+		return c.exportAuditLogs(ctx, filter, listAuditPayload.Format)
+	}
+
+	auditLogs, nextCursor, err := c.auditService.ListAuditLogs(ctx.Context(), filter, listAuditPayload.Cursor, listAuditPayload.PageSize)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListAuditLogResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 감사 로그 조회 완료"),
+		AuditLogs:  auditLogs,
+		NextCursor: nextCursor,
+	})
+}
+
+func (c *AdminController) PurgeCache(ctx *fiber.Ctx) error {
+	var purgePayload dto.PurgeCacheRequest
+	if err := utils.Bind(ctx, &purgePayload, "캐시 삭제"); err != nil {
+		return err
+	}
+
+	deleted, err := c.cacheService.Purge(ctx.Context(), purgePayload.Pattern)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.PurgeCacheResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 캐시 삭제 완료"),
+		Deleted:    deleted,
+	})
+}
+
+func (c *AdminController) exportAuditLogs(ctx *fiber.Ctx, filter dto.AuditLogFilter, format string) error {
+	contentType := "application/x-ndjson"
+	if format == "csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-%s.%s"`, time.Now().Format("20060102150405"), format))
+
+	// 스트림은 핸들러가 반환된 뒤에 쓰이므로 요청 ctx 대신 별도 context를 사용
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := c.auditService.ExportAuditLogs(context.Background(), filter, format, w); err != nil {
+			log.Printf("audit: export failed: %v", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
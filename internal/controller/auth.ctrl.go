@@ -6,9 +6,9 @@ import (
 
 	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/internal/middleware"
-	"github.com/kitae0522/gommunity/internal/model"
-	"github.com/kitae0522/gommunity/internal/repository"
 	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/i18n"
 	"github.com/kitae0522/gommunity/pkg/utils"
 )
 
@@ -20,9 +20,8 @@ func NewAuthController(service *service.AuthService) *AuthController {
 	return &AuthController{authService: service}
 }
 
-func initAuthDI(dbconn *model.PrismaClient, rdconn *redis.Client) *AuthController {
-	repository := repository.NewAuthRepository(dbconn)
-	service := service.NewAuthService(repository, rdconn)
+func initAuthDI(stores stores, rdconn *redis.Client, cacheStore cache.Cache, auditService *service.AuditService) *AuthController {
+	service := service.NewAuthService(stores.auth, auditService, cacheStore, rdconn)
 	handler := NewAuthController(service)
 	return handler
 }
@@ -47,36 +46,36 @@ func (c *AuthController) Restricted(router fiber.Router) {
 func (c *AuthController) Register(ctx *fiber.Ctx) error {
 	var createUserPayload dto.RegisterRequest
 	if err := utils.Bind(ctx, &createUserPayload, "회원가입"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	err := c.authService.Register(ctx.Context(), createUserPayload)
+	err := c.authService.Register(ctx.Context(), requestMeta(ctx), createUserPayload)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusCreated,
-		Message:    "✅ 회원가입 완료",
+		Message:    i18n.T(ctx, "✅ 회원가입 완료"),
 	})
 }
 
 func (c *AuthController) Login(ctx *fiber.Ctx) error {
 	var loginPayload dto.LoginRequest
 	if err := utils.Bind(ctx, &loginPayload, "로그인"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	token, err := c.authService.Login(ctx.Context(), loginPayload.Email, loginPayload.Password)
+	token, err := c.authService.Login(ctx.Context(), requestMeta(ctx), loginPayload.Email, loginPayload.Password)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.LoginResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 로그인 완료",
+		Message:    i18n.T(ctx, "✅ 로그인 완료"),
 		Token:      token,
 	})
 }
@@ -84,7 +83,7 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 func (c *AuthController) PasswordReset(ctx *fiber.Ctx) error {
 	var passwordResetPayload dto.PasswordResetRequest
 	if err := utils.Bind(ctx, &passwordResetPayload, "비밀번호 초기화"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	resetEntity := dto.PasswordResetEntity{
@@ -92,14 +91,14 @@ func (c *AuthController) PasswordReset(ctx *fiber.Ctx) error {
 		PasswordPayload: &passwordResetPayload,
 	}
 
-	if err := c.authService.PasswordReset(ctx.Context(), resetEntity); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+	if err := c.authService.PasswordReset(ctx.Context(), requestMeta(ctx), resetEntity); err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 비밀번호 초기화 완료",
+		Message:    i18n.T(ctx, "✅ 비밀번호 초기화 완료"),
 	})
 }
 
@@ -107,13 +106,13 @@ func (c *AuthController) Withdraw(ctx *fiber.Ctx) error {
 	var withdrawPayload dto.WithdrawRequest
 	withdrawPayload.ID = middleware.GetIdFromMiddleware(ctx)
 
-	if err := c.authService.Withdraw(ctx.Context(), withdrawPayload.ID); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+	if err := c.authService.Withdraw(ctx.Context(), requestMeta(ctx), withdrawPayload.ID); err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 유저 탈퇴 완료",
+		Message:    i18n.T(ctx, "✅ 유저 탈퇴 완료"),
 	})
 }
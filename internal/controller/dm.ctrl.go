@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type DirectMessageController struct {
+	dmService *service.DirectMessageService
+}
+
+func NewDirectMessageController(service *service.DirectMessageService) *DirectMessageController {
+	return &DirectMessageController{dmService: service}
+}
+
+func initDirectMessageDI(dbconn *model.PrismaClient, stores stores, rdconn *redis.Client) *DirectMessageController {
+	repository := repository.NewDirectMessageRepository(dbconn)
+	service := service.NewDirectMessageService(repository, stores.relation, rdconn)
+	handler := NewDirectMessageController(service)
+	return handler
+}
+
+func initDirectMessageRouter(router fiber.Router, handler *DirectMessageController) {
+	dmRouter := router.Group("/dm")
+	handler.Restricted(dmRouter)
+}
+
+func (c *DirectMessageController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Get("", c.ListConversations)
+	router.Post("/", c.CreateConversation)
+	router.Get("/:conversationID/messages", c.ListMessages)
+	router.Post("/:conversationID/messages", c.SendMessage)
+	router.Post("/:conversationID/read", c.MarkRead)
+	router.Patch("/:conversationID/mute", c.MuteConversation)
+	router.Delete("/:conversationID", c.LeaveConversation)
+}
+
+func (c *DirectMessageController) CreateConversation(ctx *fiber.Ctx) error {
+	var createConversationPayload dto.CreateConversationRequest
+	createConversationPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &createConversationPayload, "대화방 생성"); err != nil {
+		return err
+	}
+
+	conversation, err := c.dmService.CreateConversation(ctx.Context(), &createConversationPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.CreateConversationResponse{
+		IsError:      false,
+		StatusCode:   fiber.StatusCreated,
+		Message:      i18n.T(ctx, "✅ 대화방 생성 완료"),
+		Conversation: *conversation,
+	})
+}
+
+func (c *DirectMessageController) ListConversations(ctx *fiber.Ctx) error {
+	conversations, totalUnread, err := c.dmService.ListConversations(ctx.Context(), middleware.GetIdFromMiddleware(ctx))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListConversationResponse{
+		IsError:       false,
+		StatusCode:    fiber.StatusOK,
+		Message:       i18n.T(ctx, "✅ 대화방 조회 완료"),
+		TotalUnread:   totalUnread,
+		Conversations: conversations,
+	})
+}
+
+func (c *DirectMessageController) ListMessages(ctx *fiber.Ctx) error {
+	var listMessagePayload dto.ListDirectMessageRequest
+	listMessagePayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &listMessagePayload, "메시지 조회"); err != nil {
+		return err
+	}
+
+	messages, nextCursor, err := c.dmService.ListMessages(ctx.Context(), &listMessagePayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListDirectMessageResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 메시지 조회 완료"),
+		Messages:   messages,
+		NextCursor: nextCursor,
+	})
+}
+
+func (c *DirectMessageController) SendMessage(ctx *fiber.Ctx) error {
+	var sendMessagePayload dto.SendDirectMessageRequest
+	sendMessagePayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &sendMessagePayload, "메시지 전송"); err != nil {
+		return err
+	}
+
+	message, err := c.dmService.SendMessage(ctx.Context(), &sendMessagePayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.SendDirectMessageResponse{
+		IsError:       false,
+		StatusCode:    fiber.StatusCreated,
+		Message:       i18n.T(ctx, "✅ 메시지 전송 완료"),
+		DirectMessage: *message,
+	})
+}
+
+func (c *DirectMessageController) MarkRead(ctx *fiber.Ctx) error {
+	var markReadPayload dto.MarkReadRequest
+	markReadPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &markReadPayload, "메시지 읽음 처리"); err != nil {
+		return err
+	}
+
+	if err := c.dmService.MarkRead(ctx.Context(), &markReadPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 메시지 읽음 처리 완료"),
+	})
+}
+
+func (c *DirectMessageController) MuteConversation(ctx *fiber.Ctx) error {
+	var mutePayload dto.MuteConversationRequest
+	mutePayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &mutePayload, "대화방 알림 설정"); err != nil {
+		return err
+	}
+
+	if err := c.dmService.MuteConversation(ctx.Context(), &mutePayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 대화방 알림 설정 완료"),
+	})
+}
+
+func (c *DirectMessageController) LeaveConversation(ctx *fiber.Ctx) error {
+	var leavePayload dto.ConversationRequest
+	leavePayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &leavePayload, "대화방 나가기"); err != nil {
+		return err
+	}
+
+	if err := c.dmService.LeaveConversation(ctx.Context(), &leavePayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 대화방 나가기 완료"),
+	})
+}
@@ -0,0 +1,395 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository/memory"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+var updateGolden = flag.Bool("update", false, "testdata/golden의 스냅샷을 현재 응답으로 덮어씀")
+
+/*
+EnrollRouter가 등록한 실제 라우트를 app.Test로 호출하는 통합 테스트 하네스. Redis는 miniredis로 대신함.
+TEST_DATABASE_URL이 있으면 Prisma 클라이언트를 그 MySQL에 연결하고, 없으면 메모리 저장소(repository/memory)로 실행함.
+메모리 저장소는 업로드, 북마크, DM, 투표, 신고, 정지 저장소를 구현하지 않으므로 그 라우트는 DB가 있을 때만 확인함(withDB).
+TEST_DATABASE_URL의 DB는 테스트가 시작할 때마다 비워지므로 반드시 테스트 전용 DB를 지정해야 하며,
+스키마는 미리 `DATABASE_URL=$TEST_DATABASE_URL go run github.com/steebchen/prisma-client-go db push`로 맞춰둬야 함.
+*/
+type testHarness struct {
+	t   *testing.T
+	app *fiber.App
+	// 둘 중 하나만 설정됨
+	dbconn *model.PrismaClient
+	store  *memory.Store
+	redis  *miniredis.Miniredis
+}
+
+type apiRequest struct {
+	method string
+	path   string
+	token  string
+	body   interface{}
+	// body 대신 multipart 등 JSON이 아닌 본문을 보낼 때 사용
+	rawBody     []byte
+	contentType string
+}
+
+type apiResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func newTestHarness(t *testing.T) *testHarness {
+	t.Helper()
+
+	envs := config.Envs
+	t.Cleanup(func() { config.Envs = envs })
+	config.Envs.StorageDriver = "local"
+	config.Envs.StorageLocalDir = t.TempDir()
+	config.Envs.CacheDriver = "redis"
+
+	h := &testHarness{t: t}
+	var repos stores
+	if databaseURL := os.Getenv("TEST_DATABASE_URL"); databaseURL != "" {
+		t.Setenv("DATABASE_URL", databaseURL)
+		h.dbconn = model.NewClient()
+		if err := h.dbconn.Prisma.Connect(); err != nil {
+			t.Fatalf("connect to test DB: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := h.dbconn.Prisma.Disconnect(); err != nil {
+				t.Errorf("disconnect from test DB: %v", err)
+			}
+		})
+		resetDatabase(t, h.dbconn)
+		repos = prismaStores(h.dbconn)
+	} else {
+		h.store = memory.NewStore()
+		repos = stores{
+			auth:      h.store,
+			thread:    h.store,
+			relation:  h.store,
+			audit:     h.store,
+			users:     h.store,
+			uploads:   h.store,
+			bookmarks: h.store,
+		}
+	}
+
+	mr := miniredis.RunT(t)
+	h.redis = mr
+	rdconn := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdconn.Close() })
+
+	// cmd/main.go와 같은 설정으로 앱을 구성하되, 로그는 테스트 출력이 길어지지 않도록 뺌
+	app := fiber.New(fiber.Config{
+		BodyLimit:    int(config.Envs.UploadMaxBytes) + 1024*1024,
+		ErrorHandler: exception.ErrorHandler,
+	})
+	app.Use(recover.New())
+	h.app = app
+	workers := enrollRouter(app, h.dbconn, repos, rdconn)
+	ctx, cancel := context.WithCancel(context.Background())
+	wait := service.StartWorkers(ctx, workers...)
+	t.Cleanup(func() {
+		cancel()
+		wait()
+	})
+
+	return h
+}
+
+/*
+메모리 저장소가 구현하지 않는 저장소를 쓰는 단계를 하위 테스트로 실행하고, DB가 없으면 건너뜀.
+하위 테스트 안에서 부모 t로 Fatal을 호출할 수 없으므로 실행하는 동안 h.t를 하위 테스트의 t로 바꿔둠.
+*/
+func (h *testHarness) withDB(name string, steps func(t *testing.T)) {
+	parent := h.t
+	parent.Run(name, func(t *testing.T) {
+		if h.dbconn == nil {
+			t.Skip("TEST_DATABASE_URL is not set; the memory store does not implement this repository")
+		}
+		h.t = t
+		defer func() { h.t = parent }()
+		steps(t)
+	})
+}
+
+// 외래 키 순서대로 자식 테이블부터 비우고, 골든 파일의 ID가 실행마다 같도록 autoincrement도 1로 되돌림
+func resetDatabase(t *testing.T, dbconn *model.PrismaClient) {
+	t.Helper()
+
+	tables := []string{
+		"DirectMessage", "ConversationParticipant", "Conversation",
+		"PollChoice", "PollBallot", "PollOption", "Poll",
+		"Bookmark", "BookmarkFolder", "Attachment", "Upload",
+		"Report", "ModerationAction", "UserRelation", "Ban", "NetworkBan", "AuditLog",
+		"Thread", "Users",
+	}
+	ctx := context.Background()
+	for _, table := range tables {
+		if _, err := dbconn.Prisma.ExecuteRaw(fmt.Sprintf("DELETE FROM `%s`", table)).Exec(ctx); err != nil {
+			t.Fatalf("clear %s: %v", table, err)
+		}
+	}
+	for _, table := range tables {
+		if table == "Users" || table == "Upload" || table == "Poll" {
+			continue
+		}
+		if _, err := dbconn.Prisma.ExecuteRaw(fmt.Sprintf("ALTER TABLE `%s` AUTO_INCREMENT = 1", table)).Exec(ctx); err != nil {
+			t.Fatalf("reset auto increment of %s: %v", table, err)
+		}
+	}
+}
+
+func (h *testHarness) do(req apiRequest) apiResponse {
+	h.t.Helper()
+
+	var body io.Reader
+	contentType := req.contentType
+	if req.rawBody != nil {
+		body = bytes.NewReader(req.rawBody)
+	} else if req.body != nil {
+		payload, err := json.Marshal(req.body)
+		if err != nil {
+			h.t.Fatalf("%s %s: marshal body: %v", req.method, req.path, err)
+		}
+		body = bytes.NewReader(payload)
+		contentType = fiber.MIMEApplicationJSON
+	}
+
+	httpReq := httptest.NewRequest(req.method, req.path, body)
+	if contentType != "" {
+		httpReq.Header.Set(fiber.HeaderContentType, contentType)
+	}
+	if req.token != "" {
+		httpReq.Header.Set(fiber.HeaderAuthorization, "Bearer "+req.token)
+	}
+
+	resp, err := h.app.Test(httpReq, -1)
+	if err != nil {
+		h.t.Fatalf("%s %s: %v", req.method, req.path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.t.Fatalf("%s %s: read body: %v", req.method, req.path, err)
+	}
+	return apiResponse{status: resp.StatusCode, header: resp.Header, body: respBody}
+}
+
+/*
+요청을 보내고 상태 코드, 응답 형식을 확인한 뒤 testdata/golden/{name}.json 스냅샷과 비교함.
+실패 응답은 ErrResponseCtx 형식(isError, statusCode, message, error)을, 성공 응답은 isError가 false인지 확인함.
+*/
+func (h *testHarness) expect(name string, req apiRequest, status int) map[string]interface{} {
+	h.t.Helper()
+
+	resp := h.do(req)
+	if resp.status != status {
+		h.t.Fatalf("%s: %s %s = %d, want %d\n%s", name, req.method, req.path, resp.status, status, resp.body)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(resp.body, &decoded); err != nil {
+		h.t.Fatalf("%s: response is not a JSON object: %v\n%s", name, err, resp.body)
+	}
+	assertResponseShape(h.t, name, status, decoded)
+	assertGolden(h.t, name, resp.body)
+	return decoded
+}
+
+func assertResponseShape(t *testing.T, name string, status int, decoded map[string]interface{}) {
+	t.Helper()
+
+	isError, ok := decoded["isError"].(bool)
+	if !ok {
+		t.Errorf("%s: isError is missing", name)
+		return
+	}
+	if message, _ := decoded["message"].(string); message == "" {
+		t.Errorf("%s: message is missing", name)
+	}
+	if _, ok := decoded["statusCode"].(float64); !ok {
+		t.Errorf("%s: statusCode is missing", name)
+	}
+
+	if status < fiber.StatusBadRequest {
+		if isError {
+			t.Errorf("%s: isError = true on %d response", name, status)
+		}
+		return
+	}
+	if !isError {
+		t.Errorf("%s: isError = false on %d response", name, status)
+	}
+	if statusCode, _ := decoded["statusCode"].(float64); int(statusCode) != status {
+		t.Errorf("%s: statusCode = %v, want %d", name, decoded["statusCode"], status)
+	}
+	if _, ok := decoded["error"]; !ok {
+		t.Errorf("%s: error is missing", name)
+	}
+}
+
+var (
+	uuidPattern      = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	tokenPattern     = regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`)
+	// 정지 안내 메시지처럼 본문에 섞여 나오는 시각
+	localTimePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}(:\d{2})?`)
+)
+
+// 실행마다 달라지는 UUID, 시각, 토큰은 자리표시자로 바꿔서 비교함
+func normalizeBody(body []byte) []byte {
+	body = uuidPattern.ReplaceAll(body, []byte("<uuid>"))
+	body = timestampPattern.ReplaceAll(body, []byte("<time>"))
+	body = localTimePattern.ReplaceAll(body, []byte("<time>"))
+	body = tokenPattern.ReplaceAll(body, []byte("<token>"))
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "  "); err != nil {
+		return body
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes()
+}
+
+// -update로 실행하면 스냅샷을 새로 쓰고, 아니면 스냅샷이 없거나 다를 때 실패시킴
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	got := normalizeBody(body)
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Errorf("%s: golden file %s is missing (run with -update to create it)\n%s", name, path, got)
+		return
+	} else if err != nil {
+		t.Fatalf("read golden %s: %v", path, err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s: response differs from %s (run with -update to accept)\n--- want\n%s\n--- got\n%s", name, path, want, got)
+	}
+}
+
+func (h *testHarness) setRole(handle string, role model.UserRoles) {
+	h.t.Helper()
+
+	ctx := context.Background()
+	var err error
+	if h.dbconn != nil {
+		_, err = h.dbconn.Users.FindUnique(
+			model.Users.Handle.Equals(handle),
+		).Update(
+			model.Users.Role.Set(role),
+		).Exec(ctx)
+	} else {
+		var user *model.UsersModel
+		if user, err = h.store.GetUserByHandle(ctx, handle); err == nil {
+			err = h.store.UpdateUserRole(ctx, user.ID, role)
+		}
+	}
+	if err != nil {
+		h.t.Fatalf("set role of %s: %v", handle, err)
+	}
+}
+
+// 감사 로그처럼 비동기로 기록되는 결과는 원하는 응답이 올 때까지 잠시 다시 요청함
+func (h *testHarness) eventually(req apiRequest, ready func(map[string]interface{}) bool) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp := h.do(req)
+		var decoded map[string]interface{}
+		if json.Unmarshal(resp.body, &decoded) == nil && ready(decoded) {
+			return
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatalf("%s %s: condition not met in time\n%s", req.method, req.path, resp.body)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// 썸네일이 만들어지도록 썸네일 크기보다 큰 PNG를 multipart 본문으로 만듦
+func pngUploadBody(t *testing.T, filename string) ([]byte, string) {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	if err := png.Encode(part, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+	return body.Bytes(), writer.FormDataContentType()
+}
+
+func stringField(t *testing.T, decoded map[string]interface{}, path ...string) string {
+	t.Helper()
+
+	var current interface{} = decoded
+	for _, key := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s: not an object at %q", strings.Join(path, "."), key)
+		}
+		current = object[key]
+	}
+	value, ok := current.(string)
+	if !ok {
+		t.Fatalf("%s: not a string: %v", strings.Join(path, "."), current)
+	}
+	return value
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type ModerationController struct {
+	moderationService *service.ModerationService
+}
+
+func NewModerationController(service *service.ModerationService) *ModerationController {
+	return &ModerationController{moderationService: service}
+}
+
+func initModerationDI(dbconn *model.PrismaClient, rdconn *redis.Client, cacheStore cache.Cache, auditService *service.AuditService) *ModerationController {
+	repository := repository.NewModerationRepository(dbconn)
+	service := service.NewModerationService(repository, auditService, cacheStore, rdconn)
+	handler := NewModerationController(service)
+	return handler
+}
+
+func initModerationRouter(router fiber.Router, handler *ModerationController) {
+	reportRouter := router.Group("/reports")
+	handler.Restricted(reportRouter)
+
+	modRouter := router.Group("/mod")
+	handler.Moderator(modRouter)
+}
+
+func (c *ModerationController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Post("/", c.CreateReport)
+}
+
+func (c *ModerationController) Moderator(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware, middleware.RoleMiddleware(model.UserRolesModerator, model.UserRolesAdmin))
+	router.Get("/reports", c.ListReports)
+	router.Patch("/reports/:reportID", c.UpdateReport)
+	router.Get("/actions", c.ListActions)
+	router.Post("/thread/:threadID/hide", c.HideThread)
+	router.Delete("/thread/:threadID/hide", c.UnhideThread)
+	router.Post("/thread/:threadID/lock", c.LockThread)
+	router.Delete("/thread/:threadID/lock", c.UnlockThread)
+	router.Post("/thread/:threadID/pin", c.PinThread)
+	router.Delete("/thread/:threadID/pin", c.UnpinThread)
+	router.Post("/users/:handle/warn", c.WarnUser)
+	router.Post("/users/:handle/suspend", c.SuspendUser)
+}
+
+func (c *ModerationController) CreateReport(ctx *fiber.Ctx) error {
+	var reportPayload dto.CreateReportRequest
+	reportPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &reportPayload, "신고"); err != nil {
+		return err
+	}
+
+	report, err := c.moderationService.CreateReport(ctx.Context(), &reportPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.CreateReportResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusCreated,
+		Message:    i18n.T(ctx, "✅ 신고 접수 완료"),
+		Report:     *report,
+	})
+}
+
+func (c *ModerationController) ListReports(ctx *fiber.Ctx) error {
+	var listReportPayload dto.ListReportRequest
+	if err := utils.Bind(ctx, &listReportPayload, "신고 목록 조회"); err != nil {
+		return err
+	}
+
+	reports, err := c.moderationService.ListReports(ctx.Context(), &listReportPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListReportResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 신고 목록 조회 완료"),
+		Reports:    reports,
+	})
+}
+
+func (c *ModerationController) UpdateReport(ctx *fiber.Ctx) error {
+	var updateReportPayload dto.UpdateReportRequest
+	updateReportPayload.ModeratorID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &updateReportPayload, "신고 처리"); err != nil {
+		return err
+	}
+
+	if err := c.moderationService.UpdateReport(ctx.Context(), &updateReportPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 신고 처리 완료"),
+	})
+}
+
+func (c *ModerationController) ListActions(ctx *fiber.Ctx) error {
+	var listActionPayload dto.ListModerationActionRequest
+	if err := utils.Bind(ctx, &listActionPayload, "조치 기록 조회"); err != nil {
+		return err
+	}
+
+	actions, err := c.moderationService.ListActions(ctx.Context(), &listActionPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListModerationActionResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 조치 기록 조회 완료"),
+		Actions:    actions,
+	})
+}
+
+func (c *ModerationController) HideThread(ctx *fiber.Ctx) error {
+	return c.moderateThread(ctx, "쓰레드 숨김", c.moderationService.HideThread)
+}
+
+func (c *ModerationController) UnhideThread(ctx *fiber.Ctx) error {
+	return c.moderateThread(ctx, "쓰레드 숨김 해제", c.moderationService.UnhideThread)
+}
+
+func (c *ModerationController) LockThread(ctx *fiber.Ctx) error {
+	return c.moderateThread(ctx, "쓰레드 잠금", c.moderationService.LockThread)
+}
+
+func (c *ModerationController) UnlockThread(ctx *fiber.Ctx) error {
+	return c.moderateThread(ctx, "쓰레드 잠금 해제", c.moderationService.UnlockThread)
+}
+
+func (c *ModerationController) PinThread(ctx *fiber.Ctx) error {
+	var pinPayload dto.PinThreadRequest
+	pinPayload.ModeratorID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &pinPayload, "쓰레드 고정"); err != nil {
+		return err
+	}
+
+	if err := c.moderationService.PinThread(ctx.Context(), &pinPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 쓰레드 고정 완료"),
+	})
+}
+
+func (c *ModerationController) UnpinThread(ctx *fiber.Ctx) error {
+	return c.moderateThread(ctx, "쓰레드 고정 해제", c.moderationService.UnpinThread)
+}
+
+func (c *ModerationController) WarnUser(ctx *fiber.Ctx) error {
+	return c.moderateUser(ctx, "유저 경고", c.moderationService.WarnUser)
+}
+
+func (c *ModerationController) SuspendUser(ctx *fiber.Ctx) error {
+	return c.moderateUser(ctx, "유저 정지", func(reqCtx context.Context, req *dto.ModerateUserRequest) *exception.ErrResponseCtx {
+		return c.moderationService.SuspendUser(reqCtx, requestMeta(ctx), req)
+	})
+}
+
+func (c *ModerationController) moderateThread(ctx *fiber.Ctx, action string, handler func(context.Context, *dto.ModerateThreadRequest) *exception.ErrResponseCtx) error {
+	var moderatePayload dto.ModerateThreadRequest
+	moderatePayload.ModeratorID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &moderatePayload, action); err != nil {
+		return err
+	}
+
+	if err := handler(ctx.Context(), &moderatePayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ "+action+" 완료"),
+	})
+}
+
+func (c *ModerationController) moderateUser(ctx *fiber.Ctx, action string, handler func(context.Context, *dto.ModerateUserRequest) *exception.ErrResponseCtx) error {
+	var moderatePayload dto.ModerateUserRequest
+	moderatePayload.ModeratorID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &moderatePayload, action); err != nil {
+		return err
+	}
+
+	if err := handler(ctx.Context(), &moderatePayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ "+action+" 완료"),
+	})
+}
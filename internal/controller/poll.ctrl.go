@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type PollController struct {
+	pollService *service.PollService
+}
+
+func NewPollController(service *service.PollService) *PollController {
+	return &PollController{pollService: service}
+}
+
+func initPollDI(dbconn *model.PrismaClient, rdconn *redis.Client) *PollController {
+	repository := repository.NewPollRepository(dbconn)
+	service := service.NewPollService(repository, rdconn)
+	handler := NewPollController(service)
+	return handler
+}
+
+func initPollRouter(router fiber.Router, handler *PollController) {
+	pollRouter := router.Group("/poll")
+	handler.Accessible(pollRouter)
+	handler.Restricted(pollRouter)
+}
+
+func (c *PollController) Accessible(router fiber.Router) {
+	router.Get("/:threadID", middleware.OptionalJWTMiddleware, c.GetPoll)
+}
+
+func (c *PollController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Post("/:threadID", c.CreatePoll)
+	router.Post("/:threadID/vote", c.Vote)
+	router.Post("/:threadID/close", c.ClosePoll)
+}
+
+func (c *PollController) CreatePoll(ctx *fiber.Ctx) error {
+	var createPollPayload dto.CreatePollRequest
+	createPollPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &createPollPayload, "투표 생성"); err != nil {
+		return err
+	}
+
+	poll, err := c.pollService.CreatePoll(ctx.Context(), &createPollPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.GetPollResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusCreated,
+		Message:    i18n.T(ctx, "✅ 투표 생성 완료"),
+		Poll:       *poll,
+	})
+}
+
+func (c *PollController) GetPoll(ctx *fiber.Ctx) error {
+	var getPollPayload dto.GetPollRequest
+	if err := utils.Bind(ctx, &getPollPayload, "투표 조회"); err != nil {
+		return err
+	}
+
+	poll, err := c.pollService.GetPoll(ctx.Context(), middleware.GetOptionalIdFromMiddleware(ctx), getPollPayload.ThreadID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.GetPollResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 투표 조회 완료"),
+		Poll:       *poll,
+	})
+}
+
+func (c *PollController) Vote(ctx *fiber.Ctx) error {
+	var votePayload dto.VotePollRequest
+	votePayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &votePayload, "투표"); err != nil {
+		return err
+	}
+
+	poll, err := c.pollService.Vote(ctx.Context(), &votePayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.GetPollResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 투표 완료"),
+		Poll:       *poll,
+	})
+}
+
+func (c *PollController) ClosePoll(ctx *fiber.Ctx) error {
+	var closePollPayload dto.ClosePollRequest
+	closePollPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &closePollPayload, "투표 마감"); err != nil {
+		return err
+	}
+	// 요청 값으로 권한을 덮어쓰지 못하도록 바인딩 이후에 채움
+	closePollPayload.Role = middleware.GetRoleFromMiddleware(ctx)
+
+	poll, err := c.pollService.ClosePoll(ctx.Context(), &closePollPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.GetPollResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 투표 마감 완료"),
+		Poll:       *poll,
+	})
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+type RealtimeController struct {
+	realtimeService *service.RealtimeService
+}
+
+func NewRealtimeController(service *service.RealtimeService) *RealtimeController {
+	return &RealtimeController{realtimeService: service}
+}
+
+func initRealtimeDI(stores stores, rdconn *redis.Client) *RealtimeController {
+	service := service.NewRealtimeService(stores.relation, rdconn)
+	handler := NewRealtimeController(service)
+	return handler
+}
+
+func initRealtimeRouter(router fiber.Router, handler *RealtimeController) {
+	realtimeRouter := router.Group("/realtime")
+	handler.Restricted(realtimeRouter)
+}
+
+func (c *RealtimeController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Get("/board", c.SubscribeBoard)
+	router.Get("/thread/:threadID", c.SubscribeThread)
+	router.Get("/me", c.SubscribeMe)
+}
+
+func (c *RealtimeController) SubscribeBoard(ctx *fiber.Ctx) error {
+	return c.stream(ctx, true, service.RealtimeBoardChannel())
+}
+
+func (c *RealtimeController) SubscribeThread(ctx *fiber.Ctx) error {
+	var subscribePayload dto.SubscribeThreadRequest
+	if err := utils.Bind(ctx, &subscribePayload, "쓰레드 구독"); err != nil {
+		return err
+	}
+	return c.stream(ctx, false, service.RealtimeThreadChannel(subscribePayload.ThreadID))
+}
+
+func (c *RealtimeController) SubscribeMe(ctx *fiber.Ctx) error {
+	return c.stream(ctx, false, service.RealtimeUserChannel(middleware.GetIdFromMiddleware(ctx)))
+}
+
+// 연결 중에 차단하거나 뮤트한 사용자도 걸러지도록 숨길 작성자 목록은 heartbeat마다 다시 불러옴
+func (c *RealtimeController) stream(ctx *fiber.Ctx, hideMuted bool, channels ...string) error {
+	viewerID := middleware.GetIdFromMiddleware(ctx)
+	hidden, err := c.realtimeService.HiddenAuthors(ctx.Context(), viewerID, hideMuted)
+	if err != nil {
+		return err
+	}
+	events, unsubscribe := c.realtimeService.Subscribe(channels...)
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if !service.IsVisibleEvent(event, hidden) {
+					continue
+				}
+				fmt.Fprintf(w, "data: %s\n\n", event)
+			case <-heartbeat.C:
+				// 요청이 끝난 뒤에 실행되므로 요청 ctx 대신 새 context를 사용하고, 실패하면 이전 목록을 유지함
+				if refreshed, err := c.realtimeService.HiddenAuthors(context.Background(), viewerID, hideMuted); err == nil {
+					hidden = refreshed
+				}
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// 클라이언트가 연결을 끊으면 Flush에서 에러가 발생하므로 구독 해제
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
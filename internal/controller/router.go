@@ -1,19 +1,105 @@
 package controller
 
 import (
+	"strings"
+	"time"
+
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
 	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/utils"
 )
 
-func EnrollRouter(app *fiber.App, dbconn *model.PrismaClient, rdconn *redis.Client) {
+/*
+서비스가 인터페이스로 받는 저장소 묶음.
+서버는 Prisma 구현을 넣고, HTTP 테스트는 DB 없이 돌 수 있도록 메모리 구현(repository/memory)을 넣음.
+*/
+type stores struct {
+	auth      repository.AuthStore
+	thread    repository.ThreadStore
+	relation  repository.RelationStore
+	audit     repository.AuditStore
+	users     repository.UserLookup
+	uploads   repository.UploadLookup
+	bookmarks repository.BookmarkLookup
+}
+
+func prismaStores(dbconn *model.PrismaClient) stores {
+	authRepository := repository.NewAuthRepository(dbconn)
+	return stores{
+		auth:      authRepository,
+		thread:    repository.NewThreadRepository(dbconn),
+		relation:  repository.NewRelationRepository(dbconn),
+		audit:     repository.NewAuditRepository(dbconn),
+		users:     authRepository,
+		uploads:   repository.NewUploadRepository(dbconn),
+		bookmarks: repository.NewBookmarkRepository(dbconn),
+	}
+}
+
+/*
+라우터를 등록하고, 서버가 떠 있는 동안 백그라운드에서 돌아야 하는 작업을 반환함.
+반환된 작업은 호출하는 쪽에서 service.StartWorkers로 실행하고 종료 시 정리해야 함.
+*/
+func EnrollRouter(app *fiber.App, dbconn *model.PrismaClient, rdconn *redis.Client) []service.Worker {
+	return enrollRouter(app, dbconn, prismaStores(dbconn), rdconn)
+}
+
+func enrollRouter(app *fiber.App, dbconn *model.PrismaClient, stores stores, rdconn *redis.Client) []service.Worker {
+	middleware.SetSessionCache(rdconn)
+	// 감사 로그는 하나의 큐로 모아 순서대로 기록하도록 모든 서비스가 같은 인스턴스를 공유
+	auditService := service.NewAuditService(stores.audit)
+	// 2단 캐시는 인스턴스마다 L1과 무효화 구독을 하나씩만 가지도록 모든 서비스가 같은 인스턴스를 공유
+	cacheStore := newCache(rdconn)
+	previewService := service.NewLinkPreviewService(rdconn)
+	threadHandler := initThreadDI(stores, rdconn, cacheStore, auditService, previewService)
+	uploadHandler := initUploadDI(dbconn, rdconn, newBlobStore())
+
 	apiRouter := app.Group("/api")
-	initAuthRouter(apiRouter, initAuthDI(dbconn, rdconn))
-	initThreadRouter(apiRouter, initThreadDI(dbconn, rdconn))
+	initAuthRouter(apiRouter, initAuthDI(stores, rdconn, cacheStore, auditService))
+	initThreadRouter(apiRouter, threadHandler)
+	initRealtimeRouter(apiRouter, initRealtimeDI(stores, rdconn))
+	initDirectMessageRouter(apiRouter, initDirectMessageDI(dbconn, stores, rdconn))
+	initUserRouter(apiRouter, initUserDI(dbconn, stores, rdconn, cacheStore))
+	initModerationRouter(apiRouter, initModerationDI(dbconn, rdconn, cacheStore, auditService))
+	initAdminRouter(apiRouter, initAdminDI(dbconn, stores, rdconn, cacheStore, auditService))
+	initUploadRouter(apiRouter, uploadHandler)
+	initPollRouter(apiRouter, initPollDI(dbconn, rdconn))
 
 	apiRouter.Get("/ping", func(ctx *fiber.Ctx) error {
 		return ctx.JSON(fiber.Map{
 			"message": "pong",
 		})
 	})
+
+	return []service.Worker{auditService, previewService, threadHandler.threadService, uploadHandler.uploadService}
+}
+
+func newCache(rdconn *redis.Client) cache.Cache {
+	switch config.Envs.CacheDriver {
+	case "memory":
+		return cache.NewMemoryCache(int(config.Envs.CacheLocalCapacity))
+	case "tiered":
+		return cache.NewTieredCache(rdconn, int(config.Envs.CacheLocalCapacity), time.Duration(config.Envs.CacheLocalTTLSeconds)*time.Second)
+	default:
+		return cache.NewRedisCache(rdconn)
+	}
+}
+
+/*
+감사 로그는 응답 뒤에 기록되는데 fiber가 돌려주는 문자열은 요청이 끝나면 재사용되는 버퍼를 가리키므로 복사해서 넘김.
+fiber의 ProxyHeader는 헤더 값을 검증 없이 그대로 돌려주므로 IP는 utils.ClientIP로 직접 구함.
+*/
+func requestMeta(ctx *fiber.Ctx) dto.RequestMeta {
+	return dto.RequestMeta{
+		IP:        utils.ClientIP(ctx.Context().RemoteIP().String(), ctx.Get(config.Envs.ProxyHeader), config.Envs.TrustedProxies),
+		UserAgent: strings.Clone(ctx.Get(fiber.HeaderUserAgent)),
+	}
 }
@@ -0,0 +1,363 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+/*
+각 단계가 앞 단계에서 만든 데이터를 사용하므로 하나의 시나리오로 순서대로 실행함.
+DB(또는 메모리 저장소)를 비운 뒤 시작하므로 autoincrement ID는 항상 같은 값이 나오고, 경로에도 그대로 적어둠.
+(쓰레드 1: alice 글, 2: bob 답글, 3: alice 임시 저장 글, 4: alice 첨부 글)
+메모리 저장소로 실행할 때는 withDB로 묶은 단계를 건너뛰고, 나머지 단계는 DB로 실행할 때와 같은 골든 파일과 비교함.
+*/
+func TestRoutes(t *testing.T) {
+	h := newTestHarness(t)
+
+	h.expect("ping", apiRequest{method: http.MethodGet, path: "/api/ping"}, fiber.StatusOK)
+
+	tokens := testAuthRoutes(t, h)
+	testThreadRoutes(t, h, tokens)
+	testUserRoutes(t, h, tokens)
+	h.withDB("uploads", func(t *testing.T) {
+		uploadID := testUploadRoutes(t, h, tokens)
+		testAttachmentRoutes(t, h, tokens, uploadID)
+	})
+	h.withDB("bookmarks", func(t *testing.T) { testBookmarkRoutes(t, h, tokens) })
+	h.withDB("dm", func(t *testing.T) { testDirectMessageRoutes(t, h, tokens) })
+	h.withDB("polls", func(t *testing.T) { testPollRoutes(t, h, tokens) })
+	h.withDB("admin", func(t *testing.T) { testAdminRoutes(t, h, tokens) })
+	h.withDB("moderation", func(t *testing.T) { testModerationRoutes(t, h, tokens) })
+	testRealtimeRoutes(t, h, tokens)
+	testCleanupRoutes(t, h, tokens)
+}
+
+type testTokens struct {
+	alice, bob, moderator, admin, dave string
+}
+
+func register(handle string) map[string]string {
+	return map[string]string{
+		"handle":          handle,
+		"name":            handle,
+		"password":        "password1234",
+		"passwordConfirm": "password1234",
+		"email":           handle + "@example.com",
+	}
+}
+
+func testAuthRoutes(t *testing.T, h *testHarness) testTokens {
+	for _, handle := range []string{"alice", "bob", "moderator", "admin", "dave"} {
+		h.expect("auth_register_"+handle, apiRequest{method: http.MethodPost, path: "/api/auth/register", body: register(handle)}, fiber.StatusCreated)
+	}
+	h.expect("auth_register_duplicate", apiRequest{method: http.MethodPost, path: "/api/auth/register", body: register("alice")}, fiber.StatusConflict)
+	h.expect("auth_register_invalid", apiRequest{method: http.MethodPost, path: "/api/auth/register", body: map[string]string{"handle": "nobody"}}, fiber.StatusBadRequest)
+
+	// 권한은 토큰에 담기므로 로그인 전에 DB에서 바로 바꿔둠
+	h.setRole("moderator", model.UserRolesModerator)
+	h.setRole("admin", model.UserRolesAdmin)
+
+	login := func(handle, password string, status int) string {
+		decoded := h.expect("auth_login_"+handle, apiRequest{
+			method: http.MethodPost,
+			path:   "/api/auth/login",
+			body:   map[string]string{"email": handle + "@example.com", "password": password},
+		}, status)
+		if status != fiber.StatusOK {
+			return ""
+		}
+		return stringField(t, decoded, "token")
+	}
+	tokens := testTokens{
+		alice:     login("alice", "password1234", fiber.StatusOK),
+		bob:       login("bob", "password1234", fiber.StatusOK),
+		moderator: login("moderator", "password1234", fiber.StatusOK),
+		admin:     login("admin", "password1234", fiber.StatusOK),
+		dave:      login("dave", "password1234", fiber.StatusOK),
+	}
+	h.expect("auth_login_wrong_password", apiRequest{
+		method: http.MethodPost,
+		path:   "/api/auth/login",
+		body:   map[string]string{"email": "alice@example.com", "password": "wrong"},
+	}, fiber.StatusUnauthorized)
+
+	h.expect("auth_reset_unauthorized", apiRequest{method: http.MethodPatch, path: "/api/auth/reset", body: map[string]string{}}, fiber.StatusUnauthorized)
+	h.expect("auth_reset_invalid_token", apiRequest{method: http.MethodPatch, path: "/api/auth/reset", token: "invalid", body: map[string]string{}}, fiber.StatusUnauthorized)
+	h.expect("auth_reset_mismatch", apiRequest{
+		method: http.MethodPatch,
+		path:   "/api/auth/reset",
+		token:  tokens.alice,
+		body:   map[string]string{"oldPassword": "password1234", "newPassword": "password5678", "newPasswordConfirm": "password9999"},
+	}, fiber.StatusBadRequest)
+	h.expect("auth_reset", apiRequest{
+		method: http.MethodPatch,
+		path:   "/api/auth/reset",
+		token:  tokens.alice,
+		body:   map[string]string{"oldPassword": "password1234", "newPassword": "password5678", "newPasswordConfirm": "password5678"},
+	}, fiber.StatusOK)
+	return tokens
+}
+
+func testUploadRoutes(t *testing.T, h *testHarness, tokens testTokens) string {
+	body, contentType := pngUploadBody(t, "cover.png")
+	decoded := h.expect("upload_create", apiRequest{method: http.MethodPost, path: "/api/uploads", token: tokens.alice, rawBody: body, contentType: contentType}, fiber.StatusCreated)
+	uploadID := stringField(t, decoded, "upload", "id")
+
+	h.expect("upload_create_missing_file", apiRequest{method: http.MethodPost, path: "/api/uploads", token: tokens.alice}, fiber.StatusBadRequest)
+	h.expect("upload_quota", apiRequest{method: http.MethodGet, path: "/api/uploads/me/quota", token: tokens.alice}, fiber.StatusOK)
+
+	// 파일 본문은 JSON이 아니므로 상태 코드와 헤더만 확인함
+	for _, path := range []string{"/api/uploads/" + uploadID, "/api/uploads/" + uploadID + "/thumbnail"} {
+		resp := h.do(apiRequest{method: http.MethodGet, path: path})
+		if resp.status != fiber.StatusOK {
+			t.Fatalf("GET %s = %d, want 200\n%s", path, resp.status, resp.body)
+		}
+		if contentType := resp.header.Get(fiber.HeaderContentType); contentType == "" || contentType == fiber.MIMEApplicationJSON {
+			t.Errorf("GET %s: content type = %q", path, contentType)
+		}
+		if len(resp.body) == 0 {
+			t.Errorf("GET %s: empty body", path)
+		}
+	}
+	h.expect("upload_get_invalid_id", apiRequest{method: http.MethodGet, path: "/api/uploads/not-a-uuid"}, fiber.StatusBadRequest)
+	h.expect("upload_get_missing", apiRequest{method: http.MethodGet, path: "/api/uploads/00000000-0000-4000-8000-000000000000"}, fiber.StatusNotFound)
+	return uploadID
+}
+
+func testThreadRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("thread_create", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.alice, body: map[string]interface{}{
+		"title":   "hello",
+		"content": "**first** thread",
+	}}, fiber.StatusCreated)
+	h.expect("thread_create_reply", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.bob, body: map[string]interface{}{
+		"title":        "re: hello",
+		"content":      "reply",
+		"parentThread": 1,
+	}}, fiber.StatusCreated)
+	h.expect("thread_create_draft", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.alice, body: map[string]interface{}{
+		"title":   "draft",
+		"content": "not yet",
+		"isDraft": true,
+	}}, fiber.StatusCreated)
+	h.expect("thread_create_unauthorized", apiRequest{method: http.MethodPost, path: "/api/thread", body: map[string]string{"content": "anonymous"}}, fiber.StatusUnauthorized)
+	h.expect("thread_create_invalid", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.alice, body: map[string]string{"title": "no content"}}, fiber.StatusBadRequest)
+
+	h.expect("thread_list", apiRequest{method: http.MethodGet, path: "/api/thread?pageNumber=1&pageSize=10"}, fiber.StatusOK)
+	h.expect("thread_list_by_handle", apiRequest{method: http.MethodGet, path: "/api/thread/user/alice"}, fiber.StatusOK)
+	h.expect("thread_list_by_handle_missing", apiRequest{method: http.MethodGet, path: "/api/thread/user/nobody"}, fiber.StatusNotFound)
+	decoded := h.expect("thread_get", apiRequest{method: http.MethodGet, path: "/api/thread/1", token: tokens.bob}, fiber.StatusOK)
+	aliceID := stringField(t, decoded, "thread", "author", "id")
+	h.expect("thread_get_invalid_id", apiRequest{method: http.MethodGet, path: "/api/thread/abc"}, fiber.StatusBadRequest)
+	h.expect("thread_get_missing", apiRequest{method: http.MethodGet, path: "/api/thread/9999"}, fiber.StatusNotFound)
+	h.expect("thread_get_draft_hidden", apiRequest{method: http.MethodGet, path: "/api/thread/3"}, fiber.StatusNotFound)
+
+	h.expect("thread_likes", apiRequest{method: http.MethodPost, path: "/api/thread/likes", token: tokens.bob, body: map[string]int{"threadID": 1}}, fiber.StatusOK)
+	h.expect("thread_dislikes", apiRequest{method: http.MethodPost, path: "/api/thread/dislikes", token: tokens.bob, body: map[string]int{"threadID": 1}}, fiber.StatusOK)
+	h.expect("thread_likes_missing", apiRequest{method: http.MethodPost, path: "/api/thread/likes", token: tokens.bob, body: map[string]int{"threadID": 9999}}, fiber.StatusNotFound)
+
+	h.expect("thread_drafts", apiRequest{method: http.MethodGet, path: "/api/thread/drafts", token: tokens.alice}, fiber.StatusOK)
+	h.expect("thread_drafts_unauthorized", apiRequest{method: http.MethodGet, path: "/api/thread/drafts"}, fiber.StatusUnauthorized)
+	h.expect("thread_draft_save", apiRequest{method: http.MethodPatch, path: "/api/thread/drafts/3", token: tokens.alice, body: map[string]string{"title": "draft v2"}}, fiber.StatusOK)
+	h.expect("thread_draft_save_other_user", apiRequest{method: http.MethodPatch, path: "/api/thread/drafts/3", token: tokens.bob, body: map[string]string{"title": "mine"}}, fiber.StatusNotFound)
+	h.expect("thread_draft_schedule", apiRequest{method: http.MethodPost, path: "/api/thread/drafts/3/schedule", token: tokens.alice, body: map[string]interface{}{
+		"publishAt": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	}}, fiber.StatusOK)
+	h.expect("thread_draft_unschedule", apiRequest{method: http.MethodDelete, path: "/api/thread/drafts/3/schedule", token: tokens.alice}, fiber.StatusOK)
+	h.expect("thread_draft_publish", apiRequest{method: http.MethodPost, path: "/api/thread/drafts/3/publish", token: tokens.alice}, fiber.StatusOK)
+	h.expect("thread_draft_publish_again", apiRequest{method: http.MethodPost, path: "/api/thread/drafts/3/publish", token: tokens.alice}, fiber.StatusConflict)
+
+	h.expect("thread_remove_other_user", apiRequest{method: http.MethodDelete, path: "/api/thread/1", token: tokens.bob}, fiber.StatusForbidden)
+	// 삭제하는 사용자는 토큰에서만 정해지므로 본문에 작성자 ID를 넣어도 무시됨
+	h.expect("thread_remove_spoofed_owner", apiRequest{method: http.MethodDelete, path: "/api/thread/1", token: tokens.bob, body: map[string]string{"id": aliceID}}, fiber.StatusForbidden)
+	h.expect("thread_remove_missing", apiRequest{method: http.MethodDelete, path: "/api/thread/9999", token: tokens.alice}, fiber.StatusNotFound)
+}
+
+// 업로드 저장소가 필요하므로 업로드 단계에서 이어서 실행함
+func testAttachmentRoutes(t *testing.T, h *testHarness, tokens testTokens, uploadID string) {
+	h.expect("thread_create_attachment", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.alice, body: map[string]interface{}{
+		"title":       "cover",
+		"content":     "with image",
+		"attachments": []map[string]string{{"uploadID": uploadID, "altText": "gradient"}},
+	}}, fiber.StatusCreated)
+	h.expect("thread_create_attachment_reused", apiRequest{method: http.MethodPost, path: "/api/thread", token: tokens.alice, body: map[string]interface{}{
+		"title":       "again",
+		"content":     "same image",
+		"attachments": []map[string]string{{"uploadID": uploadID}},
+	}}, fiber.StatusConflict)
+}
+
+func testUserRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	for _, relation := range []string{"block", "mute"} {
+		h.expect("user_"+relation, apiRequest{method: http.MethodPost, path: "/api/users/dave/" + relation, token: tokens.bob}, fiber.StatusOK)
+		h.expect("user_"+relation+"_list", apiRequest{method: http.MethodGet, path: "/api/users/me/" + relation + "s", token: tokens.bob}, fiber.StatusOK)
+		h.expect("user_un"+relation, apiRequest{method: http.MethodDelete, path: "/api/users/dave/" + relation, token: tokens.bob}, fiber.StatusOK)
+	}
+	h.expect("user_block_author", apiRequest{method: http.MethodPost, path: "/api/users/alice/block", token: tokens.bob}, fiber.StatusOK)
+	h.expect("thread_get_blocked_author", apiRequest{method: http.MethodGet, path: "/api/thread/1", token: tokens.bob}, fiber.StatusNotFound)
+	h.expect("user_unblock_author", apiRequest{method: http.MethodDelete, path: "/api/users/alice/block", token: tokens.bob}, fiber.StatusOK)
+	h.expect("user_block_missing", apiRequest{method: http.MethodPost, path: "/api/users/nobody/block", token: tokens.bob}, fiber.StatusNotFound)
+}
+
+func testBookmarkRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("bookmark_folder_create", apiRequest{method: http.MethodPost, path: "/api/users/me/bookmark-folders", token: tokens.bob, body: map[string]string{"name": "reading"}}, fiber.StatusCreated)
+	h.expect("bookmark_folder_list", apiRequest{method: http.MethodGet, path: "/api/users/me/bookmark-folders", token: tokens.bob}, fiber.StatusOK)
+	h.expect("bookmark_create", apiRequest{method: http.MethodPost, path: "/api/users/me/bookmarks", token: tokens.bob, body: map[string]interface{}{
+		"threadID": 1,
+		"folderID": 1,
+		"note":     "read later",
+	}}, fiber.StatusCreated)
+	h.expect("bookmark_create_duplicate", apiRequest{method: http.MethodPost, path: "/api/users/me/bookmarks", token: tokens.bob, body: map[string]int{"threadID": 1}}, fiber.StatusConflict)
+	h.expect("bookmark_list", apiRequest{method: http.MethodGet, path: "/api/users/me/bookmarks?folderID=1", token: tokens.bob}, fiber.StatusOK)
+	h.expect("bookmark_update", apiRequest{method: http.MethodPatch, path: "/api/users/me/bookmarks/1", token: tokens.bob, body: map[string]interface{}{"folderID": 0}}, fiber.StatusOK)
+	h.expect("bookmark_update_other_user", apiRequest{method: http.MethodPatch, path: "/api/users/me/bookmarks/1", token: tokens.alice, body: map[string]interface{}{"note": "mine"}}, fiber.StatusNotFound)
+	h.expect("bookmark_delete", apiRequest{method: http.MethodDelete, path: "/api/users/me/bookmarks/1", token: tokens.bob}, fiber.StatusOK)
+	h.expect("bookmark_folder_delete", apiRequest{method: http.MethodDelete, path: "/api/users/me/bookmark-folders/1", token: tokens.bob}, fiber.StatusOK)
+}
+
+func testDirectMessageRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("dm_create", apiRequest{method: http.MethodPost, path: "/api/dm", token: tokens.bob, body: map[string][]string{"handles": {"alice"}}}, fiber.StatusCreated)
+	h.expect("dm_create_invalid", apiRequest{method: http.MethodPost, path: "/api/dm", token: tokens.bob, body: map[string][]string{"handles": {}}}, fiber.StatusBadRequest)
+	h.expect("dm_list", apiRequest{method: http.MethodGet, path: "/api/dm", token: tokens.bob}, fiber.StatusOK)
+	h.expect("dm_send", apiRequest{method: http.MethodPost, path: "/api/dm/1/messages", token: tokens.bob, body: map[string]string{"content": "hi alice"}}, fiber.StatusCreated)
+	h.expect("dm_send_not_participant", apiRequest{method: http.MethodPost, path: "/api/dm/1/messages", token: tokens.dave, body: map[string]string{"content": "let me in"}}, fiber.StatusNotFound)
+	h.expect("dm_messages", apiRequest{method: http.MethodGet, path: "/api/dm/1/messages", token: tokens.alice}, fiber.StatusOK)
+	h.expect("dm_read", apiRequest{method: http.MethodPost, path: "/api/dm/1/read", token: tokens.alice, body: map[string]int{"messageID": 1}}, fiber.StatusOK)
+	h.expect("dm_read_unknown_message", apiRequest{method: http.MethodPost, path: "/api/dm/1/read", token: tokens.alice, body: map[string]int{"messageID": 2147483647}}, fiber.StatusNotFound)
+	h.expect("dm_mute", apiRequest{method: http.MethodPatch, path: "/api/dm/1/mute", token: tokens.alice, body: map[string]bool{"muted": true}}, fiber.StatusOK)
+	h.expect("dm_leave", apiRequest{method: http.MethodDelete, path: "/api/dm/1", token: tokens.alice}, fiber.StatusOK)
+}
+
+func testPollRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("poll_create", apiRequest{method: http.MethodPost, path: "/api/poll/1", token: tokens.alice, body: map[string]interface{}{
+		"question": "tabs or spaces?",
+		"options":  []string{"tabs", "spaces"},
+	}}, fiber.StatusCreated)
+	h.expect("poll_create_not_author", apiRequest{method: http.MethodPost, path: "/api/poll/4", token: tokens.bob, body: map[string]interface{}{
+		"question": "mine?",
+		"options":  []string{"yes", "no"},
+	}}, fiber.StatusForbidden)
+	h.expect("poll_create_duplicate", apiRequest{method: http.MethodPost, path: "/api/poll/1", token: tokens.alice, body: map[string]interface{}{
+		"question": "again?",
+		"options":  []string{"yes", "no"},
+	}}, fiber.StatusConflict)
+	h.expect("poll_vote", apiRequest{method: http.MethodPost, path: "/api/poll/1/vote", token: tokens.bob, body: map[string][]int{"optionIDs": {1}}}, fiber.StatusOK)
+	h.expect("poll_vote_again", apiRequest{method: http.MethodPost, path: "/api/poll/1/vote", token: tokens.bob, body: map[string][]int{"optionIDs": {2}}}, fiber.StatusConflict)
+	h.expect("poll_vote_invalid_option", apiRequest{method: http.MethodPost, path: "/api/poll/1/vote", token: tokens.dave, body: map[string][]int{"optionIDs": {9999}}}, fiber.StatusBadRequest)
+	h.expect("poll_get", apiRequest{method: http.MethodGet, path: "/api/poll/1", token: tokens.bob}, fiber.StatusOK)
+	h.expect("poll_close_not_author", apiRequest{method: http.MethodPost, path: "/api/poll/1/close", token: tokens.bob}, fiber.StatusForbidden)
+	h.expect("poll_close", apiRequest{method: http.MethodPost, path: "/api/poll/1/close", token: tokens.alice}, fiber.StatusOK)
+	h.expect("poll_get_missing", apiRequest{method: http.MethodGet, path: "/api/poll/4"}, fiber.StatusNotFound)
+}
+
+func testAdminRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("admin_forbidden", apiRequest{method: http.MethodGet, path: "/api/admin/bans/users", token: tokens.bob}, fiber.StatusForbidden)
+
+	h.expect("admin_ban_user", apiRequest{method: http.MethodPost, path: "/api/admin/bans/users/dave", token: tokens.admin, body: map[string]interface{}{
+		"reason":        "spam",
+		"durationHours": 24,
+	}}, fiber.StatusOK)
+	h.expect("admin_banned_user_request", apiRequest{method: http.MethodGet, path: "/api/users/me/blocks", token: tokens.dave}, fiber.StatusForbidden)
+	h.expect("admin_ban_unknown_field", apiRequest{method: http.MethodPost, path: "/api/admin/bans/users/dave", token: tokens.admin, body: map[string]interface{}{
+		"reason":       "spam",
+		"durationHour": 24,
+	}}, fiber.StatusBadRequest)
+	h.expect("admin_ban_self", apiRequest{method: http.MethodPost, path: "/api/admin/bans/users/admin", token: tokens.admin, body: map[string]string{"reason": "oops"}}, fiber.StatusBadRequest)
+	h.expect("admin_list_bans", apiRequest{method: http.MethodGet, path: "/api/admin/bans/users", token: tokens.admin}, fiber.StatusOK)
+	h.expect("admin_unban_user", apiRequest{method: http.MethodDelete, path: "/api/admin/bans/users/dave", token: tokens.admin}, fiber.StatusOK)
+
+	h.expect("admin_ban_ip", apiRequest{method: http.MethodPost, path: "/api/admin/bans/ips", token: tokens.admin, body: map[string]string{
+		"cidr":   "198.51.100.0/24",
+		"reason": "abuse",
+	}}, fiber.StatusOK)
+	h.expect("admin_ban_ip_invalid", apiRequest{method: http.MethodPost, path: "/api/admin/bans/ips", token: tokens.admin, body: map[string]string{
+		"cidr":   "not-an-ip",
+		"reason": "abuse",
+	}}, fiber.StatusBadRequest)
+	h.expect("admin_list_ip_bans", apiRequest{method: http.MethodGet, path: "/api/admin/bans/ips", token: tokens.admin}, fiber.StatusOK)
+	h.expect("admin_unban_ip", apiRequest{method: http.MethodDelete, path: "/api/admin/bans/ips/1", token: tokens.admin}, fiber.StatusOK)
+
+	h.expect("admin_change_role", apiRequest{method: http.MethodPatch, path: "/api/admin/users/dave/role", token: tokens.admin, body: map[string]string{"role": "USER"}}, fiber.StatusOK)
+	h.expect("admin_change_role_invalid", apiRequest{method: http.MethodPatch, path: "/api/admin/users/dave/role", token: tokens.admin, body: map[string]string{"role": "OWNER"}}, fiber.StatusBadRequest)
+
+	auditRequest := apiRequest{method: http.MethodGet, path: "/api/admin/audit?event=IP_BAN", token: tokens.admin}
+	h.eventually(auditRequest, func(decoded map[string]interface{}) bool {
+		logs, _ := decoded["auditLogs"].([]interface{})
+		return len(logs) > 0
+	})
+	h.expect("admin_audit", auditRequest, fiber.StatusOK)
+	h.expect("admin_audit_invalid_event", apiRequest{method: http.MethodGet, path: "/api/admin/audit?event=UNKNOWN", token: tokens.admin}, fiber.StatusBadRequest)
+
+	h.expect("admin_purge_cache", apiRequest{method: http.MethodDelete, path: "/api/admin/cache?pattern=thread:*", token: tokens.admin}, fiber.StatusOK)
+	h.expect("admin_purge_cache_missing_pattern", apiRequest{method: http.MethodDelete, path: "/api/admin/cache", token: tokens.admin}, fiber.StatusBadRequest)
+}
+
+func testModerationRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("report_create", apiRequest{method: http.MethodPost, path: "/api/reports", token: tokens.bob, body: map[string]interface{}{
+		"targetType": "THREAD",
+		"threadID":   1,
+		"reason":     "SPAM",
+	}}, fiber.StatusCreated)
+	h.expect("report_create_duplicate", apiRequest{method: http.MethodPost, path: "/api/reports", token: tokens.bob, body: map[string]interface{}{
+		"targetType": "THREAD",
+		"threadID":   1,
+		"reason":     "SPAM",
+	}}, fiber.StatusConflict)
+	h.expect("report_create_own_thread", apiRequest{method: http.MethodPost, path: "/api/reports", token: tokens.alice, body: map[string]interface{}{
+		"targetType": "THREAD",
+		"threadID":   1,
+		"reason":     "OTHER",
+	}}, fiber.StatusBadRequest)
+
+	h.expect("mod_forbidden", apiRequest{method: http.MethodGet, path: "/api/mod/reports", token: tokens.bob}, fiber.StatusForbidden)
+	h.expect("mod_list_reports", apiRequest{method: http.MethodGet, path: "/api/mod/reports?status=OPEN", token: tokens.moderator}, fiber.StatusOK)
+	h.expect("mod_update_report", apiRequest{method: http.MethodPatch, path: "/api/mod/reports/1", token: tokens.moderator, body: map[string]string{"status": "DISMISSED"}}, fiber.StatusOK)
+	h.expect("mod_update_report_again", apiRequest{method: http.MethodPatch, path: "/api/mod/reports/1", token: tokens.moderator, body: map[string]string{"status": "ACTIONED"}}, fiber.StatusConflict)
+
+	for _, action := range []string{"hide", "lock"} {
+		h.expect("mod_"+action+"_thread", apiRequest{method: http.MethodPost, path: "/api/mod/thread/4/" + action, token: tokens.moderator, body: map[string]string{"note": action}}, fiber.StatusOK)
+		h.expect("mod_un"+action+"_thread", apiRequest{method: http.MethodDelete, path: "/api/mod/thread/4/" + action, token: tokens.moderator, body: map[string]string{}}, fiber.StatusOK)
+	}
+	h.expect("mod_pin_thread", apiRequest{method: http.MethodPost, path: "/api/mod/thread/4/pin", token: tokens.moderator, body: map[string]int{"durationHours": 1}}, fiber.StatusOK)
+	h.expect("mod_pin_reply", apiRequest{method: http.MethodPost, path: "/api/mod/thread/2/pin", token: tokens.moderator, body: map[string]int{"durationHours": 1}}, fiber.StatusBadRequest)
+	h.expect("mod_pinned_list", apiRequest{method: http.MethodGet, path: "/api/thread"}, fiber.StatusOK)
+	h.expect("mod_unpin_thread", apiRequest{method: http.MethodDelete, path: "/api/mod/thread/4/pin", token: tokens.moderator, body: map[string]string{}}, fiber.StatusOK)
+
+	h.expect("mod_warn_user", apiRequest{method: http.MethodPost, path: "/api/mod/users/dave/warn", token: tokens.moderator, body: map[string]string{"reason": "be nice"}}, fiber.StatusOK)
+	h.expect("mod_warn_admin", apiRequest{method: http.MethodPost, path: "/api/mod/users/admin/warn", token: tokens.moderator, body: map[string]string{"reason": "be nice"}}, fiber.StatusForbidden)
+	h.expect("mod_suspend_user", apiRequest{method: http.MethodPost, path: "/api/mod/users/dave/suspend", token: tokens.moderator, body: map[string]interface{}{
+		"reason":        "spam",
+		"durationHours": 1,
+	}}, fiber.StatusOK)
+	h.expect("mod_list_actions", apiRequest{method: http.MethodGet, path: "/api/mod/actions", token: tokens.moderator}, fiber.StatusOK)
+}
+
+// 구독 요청은 스트림이 끝나지 않으므로 스트림을 열기 전에 거부되는 경우만 확인함
+func testRealtimeRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	for _, path := range []string{"/api/realtime/board", "/api/realtime/thread/1", "/api/realtime/me"} {
+		resp := h.do(apiRequest{method: http.MethodGet, path: path})
+		if resp.status != fiber.StatusUnauthorized {
+			t.Errorf("GET %s without token = %d, want 401", path, resp.status)
+		}
+	}
+	h.expect("realtime_unauthorized", apiRequest{method: http.MethodGet, path: "/api/realtime/board"}, fiber.StatusUnauthorized)
+	h.expect("realtime_thread_invalid_id", apiRequest{method: http.MethodGet, path: "/api/realtime/thread/abc", token: tokens.bob}, fiber.StatusBadRequest)
+}
+
+// 삭제, 탈퇴는 다른 단계가 사용하는 데이터를 지우므로 마지막에 실행함
+func testCleanupRoutes(t *testing.T, h *testHarness, tokens testTokens) {
+	h.expect("thread_remove", apiRequest{method: http.MethodDelete, path: "/api/thread/2", token: tokens.bob}, fiber.StatusOK)
+	h.expect("thread_get_removed", apiRequest{method: http.MethodGet, path: "/api/thread/2"}, fiber.StatusNotFound)
+
+	h.expect("auth_withdraw", apiRequest{method: http.MethodDelete, path: "/api/auth/withdraw", token: tokens.alice}, fiber.StatusOK)
+	h.expect("thread_list_after_withdraw", apiRequest{method: http.MethodGet, path: "/api/thread"}, fiber.StatusOK)
+	h.expect("auth_login_withdrawn", apiRequest{
+		method: http.MethodPost,
+		path:   "/api/auth/login",
+		body:   map[string]string{"email": "alice@example.com", "password": "password5678"},
+	}, fiber.StatusUnauthorized)
+}
@@ -6,9 +6,9 @@ import (
 
 	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/internal/middleware"
-	"github.com/kitae0522/gommunity/internal/model"
-	"github.com/kitae0522/gommunity/internal/repository"
 	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/i18n"
 	"github.com/kitae0522/gommunity/pkg/utils"
 )
 
@@ -20,9 +20,8 @@ func NewThreadController(service *service.ThreadService) *ThreadController {
 	return &ThreadController{threadService: service}
 }
 
-func initThreadDI(dbconn *model.PrismaClient, rdconn *redis.Client) *ThreadController {
-	repository := repository.NewThreadRepository(dbconn)
-	service := service.NewThreadService(repository, rdconn)
+func initThreadDI(stores stores, rdconn *redis.Client, cacheStore cache.Cache, auditService *service.AuditService, previewService *service.LinkPreviewService) *ThreadController {
+	service := service.NewThreadService(stores.thread, stores.relation, stores.users, stores.uploads, stores.bookmarks, auditService, previewService, cacheStore, rdconn)
 	handler := NewThreadController(service)
 	return handler
 }
@@ -34,9 +33,11 @@ func initThreadRouter(router fiber.Router, handler *ThreadController) {
 }
 
 func (c *ThreadController) Accessible(router fiber.Router) {
-	router.Get("", c.ListThread)
-	router.Get("/user/:handle", c.ListThreadByHandle)
-	router.Get("/:threadID", c.GetThreadByID)
+	router.Get("", middleware.OptionalJWTMiddleware, c.ListThread)
+	router.Get("/user/:handle", middleware.OptionalJWTMiddleware, c.ListThreadByHandle)
+	// "/:threadID"보다 먼저 등록해야 drafts가 쓰레드 ID로 해석되지 않음
+	router.Get("/drafts", middleware.JWTMiddleware, c.ListDrafts)
+	router.Get("/:threadID", middleware.OptionalJWTMiddleware, c.GetThreadByID)
 }
 
 func (c *ThreadController) Restricted(router fiber.Router) {
@@ -45,6 +46,10 @@ func (c *ThreadController) Restricted(router fiber.Router) {
 	router.Delete("/:threadID", c.RemoveThreadByID)
 	router.Post("/likes", c.IncrementLikes)
 	router.Post("/dislikes", c.IncrementDislikes)
+	router.Patch("/drafts/:threadID", c.SaveDraft)
+	router.Post("/drafts/:threadID/schedule", c.ScheduleDraft)
+	router.Delete("/drafts/:threadID/schedule", c.UnscheduleDraft)
+	router.Post("/drafts/:threadID/publish", c.PublishDraft)
 }
 
 func (c *ThreadController) CreateThread(ctx *fiber.Ctx) error {
@@ -52,18 +57,18 @@ func (c *ThreadController) CreateThread(ctx *fiber.Ctx) error {
 	createThreadPayload.UserID = middleware.GetIdFromMiddleware(ctx)
 
 	if err := utils.Bind(ctx, &createThreadPayload, "쓰레드 생성"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	thread, err := c.threadService.CreateThread(ctx.Context(), &createThreadPayload)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(dto.CreateThreadReponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 쓰레드 생성 완료",
+		Message:    i18n.T(ctx, "✅ 쓰레드 생성 완료"),
 		Thread:     *thread,
 	})
 }
@@ -71,18 +76,18 @@ func (c *ThreadController) CreateThread(ctx *fiber.Ctx) error {
 func (c *ThreadController) ListThread(ctx *fiber.Ctx) error {
 	var listThreadPayload dto.ListThreadRequest
 	if err := utils.Bind(ctx, &listThreadPayload, "전체 쓰레드 조회"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	threads, err := c.threadService.ListThread(ctx.Context(), listThreadPayload.PageNumber, listThreadPayload.PageSize)
+	threads, err := c.threadService.ListThread(ctx.Context(), middleware.GetOptionalIdFromMiddleware(ctx), listThreadPayload.PageNumber, listThreadPayload.PageSize)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.ListThreadResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 모든 쓰레드 조회 완료",
+		Message:    i18n.T(ctx, "✅ 모든 쓰레드 조회 완료"),
 		Threads:    threads,
 	})
 }
@@ -90,18 +95,18 @@ func (c *ThreadController) ListThread(ctx *fiber.Ctx) error {
 func (c *ThreadController) ListThreadByHandle(ctx *fiber.Ctx) error {
 	var listThreadPayload dto.ListThreadByHandleRequest
 	if err := utils.Bind(ctx, &listThreadPayload, "모든 쓰레드 조회"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	threads, err := c.threadService.ListThreadByHandle(ctx.Context(), listThreadPayload.Handle)
+	threads, err := c.threadService.ListThreadByHandle(ctx.Context(), middleware.GetOptionalIdFromMiddleware(ctx), listThreadPayload.Handle)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.ListThreadByHandleResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 모든 쓰레드 조회 완료",
+		Message:    i18n.T(ctx, "✅ 모든 쓰레드 조회 완료"),
 		Handle:     listThreadPayload.Handle,
 		Threads:    threads,
 	})
@@ -110,24 +115,24 @@ func (c *ThreadController) ListThreadByHandle(ctx *fiber.Ctx) error {
 func (c *ThreadController) GetThreadByID(ctx *fiber.Ctx) error {
 	var getThreadPayload dto.GetThreadByIDRequest
 	if err := utils.Bind(ctx, &getThreadPayload, "쓰레드 조회"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	thread, err := c.threadService.GetThreadByID(ctx.Context(), getThreadPayload.ThreadID)
+	thread, err := c.threadService.GetThreadByID(ctx.Context(), requestMeta(ctx), middleware.GetOptionalIdFromMiddleware(ctx), getThreadPayload.ThreadID)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	comments, err := c.threadService.CommentsByID(ctx.Context(), getThreadPayload.ThreadID)
+	comments, err := c.threadService.CommentsByID(ctx.Context(), middleware.GetOptionalIdFromMiddleware(ctx), getThreadPayload.ThreadID)
 	if err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.GetThreadByIDResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 쓰레드 조회 완료",
-		Thread:     thread,
+		Message:    i18n.T(ctx, "✅ 쓰레드 조회 완료"),
+		Thread:     *thread,
 		SubThread:  comments,
 	})
 }
@@ -136,50 +141,146 @@ func (c *ThreadController) RemoveThreadByID(ctx *fiber.Ctx) error {
 	var removeThreadPayload dto.RemoveThreadByIDRequest
 	removeThreadPayload.ID = middleware.GetIdFromMiddleware(ctx)
 	if err := utils.Bind(ctx, &removeThreadPayload, "쓰레드 삭제"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	if err := c.threadService.RemoveThreadByID(ctx.Context(), removeThreadPayload.ID, removeThreadPayload.ThreadID); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+	if err := c.threadService.RemoveThreadByID(ctx.Context(), requestMeta(ctx), removeThreadPayload.ID, removeThreadPayload.ThreadID); err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusOK,
-		Message:    "✅ 쓰레드 삭제 완료",
+		Message:    i18n.T(ctx, "✅ 쓰레드 삭제 완료"),
 	})
 }
 
 func (c *ThreadController) IncrementLikes(ctx *fiber.Ctx) error {
 	var itractionPayload dto.InteractionRequest
+	itractionPayload.UserID = middleware.GetIdFromMiddleware(ctx)
 	if err := utils.Bind(ctx, &itractionPayload, "좋아요 수 증가"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	if err := c.threadService.IncrementLikes(ctx.Context(), itractionPayload.ThreadID); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+	if err := c.threadService.IncrementLikes(ctx.Context(), itractionPayload.UserID, itractionPayload.ThreadID); err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusNoContent).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusNoContent,
-		Message:    "✅ 쓰레드 좋아요 증가 완료",
+		Message:    i18n.T(ctx, "✅ 쓰레드 좋아요 증가 완료"),
 	})
 }
 
 func (c *ThreadController) IncrementDislikes(ctx *fiber.Ctx) error {
 	var itractionPayload dto.InteractionRequest
+	itractionPayload.UserID = middleware.GetIdFromMiddleware(ctx)
 	if err := utils.Bind(ctx, &itractionPayload, "싫어요 수 증가"); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+		return err
 	}
 
-	if err := c.threadService.IncrementDislikes(ctx.Context(), itractionPayload.ThreadID); err != nil {
-		return ctx.Status(err.StatusCode).JSON(err)
+	if err := c.threadService.IncrementDislikes(ctx.Context(), itractionPayload.UserID, itractionPayload.ThreadID); err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusNoContent).JSON(dto.DefaultResponse{
 		IsError:    false,
 		StatusCode: fiber.StatusNoContent,
-		Message:    "✅ 쓰레드 싫어요 증가 완료",
+		Message:    i18n.T(ctx, "✅ 쓰레드 싫어요 증가 완료"),
+	})
+}
+
+func (c *ThreadController) ListDrafts(ctx *fiber.Ctx) error {
+	drafts, err := c.threadService.ListDrafts(ctx.Context(), middleware.GetIdFromMiddleware(ctx))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListDraftResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 임시 저장 글 조회 완료"),
+		Drafts:     drafts,
+	})
+}
+
+func (c *ThreadController) SaveDraft(ctx *fiber.Ctx) error {
+	var saveDraftPayload dto.SaveDraftRequest
+	saveDraftPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &saveDraftPayload, "임시 저장"); err != nil {
+		return err
+	}
+
+	draft, err := c.threadService.SaveDraft(ctx.Context(), &saveDraftPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.SaveDraftResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 임시 저장 완료"),
+		Draft:      *draft,
+	})
+}
+
+func (c *ThreadController) ScheduleDraft(ctx *fiber.Ctx) error {
+	var scheduleDraftPayload dto.ScheduleDraftRequest
+	scheduleDraftPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &scheduleDraftPayload, "발행 예약"); err != nil {
+		return err
+	}
+
+	draft, err := c.threadService.ScheduleDraft(ctx.Context(), &scheduleDraftPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.SaveDraftResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 발행 예약 완료"),
+		Draft:      *draft,
+	})
+}
+
+func (c *ThreadController) UnscheduleDraft(ctx *fiber.Ctx) error {
+	var draftPayload dto.DraftRequest
+	draftPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &draftPayload, "발행 예약 취소"); err != nil {
+		return err
+	}
+
+	draft, err := c.threadService.UnscheduleDraft(ctx.Context(), &draftPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.SaveDraftResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 발행 예약 취소 완료"),
+		Draft:      *draft,
+	})
+}
+
+func (c *ThreadController) PublishDraft(ctx *fiber.Ctx) error {
+	var draftPayload dto.DraftRequest
+	draftPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &draftPayload, "쓰레드 발행"); err != nil {
+		return err
+	}
+
+	thread, err := c.threadService.PublishDraft(ctx.Context(), &draftPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.CreateThreadReponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 쓰레드 발행 완료"),
+		Thread:     *thread,
 	})
 }
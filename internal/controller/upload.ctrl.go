@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/storage"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type UploadController struct {
+	uploadService *service.UploadService
+}
+
+func NewUploadController(service *service.UploadService) *UploadController {
+	return &UploadController{uploadService: service}
+}
+
+func initUploadDI(dbconn *model.PrismaClient, rdconn *redis.Client, blobStore storage.BlobStore) *UploadController {
+	repository := repository.NewUploadRepository(dbconn)
+	service := service.NewUploadService(repository, blobStore, rdconn)
+	handler := NewUploadController(service)
+	return handler
+}
+
+func initUploadRouter(router fiber.Router, handler *UploadController) {
+	uploadRouter := router.Group("/uploads")
+	handler.Accessible(uploadRouter)
+	handler.Restricted(uploadRouter)
+}
+
+func newBlobStore() storage.BlobStore {
+	switch config.Envs.StorageDriver {
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  config.Envs.S3Endpoint,
+			Region:    config.Envs.S3Region,
+			Bucket:    config.Envs.S3Bucket,
+			AccessKey: config.Envs.S3AccessKey,
+			SecretKey: config.Envs.S3SecretKey,
+			PathStyle: config.Envs.S3PathStyle,
+		})
+	default:
+		return storage.NewLocalStore(config.Envs.StorageLocalDir)
+	}
+}
+
+func (c *UploadController) Accessible(router fiber.Router) {
+	router.Get("/:uploadID", c.GetUpload)
+	router.Get("/:uploadID/thumbnail", c.GetThumbnail)
+}
+
+func (c *UploadController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Post("/", c.UploadFile)
+	router.Get("/me/quota", c.GetStorageQuota)
+}
+
+func (c *UploadController) UploadFile(ctx *fiber.Ctx) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. file 필드가 필요합니다.", exception.ErrMissingParams)
+	}
+	if fileHeader.Size > config.Envs.UploadMaxBytes {
+		return exception.GenerateErrorCtx(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("❌ 파일 업로드 실패. 파일은 %dMB를 넘을 수 없습니다.", config.Envs.UploadMaxBytes/1024/1024), exception.ErrFileTooLarge)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. 파일을 읽을 수 없습니다.", err)
+	}
+	defer file.Close()
+
+	// 헤더의 Size를 속인 경우에도 제한보다 1바이트만 더 읽어 서비스에서 걸러지도록 함
+	data, err := io.ReadAll(io.LimitReader(file, config.Envs.UploadMaxBytes+1))
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. 파일을 읽을 수 없습니다.", err)
+	}
+
+	upload, errCtx := c.uploadService.UploadFile(ctx.Context(), &dto.UploadFileRequest{
+		UserID:   middleware.GetIdFromMiddleware(ctx),
+		Filename: fileHeader.Filename,
+		Data:     data,
+	})
+	if errCtx != nil {
+		return errCtx
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.UploadFileResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusCreated,
+		Message:    i18n.T(ctx, "✅ 파일 업로드 완료"),
+		Upload:     *upload,
+	})
+}
+
+func (c *UploadController) GetUpload(ctx *fiber.Ctx) error {
+	return c.sendUpload(ctx, false)
+}
+
+func (c *UploadController) GetThumbnail(ctx *fiber.Ctx) error {
+	return c.sendUpload(ctx, true)
+}
+
+func (c *UploadController) sendUpload(ctx *fiber.Ctx, thumbnail bool) error {
+	var getUploadPayload dto.GetUploadRequest
+	if err := utils.Bind(ctx, &getUploadPayload, "파일 조회"); err != nil {
+		return err
+	}
+
+	blob, err := c.uploadService.OpenUpload(ctx.Context(), getUploadPayload.UploadID, thumbnail)
+	if err != nil {
+		return err
+	}
+
+	// 이미지가 아닌 파일은 브라우저에서 바로 열리지 않도록 다운로드로 내려줌
+	if !blob.Inline {
+		ctx.Attachment(blob.Filename)
+	}
+	// 업로드된 파일은 수정되지 않으므로 오래 캐시해도 안전함
+	ctx.Set(fiber.HeaderContentType, blob.ContentType)
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	ctx.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return ctx.SendStream(blob.Reader)
+}
+
+func (c *UploadController) GetStorageQuota(ctx *fiber.Ctx) error {
+	usage, err := c.uploadService.GetStorageUsage(ctx.Context(), middleware.GetIdFromMiddleware(ctx))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.StorageQuotaResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 저장 공간 조회 완료"),
+		UsedBytes:  usage,
+		LimitBytes: config.Envs.UploadQuotaBytes,
+	})
+}
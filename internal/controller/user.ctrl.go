@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/middleware"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/internal/service"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type UserController struct {
+	relationService *service.RelationService
+	bookmarkService *service.BookmarkService
+}
+
+func NewUserController(relationService *service.RelationService, bookmarkService *service.BookmarkService) *UserController {
+	return &UserController{
+		relationService: relationService,
+		bookmarkService: bookmarkService,
+	}
+}
+
+func initUserDI(dbconn *model.PrismaClient, stores stores, rdconn *redis.Client, cacheStore cache.Cache) *UserController {
+	bookmarkRepository := repository.NewBookmarkRepository(dbconn)
+	relationService := service.NewRelationService(stores.relation, rdconn)
+	bookmarkService := service.NewBookmarkService(bookmarkRepository, stores.users, cacheStore, rdconn)
+	handler := NewUserController(relationService, bookmarkService)
+	return handler
+}
+
+func initUserRouter(router fiber.Router, handler *UserController) {
+	userRouter := router.Group("/users")
+	handler.Restricted(userRouter)
+}
+
+func (c *UserController) Restricted(router fiber.Router) {
+	router.Use(middleware.JWTMiddleware)
+	router.Get("/me/blocks", c.ListBlocks)
+	router.Get("/me/mutes", c.ListMutes)
+	router.Get("/me/bookmarks", c.ListBookmarks)
+	router.Post("/me/bookmarks", c.CreateBookmark)
+	router.Patch("/me/bookmarks/:bookmarkID", c.UpdateBookmark)
+	router.Delete("/me/bookmarks/:bookmarkID", c.DeleteBookmark)
+	router.Get("/me/bookmark-folders", c.ListBookmarkFolders)
+	router.Post("/me/bookmark-folders", c.CreateBookmarkFolder)
+	router.Delete("/me/bookmark-folders/:folderID", c.DeleteBookmarkFolder)
+	router.Post("/:handle/block", c.Block)
+	router.Delete("/:handle/block", c.Unblock)
+	router.Post("/:handle/mute", c.Mute)
+	router.Delete("/:handle/mute", c.Unmute)
+}
+
+func (c *UserController) Block(ctx *fiber.Ctx) error {
+	return c.handleRelation(ctx, "유저 차단", c.relationService.Block)
+}
+
+func (c *UserController) Unblock(ctx *fiber.Ctx) error {
+	return c.handleRelation(ctx, "유저 차단 해제", c.relationService.Unblock)
+}
+
+func (c *UserController) Mute(ctx *fiber.Ctx) error {
+	return c.handleRelation(ctx, "유저 뮤트", c.relationService.Mute)
+}
+
+func (c *UserController) Unmute(ctx *fiber.Ctx) error {
+	return c.handleRelation(ctx, "유저 뮤트 해제", c.relationService.Unmute)
+}
+
+func (c *UserController) ListBlocks(ctx *fiber.Ctx) error {
+	return c.listRelations(ctx, model.RelationTypeBlock, "✅ 차단한 유저 조회 완료")
+}
+
+func (c *UserController) ListMutes(ctx *fiber.Ctx) error {
+	return c.listRelations(ctx, model.RelationTypeMute, "✅ 뮤트한 유저 조회 완료")
+}
+
+func (c *UserController) handleRelation(ctx *fiber.Ctx, action string, handler func(ctx context.Context, req *dto.RelationRequest) *exception.ErrResponseCtx) error {
+	var relationPayload dto.RelationRequest
+	relationPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &relationPayload, action); err != nil {
+		return err
+	}
+
+	if err := handler(ctx.Context(), &relationPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ "+action+" 완료"),
+	})
+}
+
+func (c *UserController) listRelations(ctx *fiber.Ctx, relationType model.RelationType, message string) error {
+	users, err := c.relationService.ListRelations(ctx.Context(), middleware.GetIdFromMiddleware(ctx), relationType)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListRelationResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, message),
+		Users:      users,
+	})
+}
+
+func (c *UserController) CreateBookmark(ctx *fiber.Ctx) error {
+	var createBookmarkPayload dto.CreateBookmarkRequest
+	createBookmarkPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &createBookmarkPayload, "북마크 추가"); err != nil {
+		return err
+	}
+
+	bookmark, err := c.bookmarkService.CreateBookmark(ctx.Context(), &createBookmarkPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.CreateBookmarkResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusCreated,
+		Message:    i18n.T(ctx, "✅ 북마크 추가 완료"),
+		Bookmark:   *bookmark,
+	})
+}
+
+func (c *UserController) UpdateBookmark(ctx *fiber.Ctx) error {
+	var updateBookmarkPayload dto.UpdateBookmarkRequest
+	updateBookmarkPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &updateBookmarkPayload, "북마크 수정"); err != nil {
+		return err
+	}
+
+	bookmark, err := c.bookmarkService.UpdateBookmark(ctx.Context(), &updateBookmarkPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.CreateBookmarkResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 북마크 수정 완료"),
+		Bookmark:   *bookmark,
+	})
+}
+
+func (c *UserController) DeleteBookmark(ctx *fiber.Ctx) error {
+	var bookmarkPayload dto.BookmarkRequest
+	bookmarkPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &bookmarkPayload, "북마크 삭제"); err != nil {
+		return err
+	}
+
+	if err := c.bookmarkService.DeleteBookmark(ctx.Context(), &bookmarkPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 북마크 삭제 완료"),
+	})
+}
+
+func (c *UserController) ListBookmarks(ctx *fiber.Ctx) error {
+	var listBookmarkPayload dto.ListBookmarkRequest
+	listBookmarkPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &listBookmarkPayload, "북마크 조회"); err != nil {
+		return err
+	}
+
+	bookmarks, nextCursor, err := c.bookmarkService.ListBookmarks(ctx.Context(), &listBookmarkPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListBookmarkResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 북마크 조회 완료"),
+		Bookmarks:  bookmarks,
+		NextCursor: nextCursor,
+	})
+}
+
+func (c *UserController) CreateBookmarkFolder(ctx *fiber.Ctx) error {
+	var createFolderPayload dto.CreateBookmarkFolderRequest
+	createFolderPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &createFolderPayload, "북마크 폴더 생성"); err != nil {
+		return err
+	}
+
+	folder, err := c.bookmarkService.CreateFolder(ctx.Context(), &createFolderPayload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.CreateBookmarkFolderResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusCreated,
+		Message:    i18n.T(ctx, "✅ 북마크 폴더 생성 완료"),
+		Folder:     *folder,
+	})
+}
+
+func (c *UserController) ListBookmarkFolders(ctx *fiber.Ctx) error {
+	folders, err := c.bookmarkService.ListFolders(ctx.Context(), middleware.GetIdFromMiddleware(ctx))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ListBookmarkFolderResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 북마크 폴더 조회 완료"),
+		Folders:    folders,
+	})
+}
+
+func (c *UserController) DeleteBookmarkFolder(ctx *fiber.Ctx) error {
+	var folderPayload dto.BookmarkFolderRequest
+	folderPayload.UserID = middleware.GetIdFromMiddleware(ctx)
+	if err := utils.Bind(ctx, &folderPayload, "북마크 폴더 삭제"); err != nil {
+		return err
+	}
+
+	if err := c.bookmarkService.DeleteFolder(ctx.Context(), &folderPayload); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.DefaultResponse{
+		IsError:    false,
+		StatusCode: fiber.StatusOK,
+		Message:    i18n.T(ctx, "✅ 북마크 폴더 삭제 완료"),
+	})
+}
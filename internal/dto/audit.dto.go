@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+const (
+	AuditTargetUser   = "user"
+	AuditTargetThread = "thread"
+	AuditTargetIP     = "ip"
+)
+
+// 감사 로그, 조회수 집계에 사용하는 요청 정보. 컨트롤러가 요청에서 꺼내 서비스에 넘겨줌
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+}
+
+type AuditEntry struct {
+	Event      model.AuditEvent
+	ActorID    *string
+	TargetType string
+	TargetID   string
+	IP         string
+	UserAgent  string
+	Diff       map[string]interface{}
+}
+
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type AuditLogFilter struct {
+	Event    *model.AuditEvent
+	ActorID  string
+	TargetID string
+	From     *time.Time
+	To       *time.Time
+}
+
+type ListAuditLogRequest struct {
+	Event    string `query:"event" validate:"omitempty,oneof=LOGIN_SUCCESS LOGIN_FAILURE PASSWORD_CHANGE HANDLE_CHANGE WITHDRAW THREAD_DELETE USER_BAN USER_UNBAN IP_BAN IP_UNBAN ROLE_CHANGE"`
+	ActorID  string `query:"actorID"`
+	TargetID string `query:"targetID"`
+	From     string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To       string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Cursor   int    `query:"cursor"`
+	PageSize int    `query:"pageSize" validate:"omitempty,max=100"`
+	Format   string `query:"format" validate:"omitempty,oneof=json csv ndjson"`
+}
+
+type AuditLogResponse struct {
+	ID         int             `json:"id"`
+	Event      string          `json:"event"`
+	ActorID    string          `json:"actorID,omitempty"`
+	TargetType string          `json:"targetType,omitempty"`
+	TargetID   string          `json:"targetID,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	UserAgent  string          `json:"userAgent,omitempty"`
+	Diff       json.RawMessage `json:"diff,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type ListAuditLogResponse struct {
+	IsError    bool               `json:"isError"`
+	StatusCode int                `json:"statusCode"`
+	Message    string             `json:"message"`
+	AuditLogs  []AuditLogResponse `json:"auditLogs"`
+	NextCursor *int               `json:"nextCursor"`
+}
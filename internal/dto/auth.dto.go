@@ -3,9 +3,9 @@ package dto
 import "github.com/kitae0522/gommunity/internal/model"
 
 type RegisterRequest struct {
-	Handle          string `json:"handle" validate:"required"`
+	Handle          string `json:"handle" validate:"required,handle"`
 	Name            string `json:"name" validate:"required"`
-	Password        string `json:"password" validate:"required"`
+	Password        string `json:"password" validate:"required,password"`
 	PasswordConfirm string `json:"passwordConfirm" validate:"required"`
 	Email           string `json:"email" validate:"required,email"`
 }
@@ -29,7 +29,7 @@ type HandleResetEntity struct {
 
 type PasswordResetRequest struct {
 	OldPassword        string `json:"oldPassword" validate:"required"`
-	NewPassword        string `json:"newPassword" validate:"required"`
+	NewPassword        string `json:"newPassword" validate:"required,password"`
 	NewPasswordConfirm string `json:"newPasswordConfirm" validate:"required"`
 }
 
@@ -39,7 +39,13 @@ type PasswordResetEntity struct {
 }
 
 type WithdrawRequest struct {
-	ID string `json:"id" validate:"required"`
+	ID string `json:"-" validate:"required"`
+}
+
+type ChangeRoleRequest struct {
+	AdminID string `json:"-" validate:"required"`
+	Handle  string `params:"handle" validate:"required"`
+	Role    string `json:"role" validate:"required,oneof=USER MODERATOR ADMIN"`
 }
 
 type PasswordEntity struct {
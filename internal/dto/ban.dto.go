@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+type BanEntity struct {
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type BanUserRequest struct {
+	AdminID       string `json:"-" validate:"required"`
+	Handle        string `params:"handle" validate:"required"`
+	Reason        string `json:"reason" validate:"required,max=1000"`
+	DurationHours int    `json:"durationHours" validate:"min=0"`
+}
+
+type UnbanUserRequest struct {
+	AdminID string `json:"-" validate:"required"`
+	Handle  string `params:"handle" validate:"required"`
+}
+
+type BanIPRequest struct {
+	AdminID       string `json:"-" validate:"required"`
+	CIDR          string `json:"cidr" validate:"required"`
+	Reason        string `json:"reason" validate:"required,max=1000"`
+	DurationHours int    `json:"durationHours" validate:"min=0"`
+}
+
+type UnbanIPRequest struct {
+	AdminID string `json:"-" validate:"required"`
+	BanID   int    `params:"banID" validate:"required"`
+}
+
+type BanResponse struct {
+	ID        int        `json:"id"`
+	UserID    string     `json:"userID"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ListBanResponse struct {
+	IsError    bool          `json:"isError"`
+	StatusCode int           `json:"statusCode"`
+	Message    string        `json:"message"`
+	Bans       []BanResponse `json:"bans"`
+}
+
+type IPBanResponse struct {
+	ID        int        `json:"id"`
+	CIDR      string     `json:"cidr"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ListIPBanResponse struct {
+	IsError    bool            `json:"isError"`
+	StatusCode int             `json:"statusCode"`
+	Message    string          `json:"message"`
+	IPBans     []IPBanResponse `json:"ipBans"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+import "time"
+
+type CreateBookmarkRequest struct {
+	UserID   string  `json:"-" validate:"required"`
+	ThreadID int     `json:"threadID" validate:"required"`
+	FolderID *int    `json:"folderID"`
+	Note     *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// FolderID가 0이면 폴더에서 꺼냄
+type UpdateBookmarkRequest struct {
+	UserID     string  `json:"-" validate:"required"`
+	BookmarkID int     `params:"bookmarkID" validate:"required"`
+	FolderID   *int    `json:"folderID" validate:"omitempty,min=0"`
+	Note       *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+type BookmarkRequest struct {
+	UserID     string `json:"-" validate:"required"`
+	BookmarkID int    `params:"bookmarkID" validate:"required"`
+}
+
+type ListBookmarkRequest struct {
+	UserID   string `json:"-" validate:"required"`
+	FolderID int    `query:"folderID" validate:"min=0"`
+	Cursor   int    `query:"cursor" validate:"min=0"`
+	PageSize int    `query:"pageSize" validate:"min=0,max=100"`
+}
+
+type CreateBookmarkFolderRequest struct {
+	UserID string `json:"-" validate:"required"`
+	Name   string `json:"name" validate:"required,max=100"`
+}
+
+type BookmarkFolderRequest struct {
+	UserID   string `json:"-" validate:"required"`
+	FolderID int    `params:"folderID" validate:"required"`
+}
+
+type BookmarkThreadResponse struct {
+	ID        int            `json:"id"`
+	Author    AuthorResponse `json:"author"`
+	Title     string         `json:"title"`
+	ImgURL    string         `json:"imgUrl"`
+	Views     int            `json:"views"`
+	Likes     int            `json:"likes"`
+	Bookmarks int            `json:"bookmarks"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// 삭제되거나 숨김 처리된 쓰레드는 Available을 false로 내려주고, 저장해둔 제목만 보여줌
+type BookmarkResponse struct {
+	ID          int                     `json:"id"`
+	ThreadID    *int                    `json:"threadID"`
+	FolderID    *int                    `json:"folderID"`
+	ThreadTitle string                  `json:"threadTitle"`
+	Note        string                  `json:"note"`
+	Available   bool                    `json:"available"`
+	Thread      *BookmarkThreadResponse `json:"thread,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt"`
+}
+
+type CreateBookmarkResponse struct {
+	IsError    bool             `json:"isError"`
+	StatusCode int              `json:"statusCode"`
+	Message    string           `json:"message"`
+	Bookmark   BookmarkResponse `json:"bookmark"`
+}
+
+type ListBookmarkResponse struct {
+	IsError    bool               `json:"isError"`
+	StatusCode int                `json:"statusCode"`
+	Message    string             `json:"message"`
+	Bookmarks  []BookmarkResponse `json:"bookmarks"`
+	NextCursor *int               `json:"nextCursor"`
+}
+
+type BookmarkFolderResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateBookmarkFolderResponse struct {
+	IsError    bool                   `json:"isError"`
+	StatusCode int                    `json:"statusCode"`
+	Message    string                 `json:"message"`
+	Folder     BookmarkFolderResponse `json:"folder"`
+}
+
+type ListBookmarkFolderResponse struct {
+	IsError    bool                     `json:"isError"`
+	StatusCode int                      `json:"statusCode"`
+	Message    string                   `json:"message"`
+	Folders    []BookmarkFolderResponse `json:"folders"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type PurgeCacheRequest struct {
+	Pattern string `query:"pattern" validate:"required,max=200"`
+}
+
+type PurgeCacheResponse struct {
+	IsError    bool   `json:"isError"`
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	Deleted    int64  `json:"deleted"`
+}
@@ -0,0 +1,93 @@
+package dto
+
+import "time"
+
+type CreateConversationRequest struct {
+	UserID  string   `json:"-" validate:"required"`
+	Handles []string `json:"handles" validate:"required,min=1,max=9,dive,required"`
+}
+
+type ConversationRequest struct {
+	UserID         string `json:"-" validate:"required"`
+	ConversationID int    `params:"conversationID" validate:"required"`
+}
+
+type ParticipantResponse struct {
+	UserID            string `json:"userID"`
+	Handle            string `json:"handle"`
+	Name              string `json:"name"`
+	LastReadMessageID int    `json:"lastReadMessageID"`
+}
+
+type ConversationResponse struct {
+	ID            int                   `json:"id"`
+	IsGroup       bool                  `json:"isGroup"`
+	Participants  []ParticipantResponse `json:"participants"`
+	UnreadCount   int                   `json:"unreadCount"`
+	Muted         bool                  `json:"muted"`
+	LastMessageAt time.Time             `json:"lastMessageAt"`
+}
+
+type CreateConversationResponse struct {
+	IsError      bool                 `json:"isError"`
+	StatusCode   int                  `json:"statusCode"`
+	Message      string               `json:"message"`
+	Conversation ConversationResponse `json:"conversation"`
+}
+
+type ListConversationResponse struct {
+	IsError       bool                   `json:"isError"`
+	StatusCode    int                    `json:"statusCode"`
+	Message       string                 `json:"message"`
+	TotalUnread   int                    `json:"totalUnread"`
+	Conversations []ConversationResponse `json:"conversations"`
+}
+
+type DirectMessageResponse struct {
+	ID             int       `json:"id"`
+	ConversationID int       `json:"conversationID"`
+	SenderID       string    `json:"senderID"`
+	Content        string    `json:"content"`
+	ReadBy         []string  `json:"readBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type ListDirectMessageRequest struct {
+	UserID         string `json:"-" validate:"required"`
+	ConversationID int    `params:"conversationID" validate:"required"`
+	Cursor         int    `query:"cursor"`
+	PageSize       int    `query:"pageSize"`
+}
+
+type ListDirectMessageResponse struct {
+	IsError    bool                    `json:"isError"`
+	StatusCode int                     `json:"statusCode"`
+	Message    string                  `json:"message"`
+	Messages   []DirectMessageResponse `json:"messages"`
+	NextCursor int                     `json:"nextCursor"`
+}
+
+type SendDirectMessageRequest struct {
+	UserID         string `json:"-" validate:"required"`
+	ConversationID int    `params:"conversationID" validate:"required"`
+	Content        string `json:"content" validate:"required,max=2000"`
+}
+
+type SendDirectMessageResponse struct {
+	IsError       bool                  `json:"isError"`
+	StatusCode    int                   `json:"statusCode"`
+	Message       string                `json:"message"`
+	DirectMessage DirectMessageResponse `json:"directMessage"`
+}
+
+type MarkReadRequest struct {
+	UserID         string `json:"-" validate:"required"`
+	ConversationID int    `params:"conversationID" validate:"required"`
+	MessageID      int    `json:"messageID"`
+}
+
+type MuteConversationRequest struct {
+	UserID         string `json:"-" validate:"required"`
+	ConversationID int    `params:"conversationID" validate:"required"`
+	Muted          bool   `json:"muted"`
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type CreateReportRequest struct {
+	UserID     string `json:"-" validate:"required"`
+	TargetType string `json:"targetType" validate:"required,oneof=THREAD USER"`
+	ThreadID   *int   `json:"threadID" validate:"required_if=TargetType THREAD"`
+	Handle     string `json:"handle" validate:"required_if=TargetType USER"`
+	Reason     string `json:"reason" validate:"required,oneof=SPAM ABUSE HARASSMENT HATE_SPEECH SEXUAL_CONTENT MISINFORMATION OTHER"`
+	Detail     string `json:"detail" validate:"max=1000"`
+}
+
+type ReportResponse struct {
+	ID           int        `json:"id"`
+	ReporterID   string     `json:"reporterID"`
+	TargetType   string     `json:"targetType"`
+	ThreadID     *int       `json:"threadID"`
+	TargetUserID string     `json:"targetUserID"`
+	Reason       string     `json:"reason"`
+	Detail       string     `json:"detail"`
+	Status       string     `json:"status"`
+	HandledBy    string     `json:"handledBy"`
+	HandledAt    *time.Time `json:"handledAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type CreateReportResponse struct {
+	IsError    bool           `json:"isError"`
+	StatusCode int            `json:"statusCode"`
+	Message    string         `json:"message"`
+	Report     ReportResponse `json:"report"`
+}
+
+type ListReportRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=OPEN ACTIONED DISMISSED"`
+	PageNumber int    `query:"pageNumber"`
+	PageSize   int    `query:"pageSize"`
+}
+
+type ListReportResponse struct {
+	IsError    bool             `json:"isError"`
+	StatusCode int              `json:"statusCode"`
+	Message    string           `json:"message"`
+	Reports    []ReportResponse `json:"reports"`
+}
+
+type UpdateReportRequest struct {
+	ModeratorID string `json:"-" validate:"required"`
+	ReportID    int    `params:"reportID" validate:"required"`
+	Status      string `json:"status" validate:"required,oneof=ACTIONED DISMISSED"`
+	Note        string `json:"note" validate:"max=1000"`
+}
+
+type ModerateThreadRequest struct {
+	ModeratorID string `json:"-" validate:"required"`
+	ThreadID    int    `params:"threadID" validate:"required"`
+	ReportID    *int   `json:"reportID"`
+	Note        string `json:"note" validate:"max=1000"`
+}
+
+type PinThreadRequest struct {
+	ModeratorID   string `json:"-" validate:"required"`
+	ThreadID      int    `params:"threadID" validate:"required"`
+	DurationHours int    `json:"durationHours" validate:"required,min=1"`
+	Note          string `json:"note" validate:"max=1000"`
+}
+
+type ModerateUserRequest struct {
+	ModeratorID   string `json:"-" validate:"required"`
+	Handle        string `params:"handle" validate:"required"`
+	ReportID      *int   `json:"reportID"`
+	Reason        string `json:"reason" validate:"required,max=1000"`
+	DurationHours int    `json:"durationHours" validate:"min=0"`
+}
+
+type ModerationActionEntity struct {
+	ModeratorID  *string
+	Action       model.ModerationActionType
+	ThreadID     *int
+	TargetUserID *string
+	ReportID     *int
+	Note         string
+	ExpiresAt    *time.Time
+}
+
+type ModerationActionResponse struct {
+	ID           int        `json:"id"`
+	ModeratorID  string     `json:"moderatorID"`
+	Action       string     `json:"action"`
+	ThreadID     *int       `json:"threadID"`
+	TargetUserID string     `json:"targetUserID"`
+	ReportID     *int       `json:"reportID"`
+	Note         string     `json:"note"`
+	ExpiresAt    *time.Time `json:"expiresAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type ListModerationActionRequest struct {
+	PageNumber int `query:"pageNumber"`
+	PageSize   int `query:"pageSize"`
+}
+
+type ListModerationActionResponse struct {
+	IsError    bool                       `json:"isError"`
+	StatusCode int                        `json:"statusCode"`
+	Message    string                     `json:"message"`
+	Actions    []ModerationActionResponse `json:"actions"`
+}
+
+type WarningPayload struct {
+	Reason string `json:"reason"`
+}
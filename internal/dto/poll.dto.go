@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type CreatePollRequest struct {
+	UserID         string     `json:"-" validate:"required"`
+	ThreadID       int        `params:"threadID" validate:"required"`
+	Question       string     `json:"question" validate:"required,max=300"`
+	Options        []string   `json:"options" validate:"min=2,max=10,dive,required,max=200"`
+	MultipleChoice bool       `json:"multipleChoice"`
+	HideResults    bool       `json:"hideResults"`
+	ClosesAt       *time.Time `json:"closesAt"`
+}
+
+type GetPollRequest struct {
+	ThreadID int `params:"threadID" validate:"required"`
+}
+
+type VotePollRequest struct {
+	UserID    string `json:"-" validate:"required"`
+	ThreadID  int    `params:"threadID" validate:"required"`
+	OptionIDs []int  `json:"optionIDs" validate:"min=1,max=10,dive,required"`
+}
+
+type ClosePollRequest struct {
+	UserID   string          `json:"-" validate:"required"`
+	Role     model.UserRoles `json:"-"`
+	ThreadID int             `params:"threadID" validate:"required"`
+}
+
+// Redis에 저장하는 실시간 집계. Votes는 선택지 ID별 득표 수
+type PollTally struct {
+	VoterCount int         `json:"voterCount"`
+	Votes      map[int]int `json:"votes"`
+}
+
+type PollOptionResponse struct {
+	ID       int    `json:"id"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	Votes    *int   `json:"votes,omitempty"`
+}
+
+type PollResponse struct {
+	ThreadID       int                  `json:"threadID"`
+	Question       string               `json:"question"`
+	MultipleChoice bool                 `json:"multipleChoice"`
+	HideResults    bool                 `json:"hideResults"`
+	IsClosed       bool                 `json:"isClosed"`
+	ResultsVisible bool                 `json:"resultsVisible"`
+	VoterCount     *int                 `json:"voterCount,omitempty"`
+	Options        []PollOptionResponse `json:"options"`
+	MyChoices      []int                `json:"myChoices"`
+	ClosesAt       *time.Time           `json:"closesAt"`
+	ClosedAt       *time.Time           `json:"closedAt"`
+	CreatedAt      time.Time            `json:"createdAt"`
+}
+
+type GetPollResponse struct {
+	IsError    bool         `json:"isError"`
+	StatusCode int          `json:"statusCode"`
+	Message    string       `json:"message"`
+	Poll       PollResponse `json:"poll"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type RealtimeEventType string
+
+const (
+	EventThreadCreated     RealtimeEventType = "thread.created"
+	EventThreadReplied     RealtimeEventType = "thread.replied"
+	EventThreadDeleted     RealtimeEventType = "thread.deleted"
+	EventThreadCounter     RealtimeEventType = "thread.counter"
+	EventPollVoted         RealtimeEventType = "poll.voted"
+	EventPollClosed        RealtimeEventType = "poll.closed"
+	EventNotificationReply RealtimeEventType = "notification.reply"
+	EventDirectMessage     RealtimeEventType = "dm.message"
+	EventModerationWarning RealtimeEventType = "moderation.warning"
+)
+
+type RealtimeEvent struct {
+	Type           RealtimeEventType `json:"type"`
+	ThreadID       int               `json:"threadID,omitempty"`
+	ConversationID int               `json:"conversationID,omitempty"`
+	AuthorID       string            `json:"authorID,omitempty"`
+	Payload        interface{}       `json:"payload,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+type CounterPayload struct {
+	Field  string `json:"field"`
+	Amount int64  `json:"amount"`
+}
+
+type SubscribeThreadRequest struct {
+	ThreadID int `params:"threadID" validate:"required"`
+}
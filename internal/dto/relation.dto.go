@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type RelationRequest struct {
+	UserID string `json:"-" validate:"required"`
+	Handle string `params:"handle" validate:"required"`
+}
+
+type RelationUserResponse struct {
+	UserID    string    `json:"userID"`
+	Handle    string    `json:"handle"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ListRelationResponse struct {
+	IsError    bool                   `json:"isError"`
+	StatusCode int                    `json:"statusCode"`
+	Message    string                 `json:"message"`
+	Users      []RelationUserResponse `json:"users"`
+}
+
+type ViewerRelations struct {
+	Blocked []string `json:"blocked"`
+	Muted   []string `json:"muted"`
+}
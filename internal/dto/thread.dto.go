@@ -7,34 +7,108 @@ import (
 )
 
 type CreateThreadRequest struct {
-	UserID       string  `json:"userID"`
-	Title        string  `json:"title"`
-	ImgUrl       *string `json:"imgUrl"`
-	Content      string  `json:"content" validate:"required"`
-	ParentThread *int    `json:"parentThread"`
-	NextThread   *int    `json:"nextThread"`
-	PrevThread   *int    `json:"prevThread"`
+	UserID       string              `json:"-" validate:"required"`
+	Title        string              `json:"title"`
+	ImgUrl       *string             `json:"imgUrl" validate:"omitempty,safeurl"`
+	Attachments  []AttachmentRequest `json:"attachments" validate:"max=10,dive"`
+	Content      string              `json:"content" validate:"required,maxcontent"`
+	ParentThread *int                `json:"parentThread"`
+	NextThread   *int                `json:"nextThread"`
+	PrevThread   *int                `json:"prevThread"`
+	IsDraft      bool                `json:"isDraft"`
+	PublishAt    *time.Time          `json:"publishAt"`
+}
+
+type AttachmentRequest struct {
+	UploadID string `json:"uploadID" validate:"required,uuid"`
+	AltText  string `json:"altText" validate:"max=500"`
+}
+
+type AttachmentResponse struct {
+	ID           int    `json:"id"`
+	UploadID     string `json:"uploadID"`
+	Position     int    `json:"position"`
+	AltText      string `json:"altText"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	Filename     string `json:"filename"`
+	ContentType  string `json:"contentType"`
+	Size         int    `json:"size"`
+	Width        *int   `json:"width,omitempty"`
+	Height       *int   `json:"height,omitempty"`
 }
 
 type CreateThreadReponse struct {
-	IsError    bool              `json:"isError"`
-	StatusCode int               `json:"statusCode"`
-	Message    string            `json:"message"`
-	Thread     model.ThreadModel `json:"thread"`
+	IsError    bool           `json:"isError"`
+	StatusCode int            `json:"statusCode"`
+	Message    string         `json:"message"`
+	Thread     ThreadResponse `json:"thread"`
+}
+
+// 렌더링 결과를 응답 최상위에 펼쳐서 내려주기 위해 임베딩해서 사용
+type RenderedContent struct {
+	ContentHTML string   `json:"contentHtml"`
+	Mentions    []string `json:"mentions"`
+	Hashtags    []string `json:"hashtags"`
+	Links       []string `json:"links"`
+}
+
+type LinkPreview struct {
+	Link        string `json:"link"`
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+	SiteName    string `json:"siteName,omitempty"`
+}
+
+// 작성자 정보 중 목록에 노출해도 되는 값만 담음
+type AuthorResponse struct {
+	ID         string `json:"id"`
+	Handle     string `json:"handle"`
+	Name       string `json:"name"`
+	ProfilePic string `json:"profilePic,omitempty"`
 }
 
+/*
+쓰레드를 내려주는 모든 API와 쓰레드 캐시가 같은 형식을 사용함.
+필드를 바꾸면 이전 형식으로 캐시된 값을 읽지 않도록 service의 threadPayloadVersion도 함께 올려야 함.
+*/
 type ThreadResponse struct {
-	ID        int       `json:"id"`
-	UserID    string    `json:"userID"`
-	Handle    string    `json:"handle"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	ImgURL    string    `json:"imgUrl"`
-	Views     int       `json:"views"`
-	Likes     int       `json:"likes"`
-	Dislikes  int       `json:"dislikes"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          int                  `json:"id"`
+	Author      AuthorResponse       `json:"author"`
+	Status      string               `json:"status"`
+	Title       string               `json:"title"`
+	Content     string               `json:"content"`
+	ImgURL      string               `json:"imgUrl"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	Previews    []LinkPreview        `json:"previews"`
+	Views       int                  `json:"views"`
+	RawViews    int                  `json:"rawViews"`
+	Likes       int                  `json:"likes"`
+	Dislikes    int                  `json:"dislikes"`
+	Bookmarks   int                  `json:"bookmarks"`
+	Bookmarked  bool                 `json:"bookmarked"`
+	IsLocked    bool                 `json:"isLocked"`
+	PinnedUntil *time.Time           `json:"pinnedUntil,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+	RenderedContent
+}
+
+// 상세 조회에서 쓰레드 아래에 붙는 답글
+type CommentResponse struct {
+	ID          int                  `json:"id"`
+	Author      AuthorResponse       `json:"author"`
+	Title       string               `json:"title"`
+	Content     string               `json:"content"`
+	ImgURL      string               `json:"imgUrl"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	Likes       int                  `json:"likes"`
+	Dislikes    int                  `json:"dislikes"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+	RenderedContent
 }
 
 type ListThreadRequest struct {
@@ -54,11 +128,11 @@ type ListThreadByHandleRequest struct {
 }
 
 type ListThreadByHandleResponse struct {
-	IsError    bool                `json:"isError"`
-	StatusCode int                 `json:"statusCode"`
-	Message    string              `json:"message"`
-	Handle     string              `json:"handle"`
-	Threads    []model.ThreadModel `json:"threads"`
+	IsError    bool             `json:"isError"`
+	StatusCode int              `json:"statusCode"`
+	Message    string           `json:"message"`
+	Handle     string           `json:"handle"`
+	Threads    []ThreadResponse `json:"threads"`
 }
 
 type GetThreadByIDRequest struct {
@@ -66,18 +140,76 @@ type GetThreadByIDRequest struct {
 }
 
 type GetThreadByIDResponse struct {
-	IsError    bool                `json:"isError"`
-	StatusCode int                 `json:"statusCode"`
-	Message    string              `json:"message"`
-	Thread     *model.ThreadModel  `json:"thread"`
-	SubThread  []model.ThreadModel `json:"subThread"`
+	IsError    bool              `json:"isError"`
+	StatusCode int               `json:"statusCode"`
+	Message    string            `json:"message"`
+	Thread     ThreadResponse    `json:"thread"`
+	SubThread  []CommentResponse `json:"subThread"`
 }
 
 type RemoveThreadByIDRequest struct {
-	ID       string `json:"id" validate:"required"`
+	ID       string `json:"-" validate:"required"`
 	ThreadID int    `params:"threadID" validate:"required"`
 }
 
 type InteractionRequest struct {
-	ThreadID int `json:"threadID" validate:"required"`
+	UserID   string `json:"-"`
+	ThreadID int    `json:"threadID" validate:"required"`
+}
+
+type SaveDraftRequest struct {
+	UserID   string  `json:"-" validate:"required"`
+	ThreadID int     `params:"threadID" validate:"required"`
+	Title    *string `json:"title" validate:"omitempty,max=255"`
+	Content  *string `json:"content" validate:"omitempty,maxcontent"`
+}
+
+type ScheduleDraftRequest struct {
+	UserID    string    `json:"-" validate:"required"`
+	ThreadID  int       `params:"threadID" validate:"required"`
+	PublishAt time.Time `json:"publishAt" validate:"required"`
+}
+
+type DraftRequest struct {
+	UserID   string `json:"-" validate:"required"`
+	ThreadID int    `params:"threadID" validate:"required"`
+}
+
+type DraftResponse struct {
+	ID          int                  `json:"id"`
+	Title       string               `json:"title"`
+	Content     string               `json:"content"`
+	Status      string               `json:"status"`
+	PublishAt   *time.Time           `json:"publishAt"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+type SaveDraftResponse struct {
+	IsError    bool          `json:"isError"`
+	StatusCode int           `json:"statusCode"`
+	Message    string        `json:"message"`
+	Draft      DraftResponse `json:"draft"`
+}
+
+type ListDraftResponse struct {
+	IsError    bool            `json:"isError"`
+	StatusCode int             `json:"statusCode"`
+	Message    string          `json:"message"`
+	Drafts     []DraftResponse `json:"drafts"`
+}
+
+// Status가 있으면 PublishAt도 같이 덮어씀 (PublishAt이 nil이면 예약 시각을 비움)
+type DraftUpdateEntity struct {
+	Title     *string
+	Content   *string
+	Status    *model.ThreadStatus
+	PublishAt *time.Time
+}
+
+type InteractionDeltaEntity struct {
+	ThreadID int
+	Field    string
+	Amount   int
 }
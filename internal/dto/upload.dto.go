@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+type UploadFileRequest struct {
+	UserID   string
+	Filename string
+	Data     []byte
+}
+
+type UploadEntity struct {
+	ID                   string
+	UserID               string
+	Filename             string
+	ContentType          string
+	Size                 int
+	Width                *int
+	Height               *int
+	BlobKey              string
+	ThumbnailKey         *string
+	ThumbnailContentType *string
+}
+
+type GetUploadRequest struct {
+	UploadID string `params:"uploadID" validate:"required,uuid"`
+}
+
+type UploadResponse struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl,omitempty"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"contentType"`
+	Size         int       `json:"size"`
+	Width        *int      `json:"width,omitempty"`
+	Height       *int      `json:"height,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type UploadFileResponse struct {
+	IsError    bool           `json:"isError"`
+	StatusCode int            `json:"statusCode"`
+	Message    string         `json:"message"`
+	Upload     UploadResponse `json:"upload"`
+}
+
+type StorageQuotaResponse struct {
+	IsError    bool   `json:"isError"`
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	UsedBytes  int64  `json:"usedBytes"`
+	LimitBytes int64  `json:"limitBytes"`
+}
+
+type UploadBlob struct {
+	Reader      io.ReadCloser
+	ContentType string
+	Filename    string
+	Inline      bool
+}
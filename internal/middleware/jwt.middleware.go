@@ -1,31 +1,100 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
 	"strings"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/pkg/crypt"
 	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
 )
 
+var sessionCache *redis.Client
+
+// 정지된 유저와 폐기된 토큰을 확인하기 위해 사용할 Redis 클라이언트를 등록
+func SetSessionCache(rdconn *redis.Client) {
+	sessionCache = rdconn
+}
+
 func JWTMiddleware(ctx *fiber.Ctx) error {
 	authHeader := strings.Split(ctx.Get("Authorization"), " ")
 	if len(authHeader) != 2 {
-		ctxResponse := exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 접근 권한이 없습니다.", exception.ErrUnauthorizedRequest)
-		return ctx.Status(ctxResponse.StatusCode).JSON(ctxResponse)
+		return exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 접근 권한이 없습니다.", exception.ErrUnauthorizedRequest)
 	}
 	token := authHeader[1]
 
-	uuid, err := crypt.ParseJWT(token)
+	claims, err := crypt.ParseJWT(token)
 	if err != nil {
-		ctxResponse := exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 유효하지 않는 토큰 값입니다.", err)
-		return ctx.Status(ctxResponse.StatusCode).JSON(ctxResponse)
+		return exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 유효하지 않는 토큰 값입니다.", fmt.Errorf("%w: %v", exception.ErrInvalidToken, err))
+	}
+
+	if ctxResponse := checkSession(ctx.Context(), claims); ctxResponse != nil {
+		return ctxResponse
+	}
+
+	ctx.Locals("uuid", claims.UUID)
+	ctx.Locals("role", claims.Role)
+	return ctx.Next()
+}
+
+// 비로그인 유저도 접근 가능한 API에서 로그인한 유저를 식별하기 위해 사용. 토큰이 없거나 유효하지 않아도 요청은 통과시킴
+func OptionalJWTMiddleware(ctx *fiber.Ctx) error {
+	authHeader := strings.Split(ctx.Get("Authorization"), " ")
+	if len(authHeader) != 2 {
+		return ctx.Next()
+	}
+
+	if claims, err := crypt.ParseJWT(authHeader[1]); err == nil && checkSession(ctx.Context(), claims) == nil {
+		ctx.Locals("uuid", claims.UUID)
+		ctx.Locals("role", claims.Role)
 	}
-	ctx.Locals("uuid", uuid)
 	return ctx.Next()
 }
 
 func GetIdFromMiddleware(ctx *fiber.Ctx) string {
 	return ctx.Locals("uuid").(string)
 }
+
+func GetOptionalIdFromMiddleware(ctx *fiber.Ctx) string {
+	if uuid, ok := ctx.Locals("uuid").(string); ok {
+		return uuid
+	}
+	return ""
+}
+
+/*
+토큰 자체는 유효하더라도 아래의 경우에는 요청을 거부함.
+1. 정지된 유저 (auth:ban:{uuid}) -> 정지 사유와 해제일을 함께 응답
+2. 토큰 폐기 시점(auth:revoked:{uuid}) 이전에 발급된 토큰
+초 단위로 비교하면 폐기 직후 같은 초에 다시 발급받은 토큰까지 거부되므로 두 시각 모두 밀리초 단위로 비교함.
+Redis에 문제가 생긴 경우에는 서비스 전체가 멈추지 않도록 로그만 남기고 통과시킴.
+*/
+func checkSession(ctx context.Context, claims *crypt.TokenClaims) *exception.ErrResponseCtx {
+	if sessionCache == nil {
+		return nil
+	}
+
+	var ban *dto.BanEntity
+	if err := utils.GetCache(sessionCache, ctx, utils.BanCacheKey(claims.UUID), &ban); err != nil {
+		log.Printf("session: failed to check ban: %v", err)
+	} else if ban != nil {
+		return exception.GenerateErrorCtx(fiber.StatusForbidden, utils.BanMessage(ban.Reason, ban.ExpiresAt), exception.ErrUserBanned).WithDetail(ban)
+	}
+
+	revokedAt, err := sessionCache.Get(ctx, utils.TokenRevokedCacheKey(claims.UUID)).Result()
+	if err != nil && err != redis.Nil {
+		log.Printf("session: failed to check token revocation: %v", err)
+	} else if err == nil {
+		if revokedMilli, _ := strconv.ParseInt(revokedAt, 10, 64); claims.IssuedAt < revokedMilli {
+			return exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 만료된 토큰입니다. 다시 로그인해주세요.", exception.ErrTokenRevoked)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+// JWTMiddleware 이후에 사용해야 함
+func RoleMiddleware(roles ...model.UserRoles) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role := GetRoleFromMiddleware(ctx)
+		for _, allowed := range roles {
+			if role == allowed {
+				return ctx.Next()
+			}
+		}
+
+		return exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 해당 기능에 접근할 권한이 없습니다.", exception.ErrForbiddenRole)
+	}
+}
+
+func GetRoleFromMiddleware(ctx *fiber.Ctx) model.UserRoles {
+	if role, ok := ctx.Locals("role").(string); ok {
+		return model.UserRoles(role)
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type AuditRepository struct {
+	client *model.PrismaClient
+}
+
+func NewAuditRepository(prismaClient *model.PrismaClient) *AuditRepository {
+	return &AuditRepository{client: prismaClient}
+}
+
+func (r *AuditRepository) CreateAuditLog(ctx context.Context, entry dto.AuditEntry, diff []byte) (*model.AuditLogModel, error) {
+	params := []model.AuditLogSetParam{
+		model.AuditLog.ActorID.SetIfPresent(entry.ActorID),
+	}
+	if entry.TargetType != "" {
+		params = append(params, model.AuditLog.TargetType.Set(entry.TargetType))
+	}
+	if entry.TargetID != "" {
+		params = append(params, model.AuditLog.TargetID.Set(entry.TargetID))
+	}
+	if entry.IP != "" {
+		params = append(params, model.AuditLog.IP.Set(entry.IP))
+	}
+	if entry.UserAgent != "" {
+		params = append(params, model.AuditLog.UserAgent.Set(entry.UserAgent))
+	}
+	if diff != nil {
+		params = append(params, model.AuditLog.Diff.Set(model.JSON(diff)))
+	}
+
+	return r.client.AuditLog.CreateOne(
+		model.AuditLog.Event.Set(entry.Event),
+		params...,
+	).Exec(ctx)
+}
+
+// 내보내기 중에도 새 로그가 계속 쌓이므로 offset 대신 ID 커서로 페이지를 나눔
+func (r *AuditRepository) ListAuditLogs(ctx context.Context, filter dto.AuditLogFilter, cursor, pageSize int) ([]model.AuditLogModel, error) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	params := []model.AuditLogWhereParam{}
+	if filter.Event != nil {
+		params = append(params, model.AuditLog.Event.Equals(*filter.Event))
+	}
+	if filter.ActorID != "" {
+		params = append(params, model.AuditLog.ActorID.Equals(filter.ActorID))
+	}
+	if filter.TargetID != "" {
+		params = append(params, model.AuditLog.TargetID.Equals(filter.TargetID))
+	}
+	if filter.From != nil {
+		params = append(params, model.AuditLog.CreatedAt.Gte(*filter.From))
+	}
+	if filter.To != nil {
+		params = append(params, model.AuditLog.CreatedAt.Lt(*filter.To))
+	}
+	if cursor > 0 {
+		params = append(params, model.AuditLog.ID.Lt(cursor))
+	}
+
+	return r.client.AuditLog.FindMany(params...).OrderBy(
+		model.AuditLog.ID.Order(model.SortOrderDesc),
+	).Take(pageSize).Exec(ctx)
+}
@@ -74,6 +74,31 @@ func (r *AuthRepository) UpdateUserHandle(ctx context.Context, ID string, handle
 	return err
 }
 
+func (r *AuthRepository) GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error) {
+	return r.client.Users.FindUnique(model.Users.Handle.Equals(handle)).Exec(ctx)
+}
+
+// 작성자 정보를 붙일 때 사용하므로 비밀번호 해시 등은 가져오지 않음
+func (r *AuthRepository) ListUsersByIDs(ctx context.Context, IDs []string) ([]model.UsersModel, error) {
+	return r.client.Users.FindMany(
+		model.Users.ID.In(IDs),
+	).Select(
+		model.Users.ID.Field(),
+		model.Users.Handle.Field(),
+		model.Users.Name.Field(),
+		model.Users.ProfilePic.Field(),
+	).Exec(ctx)
+}
+
+func (r *AuthRepository) UpdateUserRole(ctx context.Context, ID string, role model.UserRoles) error {
+	_, err := r.client.Users.FindUnique(
+		model.Users.ID.Equals(ID),
+	).Update(
+		model.Users.Role.Set(role),
+	).Exec(ctx)
+	return err
+}
+
 func (r *AuthRepository) UpdateUserPassword(ctx context.Context, ID, salt, plainPassword string) error {
 	hashPassword := crypt.NewSHA256(plainPassword, salt)
 	_, err := r.client.Users.FindUnique(
@@ -85,9 +110,19 @@ func (r *AuthRepository) UpdateUserPassword(ctx context.Context, ID, salt, plain
 }
 
 func (r *AuthRepository) DeleteUser(ctx context.Context, ID string) (bool, error) {
-	_, err := r.client.Users.FindUnique(
-		model.Users.ID.Equals(ID),
-	).Delete().Exec(ctx)
+	if _, err := r.client.Users.FindUnique(model.Users.ID.Equals(ID)).Exec(ctx); err != nil {
+		return false, err
+	}
+
+	// 참여자 레코드는 Cascade로 지워지지만 대화방은 남기 때문에, 탈퇴하는 유저만 남은 대화방은 함께 삭제
+	err := r.client.Prisma.Transaction(
+		r.client.Conversation.FindMany(
+			model.Conversation.Participants.Every(model.ConversationParticipant.UserID.Equals(ID)),
+		).Delete().Tx(),
+		r.client.Users.FindUnique(
+			model.Users.ID.Equals(ID),
+		).Delete().Tx(),
+	).Exec(ctx)
 
 	if err != nil {
 		return false, err
@@ -95,6 +130,17 @@ func (r *AuthRepository) DeleteUser(ctx context.Context, ID string) (bool, error
 	return true, nil
 }
 
+func (r *AuthRepository) GetActiveBan(ctx context.Context, userID string) (*model.BanModel, error) {
+	params := append(activeBanParams(), model.Ban.UserID.Equals(userID))
+	return r.client.Ban.FindFirst(params...).OrderBy(
+		model.Ban.CreatedAt.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (r *AuthRepository) ListActiveIPBans(ctx context.Context) ([]model.NetworkBanModel, error) {
+	return listActiveIPBans(ctx, r.client)
+}
+
 func (r *AuthRepository) findUserByEmail(ctx context.Context, email string) (*model.UsersModel, error) {
 	user, err := r.client.Users.FindUnique(
 		model.Users.Email.Equals(email),
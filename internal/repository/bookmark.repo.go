@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type BookmarkRepository struct {
+	client *model.PrismaClient
+}
+
+func NewBookmarkRepository(prismaClient *model.PrismaClient) *BookmarkRepository {
+	return &BookmarkRepository{client: prismaClient}
+}
+
+func (r *BookmarkRepository) GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	return r.client.Thread.FindUnique(model.Thread.ID.Equals(threadID)).Exec(ctx)
+}
+
+// 북마크 수는 북마크 레코드와 같은 트랜잭션에서 함께 올려서 어긋나지 않도록 함
+func (r *BookmarkRepository) CreateBookmark(ctx context.Context, req *dto.CreateBookmarkRequest, threadTitle string) error {
+	params := []model.BookmarkSetParam{
+		model.Bookmark.Thread.Link(model.Thread.ID.Equals(req.ThreadID)),
+		model.Bookmark.Note.SetIfPresent(req.Note),
+	}
+	if req.FolderID != nil {
+		params = append(params, model.Bookmark.Folder.Link(model.BookmarkFolder.ID.Equals(*req.FolderID)))
+	}
+
+	return r.client.Prisma.Transaction(
+		r.client.Bookmark.CreateOne(
+			model.Bookmark.ThreadTitle.Set(threadTitle),
+			model.Bookmark.User.Link(model.Users.ID.Equals(req.UserID)),
+			params...,
+		).Tx(),
+		r.client.Thread.FindUnique(
+			model.Thread.ID.Equals(req.ThreadID),
+		).Update(
+			model.Thread.Bookmarks.Increment(1),
+		).Tx(),
+	).Exec(ctx)
+}
+
+func (r *BookmarkRepository) GetBookmarkByID(ctx context.Context, bookmarkID int) (*model.BookmarkModel, error) {
+	return r.client.Bookmark.FindUnique(
+		model.Bookmark.ID.Equals(bookmarkID),
+	).With(
+		model.Bookmark.Thread.Fetch(),
+	).Exec(ctx)
+}
+
+func (r *BookmarkRepository) GetBookmarkByThread(ctx context.Context, userID string, threadID int) (*model.BookmarkModel, error) {
+	return r.client.Bookmark.FindFirst(
+		model.Bookmark.UserID.Equals(userID),
+		model.Bookmark.ThreadID.Equals(threadID),
+	).With(
+		model.Bookmark.Thread.Fetch(),
+	).Exec(ctx)
+}
+
+func (r *BookmarkRepository) UpdateBookmark(ctx context.Context, bookmarkID int, params ...model.BookmarkSetParam) error {
+	_, err := r.client.Bookmark.FindUnique(
+		model.Bookmark.ID.Equals(bookmarkID),
+	).Update(params...).Exec(ctx)
+	return err
+}
+
+func (r *BookmarkRepository) DeleteBookmark(ctx context.Context, bookmark *model.BookmarkModel) error {
+	txns := []model.PrismaTransaction{
+		r.client.Bookmark.FindUnique(
+			model.Bookmark.ID.Equals(bookmark.ID),
+		).Delete().Tx(),
+	}
+	if threadID, ok := bookmark.ThreadID(); ok {
+		txns = append(txns, r.client.Thread.FindUnique(
+			model.Thread.ID.Equals(threadID),
+		).Update(
+			model.Thread.Bookmarks.Decrement(1),
+		).Tx())
+	}
+	return r.client.Prisma.Transaction(txns...).Exec(ctx)
+}
+
+// 북마크 도중에도 새 북마크가 추가될 수 있으므로 offset 대신 ID 커서로 페이지를 나눔
+func (r *BookmarkRepository) ListBookmarks(ctx context.Context, userID string, folderID, cursor, pageSize int) ([]model.BookmarkModel, error) {
+	params := []model.BookmarkWhereParam{
+		model.Bookmark.UserID.Equals(userID),
+	}
+	if folderID > 0 {
+		params = append(params, model.Bookmark.FolderID.Equals(folderID))
+	}
+	if cursor > 0 {
+		params = append(params, model.Bookmark.ID.Lt(cursor))
+	}
+
+	return r.client.Bookmark.FindMany(params...).OrderBy(
+		model.Bookmark.ID.Order(model.SortOrderDesc),
+	).With(
+		model.Bookmark.Thread.Fetch(),
+	).Take(pageSize).Exec(ctx)
+}
+
+func (r *BookmarkRepository) ListBookmarkedThreadIDs(ctx context.Context, userID string, threadIDs []int) ([]int, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+	bookmarks, err := r.client.Bookmark.FindMany(
+		model.Bookmark.UserID.Equals(userID),
+		model.Bookmark.ThreadID.In(threadIDs),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkedIDs := make([]int, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if threadID, ok := bookmark.ThreadID(); ok {
+			bookmarkedIDs = append(bookmarkedIDs, threadID)
+		}
+	}
+	return bookmarkedIDs, nil
+}
+
+func (r *BookmarkRepository) CreateFolder(ctx context.Context, req *dto.CreateBookmarkFolderRequest) (*model.BookmarkFolderModel, error) {
+	return r.client.BookmarkFolder.CreateOne(
+		model.BookmarkFolder.Name.Set(req.Name),
+		model.BookmarkFolder.User.Link(model.Users.ID.Equals(req.UserID)),
+	).Exec(ctx)
+}
+
+func (r *BookmarkRepository) GetFolderByID(ctx context.Context, folderID int) (*model.BookmarkFolderModel, error) {
+	return r.client.BookmarkFolder.FindUnique(model.BookmarkFolder.ID.Equals(folderID)).Exec(ctx)
+}
+
+func (r *BookmarkRepository) ListFolders(ctx context.Context, userID string) ([]model.BookmarkFolderModel, error) {
+	return r.client.BookmarkFolder.FindMany(
+		model.BookmarkFolder.UserID.Equals(userID),
+	).OrderBy(
+		model.BookmarkFolder.Name.Order(model.SortOrderAsc),
+	).Exec(ctx)
+}
+
+// 폴더를 지워도 안에 있던 북마크는 폴더 없이 남음 (onDelete: SetNull)
+func (r *BookmarkRepository) DeleteFolder(ctx context.Context, folderID int) error {
+	_, err := r.client.BookmarkFolder.FindUnique(
+		model.BookmarkFolder.ID.Equals(folderID),
+	).Delete().Exec(ctx)
+	return err
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type DirectMessageRepository struct {
+	client *model.PrismaClient
+}
+
+func NewDirectMessageRepository(prismaClient *model.PrismaClient) *DirectMessageRepository {
+	return &DirectMessageRepository{client: prismaClient}
+}
+
+func (r *DirectMessageRepository) GetUsersByHandles(ctx context.Context, handles []string) ([]model.UsersModel, error) {
+	return r.client.Users.FindMany(
+		model.Users.Handle.In(handles),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) FindDirectConversation(ctx context.Context, userID, otherID string) (*model.ConversationModel, error) {
+	return r.client.Conversation.FindFirst(
+		model.Conversation.IsGroup.Equals(false),
+		model.Conversation.Participants.Some(model.ConversationParticipant.UserID.Equals(userID)),
+		model.Conversation.Participants.Some(model.ConversationParticipant.UserID.Equals(otherID)),
+	).With(
+		model.Conversation.Participants.Fetch().With(model.ConversationParticipant.User.Fetch()),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) CreateConversation(ctx context.Context, isGroup bool, userIDs []string) (*model.ConversationModel, error) {
+	conversation, err := r.client.Conversation.CreateOne(
+		model.Conversation.IsGroup.Set(isGroup),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]model.PrismaTransaction, 0, len(userIDs))
+	for _, userID := range userIDs {
+		txns = append(txns, r.client.ConversationParticipant.CreateOne(
+			model.ConversationParticipant.Conversation.Link(model.Conversation.ID.Equals(conversation.ID)),
+			model.ConversationParticipant.User.Link(model.Users.ID.Equals(userID)),
+		).Tx())
+	}
+	if err := r.client.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		r.client.Conversation.FindUnique(model.Conversation.ID.Equals(conversation.ID)).Delete().Exec(ctx)
+		return nil, err
+	}
+
+	return r.GetConversationByID(ctx, conversation.ID)
+}
+
+func (r *DirectMessageRepository) GetConversationByID(ctx context.Context, conversationID int) (*model.ConversationModel, error) {
+	return r.client.Conversation.FindUnique(
+		model.Conversation.ID.Equals(conversationID),
+	).With(
+		model.Conversation.Participants.Fetch().With(model.ConversationParticipant.User.Fetch()),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) ListConversations(ctx context.Context, userID string) ([]model.ConversationModel, error) {
+	return r.client.Conversation.FindMany(
+		model.Conversation.Participants.Some(model.ConversationParticipant.UserID.Equals(userID)),
+	).With(
+		model.Conversation.Participants.Fetch().With(model.ConversationParticipant.User.Fetch()),
+	).OrderBy(
+		model.Conversation.LastMessageAt.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) GetParticipant(ctx context.Context, conversationID int, userID string) (*model.ConversationParticipantModel, error) {
+	return r.client.ConversationParticipant.FindFirst(
+		model.ConversationParticipant.ConversationID.Equals(conversationID),
+		model.ConversationParticipant.UserID.Equals(userID),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) CreateMessage(ctx context.Context, conversationID int, senderID, content string) (*model.DirectMessageModel, error) {
+	message, err := r.client.DirectMessage.CreateOne(
+		model.DirectMessage.Content.Set(content),
+		model.DirectMessage.Conversation.Link(model.Conversation.ID.Equals(conversationID)),
+		model.DirectMessage.Sender.Link(model.Users.ID.Equals(senderID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 보낸 사람은 자신의 메시지를 읽은 것으로 처리
+	err = r.client.Prisma.Transaction(
+		r.client.Conversation.FindUnique(
+			model.Conversation.ID.Equals(conversationID),
+		).Update(
+			model.Conversation.LastMessageAt.Set(message.CreatedAt),
+		).Tx(),
+		r.client.ConversationParticipant.FindMany(
+			model.ConversationParticipant.ConversationID.Equals(conversationID),
+			model.ConversationParticipant.UserID.Equals(senderID),
+		).Update(
+			model.ConversationParticipant.LastReadMessageID.Set(message.ID),
+		).Tx(),
+	).Exec(ctx)
+	return message, err
+}
+
+func (r *DirectMessageRepository) ListMessages(ctx context.Context, conversationID, cursor, pageSize int) ([]model.DirectMessageModel, error) {
+	params := []model.DirectMessageWhereParam{
+		model.DirectMessage.ConversationID.Equals(conversationID),
+	}
+	if cursor > 0 {
+		params = append(params, model.DirectMessage.ID.Lt(cursor))
+	}
+	return r.client.DirectMessage.FindMany(params...).OrderBy(
+		model.DirectMessage.ID.Order(model.SortOrderDesc),
+	).Take(pageSize).Exec(ctx)
+}
+
+/*
+사용자가 참여한 모든 대화방의 안 읽은 메시지 수를 한 번의 쿼리로 셈. (key: 대화방 ID)
+Prisma Go 클라이언트는 groupBy 집계를 지원하지 않아 raw 쿼리를 사용하며, MySQL의 COUNT는 BIGINT라 문자열로 전달됨.
+탈퇴한 사용자가 보낸 메시지(senderID가 NULL)도 안 읽은 메시지로 셈.
+*/
+func (r *DirectMessageRepository) CountUnreadMessages(ctx context.Context, userID string) (map[int]int, error) {
+	var rows []struct {
+		ConversationID int    `json:"conversationID"`
+		Unread         string `json:"unread"`
+	}
+	err := r.client.Prisma.QueryRaw(
+		"SELECT p.`conversationID` AS conversationID, COUNT(m.`id`) AS unread "+
+			"FROM `ConversationParticipant` p "+
+			"JOIN `DirectMessage` m ON m.`conversationID` = p.`conversationID` AND m.`id` > p.`lastReadMessageID` "+
+			"AND (m.`senderID` IS NULL OR m.`senderID` <> p.`userID`) "+
+			"WHERE p.`userID` = ? GROUP BY p.`conversationID`",
+		userID,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := make(map[int]int, len(rows))
+	for _, row := range rows {
+		count, err := strconv.Atoi(row.Unread)
+		if err != nil {
+			return nil, err
+		}
+		unread[row.ConversationID] = count
+	}
+	return unread, nil
+}
+
+func (r *DirectMessageRepository) GetMessage(ctx context.Context, conversationID, messageID int) (*model.DirectMessageModel, error) {
+	return r.client.DirectMessage.FindFirst(
+		model.DirectMessage.ID.Equals(messageID),
+		model.DirectMessage.ConversationID.Equals(conversationID),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) GetLatestMessage(ctx context.Context, conversationID int) (*model.DirectMessageModel, error) {
+	return r.client.DirectMessage.FindFirst(
+		model.DirectMessage.ConversationID.Equals(conversationID),
+	).OrderBy(
+		model.DirectMessage.ID.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (r *DirectMessageRepository) MarkRead(ctx context.Context, conversationID int, userID string, messageID int) error {
+	_, err := r.client.ConversationParticipant.FindMany(
+		model.ConversationParticipant.ConversationID.Equals(conversationID),
+		model.ConversationParticipant.UserID.Equals(userID),
+		model.ConversationParticipant.LastReadMessageID.Lt(messageID),
+	).Update(
+		model.ConversationParticipant.LastReadMessageID.Set(messageID),
+	).Exec(ctx)
+	return err
+}
+
+func (r *DirectMessageRepository) SetMuted(ctx context.Context, conversationID int, userID string, mutedAt *time.Time) error {
+	_, err := r.client.ConversationParticipant.FindMany(
+		model.ConversationParticipant.ConversationID.Equals(conversationID),
+		model.ConversationParticipant.UserID.Equals(userID),
+	).Update(
+		model.ConversationParticipant.MutedAt.SetOptional(mutedAt),
+	).Exec(ctx)
+	return err
+}
+
+func (r *DirectMessageRepository) RemoveParticipant(ctx context.Context, conversationID int, userID string) error {
+	if _, err := r.client.ConversationParticipant.FindMany(
+		model.ConversationParticipant.ConversationID.Equals(conversationID),
+		model.ConversationParticipant.UserID.Equals(userID),
+	).Delete().Exec(ctx); err != nil {
+		return err
+	}
+
+	// 마지막 참여자가 나가면 대화방도 함께 삭제
+	_, err := r.client.Conversation.FindMany(
+		model.Conversation.ID.Equals(conversationID),
+		model.Conversation.Participants.None(),
+	).Delete().Exec(ctx)
+	return err
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+func (s *Store) CreateAuditLog(ctx context.Context, entry dto.AuditEntry, diff []byte) (*model.AuditLogModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := model.InnerAuditLog{
+		ID:        s.nextID("AuditLog"),
+		Event:     entry.Event,
+		ActorID:   entry.ActorID,
+		CreatedAt: time.Now(),
+	}
+	if entry.TargetType != "" {
+		log.TargetType = stringPtr(entry.TargetType)
+	}
+	if entry.TargetID != "" {
+		log.TargetID = stringPtr(entry.TargetID)
+	}
+	if entry.IP != "" {
+		log.IP = stringPtr(entry.IP)
+	}
+	if entry.UserAgent != "" {
+		log.UserAgent = stringPtr(entry.UserAgent)
+	}
+	if diff != nil {
+		raw := model.JSON(diff)
+		log.Diff = &raw
+	}
+	s.auditLogs[log.ID] = log
+	return &model.AuditLogModel{InnerAuditLog: log}, nil
+}
+
+func (s *Store) ListAuditLogs(ctx context.Context, filter dto.AuditLogFilter, cursor, pageSize int) ([]model.AuditLogModel, error) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	logs := make([]model.AuditLogModel, 0)
+	for _, log := range s.auditLogs {
+		if filter.Event != nil && log.Event != *filter.Event {
+			continue
+		}
+		if filter.ActorID != "" && (log.ActorID == nil || *log.ActorID != filter.ActorID) {
+			continue
+		}
+		if filter.TargetID != "" && (log.TargetID == nil || *log.TargetID != filter.TargetID) {
+			continue
+		}
+		if filter.From != nil && log.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !log.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		if cursor > 0 && log.ID >= cursor {
+			continue
+		}
+		logs = append(logs, model.AuditLogModel{InnerAuditLog: log})
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].ID > logs[j].ID })
+	if len(logs) > pageSize {
+		logs = logs[:pageSize]
+	}
+	return logs, nil
+}
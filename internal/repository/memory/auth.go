@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/crypt"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+func (s *Store) CreateUser(ctx context.Context, req dto.RegisterRequest) (*model.UsersModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, user := range s.users {
+		if user.Handle == req.Handle {
+			return nil, uniqueConstraintError("handle")
+		}
+		if user.Email == req.Email {
+			return nil, uniqueConstraintError("email")
+		}
+	}
+
+	salt := crypt.EncodeBase64(utils.GenerateUUID())
+	now := time.Now()
+	user := model.InnerUsers{
+		ID:           utils.GenerateUUID(),
+		Handle:       req.Handle,
+		Email:        req.Email,
+		HashPassword: crypt.NewSHA256(req.Password, salt),
+		Salt:         salt,
+		Role:         model.UserRolesUser,
+		Name:         req.Name,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	s.users[user.ID] = user
+	return &model.UsersModel{InnerUsers: user}, nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*model.UsersModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, err := s.findUser(func(user model.InnerUsers) bool { return user.Email == email })
+	if err != nil {
+		return nil, err
+	}
+	return &model.UsersModel{InnerUsers: user}, nil
+}
+
+func (s *Store) GetUserPasswordByEmail(ctx context.Context, email string) (*dto.PasswordEntity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, err := s.findUser(func(user model.InnerUsers) bool { return user.Email == email })
+	if err != nil {
+		return nil, err
+	}
+	return passwordEntity(user), nil
+}
+
+func (s *Store) GetUserPasswordByID(ctx context.Context, ID string) (*dto.PasswordEntity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, exists := s.users[ID]
+	if !exists {
+		return nil, model.ErrNotFound
+	}
+	return passwordEntity(user), nil
+}
+
+func (s *Store) UpdateUserHandle(ctx context.Context, ID string, handle string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.users[ID]
+	if !exists {
+		return model.ErrNotFound
+	}
+	for _, other := range s.users {
+		if other.ID != ID && other.Handle == handle {
+			return uniqueConstraintError("handle")
+		}
+	}
+	user.Handle = handle
+	user.UpdatedAt = time.Now()
+	s.users[ID] = user
+	return nil
+}
+
+func (s *Store) GetUserByID(ctx context.Context, id string) (*model.UsersModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, exists := s.users[id]
+	if !exists {
+		return nil, model.ErrNotFound
+	}
+	return &model.UsersModel{InnerUsers: user}, nil
+}
+
+func (s *Store) GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, err := s.findUser(func(user model.InnerUsers) bool { return user.Handle == handle })
+	if err != nil {
+		return nil, err
+	}
+	return &model.UsersModel{InnerUsers: user}, nil
+}
+
+// AuthRepository.ListUsersByIDs와 같이 작성자 정보에 필요한 필드만 채움
+func (s *Store) ListUsersByIDs(ctx context.Context, IDs []string) ([]model.UsersModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]model.UsersModel, 0, len(IDs))
+	for _, ID := range IDs {
+		user, exists := s.users[ID]
+		if !exists {
+			continue
+		}
+		users = append(users, model.UsersModel{InnerUsers: model.InnerUsers{
+			ID:         user.ID,
+			Handle:     user.Handle,
+			Name:       user.Name,
+			ProfilePic: user.ProfilePic,
+		}})
+	}
+	return users, nil
+}
+
+func (s *Store) UpdateUserRole(ctx context.Context, ID string, role model.UserRoles) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.users[ID]
+	if !exists {
+		return model.ErrNotFound
+	}
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	s.users[ID] = user
+	return nil
+}
+
+func (s *Store) UpdateUserPassword(ctx context.Context, ID, salt, plainPassword string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.users[ID]
+	if !exists {
+		return model.ErrNotFound
+	}
+	user.HashPassword = crypt.NewSHA256(plainPassword, salt)
+	user.UpdatedAt = time.Now()
+	s.users[ID] = user
+	return nil
+}
+
+// 스키마의 onDelete: Cascade를 따라 유저가 가진 쓰레드, 관계, 업로드, 차단, 북마크를 함께 지움
+func (s *Store) DeleteUser(ctx context.Context, ID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[ID]; !exists {
+		return false, model.ErrNotFound
+	}
+
+	for threadID, thread := range s.threads {
+		if thread.UserID == ID {
+			s.deleteThread(threadID)
+		}
+	}
+	for relationID, relation := range s.relations {
+		if relation.UserID == ID || relation.TargetID == ID {
+			delete(s.relations, relationID)
+		}
+	}
+	for uploadID, upload := range s.uploads {
+		if upload.UserID == ID {
+			s.deleteUpload(uploadID)
+		}
+	}
+	for banID, ban := range s.bans {
+		if ban.UserID == ID {
+			delete(s.bans, banID)
+		}
+	}
+	for bookmarkID, bookmark := range s.bookmarks {
+		if bookmark.UserID == ID {
+			delete(s.bookmarks, bookmarkID)
+		}
+	}
+	delete(s.users, ID)
+	return true, nil
+}
+
+func (s *Store) GetActiveBan(ctx context.Context, userID string) (*model.BanModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var active []model.InnerBan
+	for _, ban := range s.bans {
+		if ban.UserID == userID && isActive(ban.RevokedAt, ban.ExpiresAt, now) {
+			active = append(active, ban)
+		}
+	}
+	if len(active) == 0 {
+		return nil, model.ErrNotFound
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].CreatedAt.After(active[j].CreatedAt) })
+	return &model.BanModel{InnerBan: active[0]}, nil
+}
+
+func (s *Store) ListActiveIPBans(ctx context.Context) ([]model.NetworkBanModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	ipBans := make([]model.NetworkBanModel, 0)
+	for _, ban := range s.ipBans {
+		if isActive(ban.RevokedAt, ban.ExpiresAt, now) {
+			ipBans = append(ipBans, model.NetworkBanModel{InnerNetworkBan: ban})
+		}
+	}
+	sort.Slice(ipBans, func(i, j int) bool { return ipBans[i].CreatedAt.After(ipBans[j].CreatedAt) })
+	return ipBans, nil
+}
+
+// 테스트에서 차단된 유저를 준비할 때 사용하며 ModerationRepository.CreateBan과 같은 동작을 함
+func (s *Store) CreateBan(ctx context.Context, userID, reason, createdBy string, expiresAt *time.Time) (*model.BanModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[userID]; !exists {
+		return nil, model.ErrNotFound
+	}
+	ban := model.InnerBan{
+		ID:        s.nextID("Ban"),
+		UserID:    userID,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	s.bans[ban.ID] = ban
+	return &model.BanModel{InnerBan: ban}, nil
+}
+
+func (s *Store) CreateIPBan(ctx context.Context, cidr, reason, createdBy string, expiresAt *time.Time) (*model.NetworkBanModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ban := model.InnerNetworkBan{
+		ID:        s.nextID("NetworkBan"),
+		Cidr:      cidr,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	s.ipBans[ban.ID] = ban
+	return &model.NetworkBanModel{InnerNetworkBan: ban}, nil
+}
+
+func (s *Store) findUser(match func(model.InnerUsers) bool) (model.InnerUsers, error) {
+	for _, user := range s.users {
+		if match(user) {
+			return user, nil
+		}
+	}
+	return model.InnerUsers{}, model.ErrNotFound
+}
+
+func passwordEntity(user model.InnerUsers) *dto.PasswordEntity {
+	return &dto.PasswordEntity{
+		ID:           user.ID,
+		HashPassword: user.HashPassword,
+		Salt:         user.Salt,
+		Role:         user.Role,
+		Handle:       user.Handle,
+	}
+}
+
+// activeBanParams와 같이 해제되지 않았고 만료되지 않은 차단만 유효함
+func isActive(revokedAt, expiresAt *model.DateTime, now time.Time) bool {
+	return revokedAt == nil && (expiresAt == nil || expiresAt.After(now))
+}
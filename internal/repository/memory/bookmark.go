@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+// 북마크 폴더는 구현하지 않으므로 폴더를 지정하면 model.ErrNotFound를 반환함
+func (s *Store) CreateBookmark(ctx context.Context, req *dto.CreateBookmarkRequest, threadTitle string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[req.UserID]; !exists {
+		return model.ErrNotFound
+	}
+	thread, exists := s.threads[req.ThreadID]
+	if !exists || req.FolderID != nil {
+		return model.ErrNotFound
+	}
+	for _, bookmark := range s.bookmarks {
+		if bookmark.UserID == req.UserID && pointsTo(bookmark.ThreadID, req.ThreadID) {
+			return uniqueConstraintError("userID", "threadID")
+		}
+	}
+
+	now := time.Now()
+	threadID := req.ThreadID
+	bookmark := model.InnerBookmark{
+		ID:          s.nextID("Bookmark"),
+		UserID:      req.UserID,
+		ThreadID:    &threadID,
+		ThreadTitle: threadTitle,
+		Note:        req.Note,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.bookmarks[bookmark.ID] = bookmark
+
+	thread.Bookmarks++
+	s.threads[thread.ID] = thread
+	return nil
+}
+
+func (s *Store) ListBookmarkedThreadIDs(ctx context.Context, userID string, threadIDs []int) ([]int, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	bookmarkedIDs := make([]int, 0, len(threadIDs))
+	for _, bookmark := range s.bookmarks {
+		if bookmark.UserID != userID || bookmark.ThreadID == nil {
+			continue
+		}
+		for _, threadID := range threadIDs {
+			if *bookmark.ThreadID == threadID {
+				bookmarkedIDs = append(bookmarkedIDs, threadID)
+				break
+			}
+		}
+	}
+	sort.Ints(bookmarkedIDs)
+	return bookmarkedIDs, nil
+}
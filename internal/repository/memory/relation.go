@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+// 이미 같은 관계가 있다면 그대로 성공으로 처리
+func (s *Store) CreateRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[userID]; !exists {
+		return model.ErrNotFound
+	}
+	if _, exists := s.users[targetID]; !exists {
+		return model.ErrNotFound
+	}
+	for _, relation := range s.relations {
+		if relation.UserID == userID && relation.TargetID == targetID && relation.Type == relationType {
+			return nil
+		}
+	}
+
+	relation := model.InnerUserRelation{
+		ID:        s.nextID("UserRelation"),
+		UserID:    userID,
+		TargetID:  targetID,
+		Type:      relationType,
+		CreatedAt: time.Now(),
+	}
+	s.relations[relation.ID] = relation
+	return nil
+}
+
+func (s *Store) DeleteRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for relationID, relation := range s.relations {
+		if relation.UserID == userID && relation.TargetID == targetID && relation.Type == relationType {
+			delete(s.relations, relationID)
+		}
+	}
+	return nil
+}
+
+func (s *Store) ListRelations(ctx context.Context, userID string, relationType model.RelationType) ([]model.UserRelationModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	relations := make([]model.UserRelationModel, 0)
+	for _, relation := range s.relations {
+		if relation.UserID != userID || relation.Type != relationType {
+			continue
+		}
+		target := model.UsersModel{InnerUsers: s.users[relation.TargetID]}
+		relations = append(relations, model.UserRelationModel{
+			InnerUserRelation:     relation,
+			RelationsUserRelation: model.RelationsUserRelation{Target: &target},
+		})
+	}
+	sort.Slice(relations, func(i, j int) bool { return relations[i].CreatedAt.After(relations[j].CreatedAt) })
+	return relations, nil
+}
+
+func (s *Store) ListRelationsByOwner(ctx context.Context, userID string) ([]model.UserRelationModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	relations := make([]model.UserRelationModel, 0)
+	for _, relation := range s.relations {
+		if relation.UserID == userID {
+			relations = append(relations, model.UserRelationModel{InnerUserRelation: relation})
+		}
+	}
+	sort.Slice(relations, func(i, j int) bool { return relations[i].ID < relations[j].ID })
+	return relations, nil
+}
+
+func (s *Store) IsBlocked(ctx context.Context, ownerID, targetID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, relation := range s.relations {
+		if relation.Type == model.RelationTypeBlock && relation.UserID == ownerID && relation.TargetID == targetID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) HasBlockBetween(ctx context.Context, userID string, otherIDs []string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, relation := range s.relations {
+		if relation.Type != model.RelationTypeBlock {
+			continue
+		}
+		if relation.UserID == userID && containsString(otherIDs, relation.TargetID) {
+			return true, nil
+		}
+		if relation.TargetID == userID && containsString(otherIDs, relation.UserID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
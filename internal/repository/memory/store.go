@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+)
+
+var (
+	_ repository.AuthStore      = (*Store)(nil)
+	_ repository.ThreadStore    = (*Store)(nil)
+	_ repository.RelationStore  = (*Store)(nil)
+	_ repository.AuditStore     = (*Store)(nil)
+	_ repository.UserLookup     = (*Store)(nil)
+	_ repository.UploadLookup   = (*Store)(nil)
+	_ repository.BookmarkLookup = (*Store)(nil)
+)
+
+/*
+DB 없이 서비스를 테스트할 수 있도록 저장소 인터페이스를 메모리에서 구현함.
+테이블 사이의 Cascade, SetNull을 맞추기 위해 모든 테이블을 하나의 Store에 두고 하나의 락으로 보호함.
+unique 제약은 model.IsErrUniqueConstraint로 구분되도록 Prisma와 같은 형식의 에러를 반환하고,
+없는 레코드는 model.ErrNotFound를 반환함.
+대화방(DM), 투표 등 서비스 테스트에서 다루지 않는 테이블은 구현하지 않음.
+*/
+type Store struct {
+	mu sync.RWMutex
+
+	users       map[string]model.InnerUsers
+	threads     map[int]model.InnerThread
+	attachments map[int]model.InnerAttachment
+	uploads     map[string]model.InnerUpload
+	relations   map[int]model.InnerUserRelation
+	bans        map[int]model.InnerBan
+	ipBans      map[int]model.InnerNetworkBan
+	bookmarks   map[int]model.InnerBookmark
+	auditLogs   map[int]model.InnerAuditLog
+
+	sequences map[string]int
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:       make(map[string]model.InnerUsers),
+		threads:     make(map[int]model.InnerThread),
+		attachments: make(map[int]model.InnerAttachment),
+		uploads:     make(map[string]model.InnerUpload),
+		relations:   make(map[int]model.InnerUserRelation),
+		bans:        make(map[int]model.InnerBan),
+		ipBans:      make(map[int]model.InnerNetworkBan),
+		bookmarks:   make(map[int]model.InnerBookmark),
+		auditLogs:   make(map[int]model.InnerAuditLog),
+		sequences:   make(map[string]int),
+	}
+}
+
+// HTTP 테스트가 DB와 같은 골든 파일을 쓸 수 있도록 MySQL autoincrement처럼 테이블마다 1부터 셈
+func (s *Store) nextID(table string) int {
+	s.sequences[table]++
+	return s.sequences[table]
+}
+
+// Prisma가 unique 제약 위반 시 반환하는 P2002 에러와 같은 형식
+func uniqueConstraintError(fields ...string) error {
+	target := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		target = append(target, field)
+	}
+	return &protocol.UserFacingError{
+		ErrorCode: "P2002",
+		Message:   "Unique constraint failed on the fields",
+		Meta:      protocol.Meta{Target: target},
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,417 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+func (s *Store) CreateThread(ctx context.Context, req *dto.CreateThreadRequest, status model.ThreadStatus) (*model.ThreadModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[req.UserID]; !exists {
+		return nil, model.ErrNotFound
+	}
+
+	now := time.Now()
+	thread := model.InnerThread{
+		ID:        s.nextID("Thread"),
+		UserID:    req.UserID,
+		Title:     req.Title,
+		ImgURL:    req.ImgUrl,
+		Content:   req.Content,
+		Status:    status,
+		PublishAt: req.PublishAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.threads[thread.ID] = thread
+	return &model.ThreadModel{InnerThread: thread}, nil
+}
+
+// 트랜잭션과 같이 연결할 쓰레드와 첨부 파일을 모두 확인한 뒤에만 저장함
+func (s *Store) LinkThread(ctx context.Context, threadID int, req *dto.CreateThreadRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists {
+		return model.ErrNotFound
+	}
+
+	var linkTarget *int
+	if req.ParentThread != nil {
+		linkTarget = req.ParentThread
+	} else if req.NextThread != nil {
+		linkTarget = req.NextThread
+	} else if req.PrevThread != nil {
+		linkTarget = req.PrevThread
+	}
+	if linkTarget != nil {
+		if _, exists := s.threads[*linkTarget]; !exists {
+			return model.ErrNotFound
+		}
+	}
+
+	for _, attachment := range req.Attachments {
+		if _, exists := s.uploads[attachment.UploadID]; !exists {
+			return model.ErrNotFound
+		}
+		for _, attached := range s.attachments {
+			if attached.UploadID == attachment.UploadID {
+				return uniqueConstraintError("uploadID")
+			}
+		}
+	}
+
+	if req.ParentThread != nil {
+		thread.ParentThread = linkTarget
+	} else if req.NextThread != nil {
+		thread.NextThread = linkTarget
+	} else if req.PrevThread != nil {
+		thread.PrevThread = linkTarget
+	}
+	s.threads[threadID] = thread
+
+	for position, attachment := range req.Attachments {
+		inner := model.InnerAttachment{
+			ID:        s.nextID("Attachment"),
+			ThreadID:  threadID,
+			UploadID:  attachment.UploadID,
+			Position:  position,
+			CreatedAt: time.Now(),
+		}
+		if attachment.AltText != "" {
+			inner.AltText = stringPtr(attachment.AltText)
+		}
+		s.attachments[inner.ID] = inner
+	}
+	return nil
+}
+
+// 고정된 쓰레드는 ListPinnedThread로 따로 가져오므로 제외
+func (s *Store) ListThread(ctx context.Context, offset, limit int) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return isVisibleRoot(thread) && (thread.PinnedUntil == nil || thread.PinnedUntil.Before(now))
+	})
+	sortThreadsByID(threads)
+
+	if offset >= len(threads) {
+		return []model.ThreadModel{}, nil
+	}
+	end := offset + limit
+	if end > len(threads) {
+		end = len(threads)
+	}
+	return s.withAuthors(s.withAttachments(threads[offset:end])), nil
+}
+
+func (s *Store) ListPinnedThread(ctx context.Context) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return isVisibleRoot(thread) && thread.PinnedUntil != nil && thread.PinnedUntil.After(now)
+	})
+	sort.Slice(threads, func(i, j int) bool { return threads[i].PinnedUntil.After(*threads[j].PinnedUntil) })
+	return s.withAuthors(s.withAttachments(threads)), nil
+}
+
+func (s *Store) ListAttachments(ctx context.Context, threadID int) ([]model.AttachmentModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.threadAttachments(threadID), nil
+}
+
+func (s *Store) ListThreadByHandle(ctx context.Context, handle string) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, err := s.findUser(func(user model.InnerUsers) bool { return user.Handle == handle })
+	if err != nil {
+		return nil, err
+	}
+
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return thread.UserID == user.ID && thread.HiddenAt == nil && thread.Status == model.ThreadStatusPublished
+	})
+	sortThreadsByID(threads)
+	return s.withAuthors(s.withAttachments(threads)), nil
+}
+
+func (s *Store) GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists {
+		return nil, model.ErrNotFound
+	}
+	return &model.ThreadModel{InnerThread: thread}, nil
+}
+
+func (s *Store) CommentsByID(ctx context.Context, threadID int) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return thread.ParentThread != nil && *thread.ParentThread == threadID &&
+			thread.HiddenAt == nil && thread.Status == model.ThreadStatusPublished
+	})
+	sortThreadsByID(threads)
+	return s.withAuthors(s.withAttachments(threads)), nil
+}
+
+func (s *Store) GetDraftByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists {
+		return nil, model.ErrNotFound
+	}
+	return &s.withAttachments([]model.InnerThread{thread})[0], nil
+}
+
+func (s *Store) ListDrafts(ctx context.Context, userID string) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return thread.UserID == userID && isUnpublished(thread)
+	})
+	sort.Slice(threads, func(i, j int) bool { return threads[i].UpdatedAt.After(threads[j].UpdatedAt) })
+	return s.withAttachments(threads), nil
+}
+
+func (s *Store) UpdateDraft(ctx context.Context, threadID int, update dto.DraftUpdateEntity) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists || !isUnpublished(thread) {
+		return false, nil
+	}
+	if update.Title != nil {
+		thread.Title = *update.Title
+	}
+	if update.Content != nil {
+		thread.Content = *update.Content
+	}
+	if update.Status != nil {
+		thread.Status = *update.Status
+		thread.PublishAt = update.PublishAt
+	}
+	thread.UpdatedAt = time.Now()
+	s.threads[threadID] = thread
+	return true, nil
+}
+
+func (s *Store) ListDueDrafts(ctx context.Context, now time.Time, pageSize int) ([]model.ThreadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	threads := s.filterThreads(func(thread model.InnerThread) bool {
+		return thread.Status == model.ThreadStatusScheduled && thread.PublishAt != nil && !thread.PublishAt.After(now)
+	})
+	sort.Slice(threads, func(i, j int) bool { return threads[i].PublishAt.Before(*threads[j].PublishAt) })
+	if pageSize > 0 && len(threads) > pageSize {
+		threads = threads[:pageSize]
+	}
+	return toThreadModels(threads), nil
+}
+
+func (s *Store) PublishDraft(ctx context.Context, threadID int, fromStatus ...model.ThreadStatus) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists || !containsStatus(fromStatus, thread.Status) {
+		return false, nil
+	}
+	now := time.Now()
+	thread.Status = model.ThreadStatusPublished
+	thread.PublishAt = nil
+	thread.CreatedAt = now
+	thread.UpdatedAt = now
+	s.threads[threadID] = thread
+	return true, nil
+}
+
+func (s *Store) RemoveThreadByID(ctx context.Context, userID string, threadID int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.threads[threadID]; !exists {
+		return false, model.ErrNotFound
+	}
+	s.deleteThread(threadID)
+	return true, nil
+}
+
+func (s *Store) SetThreadPinned(ctx context.Context, threadID int, pinnedUntil *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, exists := s.threads[threadID]
+	if !exists {
+		return model.ErrNotFound
+	}
+	thread.PinnedUntil = pinnedUntil
+	s.threads[threadID] = thread
+	return nil
+}
+
+// 트랜잭션과 같이 하나라도 반영할 수 없으면 아무것도 반영하지 않음
+func (s *Store) ApplyInteractions(ctx context.Context, deltas []dto.InteractionDeltaEntity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delta := range deltas {
+		if _, exists := s.threads[delta.ThreadID]; !exists {
+			return model.ErrNotFound
+		}
+		switch delta.Field {
+		case "views", "rawViews", "likes", "dislikes":
+		default:
+			return fmt.Errorf("unknown interaction field: %s", delta.Field)
+		}
+	}
+
+	for _, delta := range deltas {
+		thread := s.threads[delta.ThreadID]
+		switch delta.Field {
+		case "views":
+			thread.Views += delta.Amount
+		case "rawViews":
+			thread.RawViews += delta.Amount
+		case "likes":
+			thread.Likes += delta.Amount
+		case "dislikes":
+			thread.Dislikes += delta.Amount
+		}
+		thread.UpdatedAt = time.Now()
+		s.threads[delta.ThreadID] = thread
+	}
+	return nil
+}
+
+/*
+스키마의 parent, next, prev 관계는 모두 onDelete: Cascade이므로 이 쓰레드를 가리키는 쓰레드도 함께 지우고,
+첨부는 Cascade, 북마크는 SetNull로 쓰레드 연결만 끊음.
+*/
+func (s *Store) deleteThread(threadID int) {
+	if _, exists := s.threads[threadID]; !exists {
+		return
+	}
+	delete(s.threads, threadID)
+
+	for attachmentID, attachment := range s.attachments {
+		if attachment.ThreadID == threadID {
+			delete(s.attachments, attachmentID)
+		}
+	}
+	for bookmarkID, bookmark := range s.bookmarks {
+		if bookmark.ThreadID != nil && *bookmark.ThreadID == threadID {
+			bookmark.ThreadID = nil
+			s.bookmarks[bookmarkID] = bookmark
+		}
+	}
+	for childID, child := range s.threads {
+		if pointsTo(child.ParentThread, threadID) || pointsTo(child.NextThread, threadID) || pointsTo(child.PrevThread, threadID) {
+			s.deleteThread(childID)
+		}
+	}
+}
+
+func (s *Store) filterThreads(match func(model.InnerThread) bool) []model.InnerThread {
+	threads := make([]model.InnerThread, 0)
+	for _, thread := range s.threads {
+		if match(thread) {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+// Prisma의 fetchAttachments()와 같이 첨부를 위치 순으로, 업로드 정보와 함께 채움
+func (s *Store) withAttachments(threads []model.InnerThread) []model.ThreadModel {
+	models := make([]model.ThreadModel, 0, len(threads))
+	for _, thread := range threads {
+		models = append(models, model.ThreadModel{
+			InnerThread:     thread,
+			RelationsThread: model.RelationsThread{Attachments: s.threadAttachments(thread.ID)},
+		})
+	}
+	return models
+}
+
+// Prisma의 fetchAuthor()와 같이 작성자를 채움
+func (s *Store) withAuthors(models []model.ThreadModel) []model.ThreadModel {
+	for i := range models {
+		author := model.UsersModel{InnerUsers: s.users[models[i].UserID]}
+		models[i].RelationsThread.User = &author
+	}
+	return models
+}
+
+func (s *Store) threadAttachments(threadID int) []model.AttachmentModel {
+	attachments := make([]model.AttachmentModel, 0)
+	for _, attachment := range s.attachments {
+		if attachment.ThreadID != threadID {
+			continue
+		}
+		upload := model.UploadModel{InnerUpload: s.uploads[attachment.UploadID]}
+		attachments = append(attachments, model.AttachmentModel{
+			InnerAttachment:     attachment,
+			RelationsAttachment: model.RelationsAttachment{Upload: &upload},
+		})
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].Position < attachments[j].Position })
+	return attachments
+}
+
+func toThreadModels(threads []model.InnerThread) []model.ThreadModel {
+	models := make([]model.ThreadModel, 0, len(threads))
+	for _, thread := range threads {
+		models = append(models, model.ThreadModel{InnerThread: thread})
+	}
+	return models
+}
+
+// 정렬 조건이 없는 Prisma 조회는 MySQL 기본 순서(기본 키 순)로 반환되므로 ID 순으로 맞춤
+func sortThreadsByID(threads []model.InnerThread) {
+	sort.Slice(threads, func(i, j int) bool { return threads[i].ID < threads[j].ID })
+}
+
+func isVisibleRoot(thread model.InnerThread) bool {
+	return thread.ParentThread == nil && thread.HiddenAt == nil && thread.Status == model.ThreadStatusPublished
+}
+
+func isUnpublished(thread model.InnerThread) bool {
+	return thread.Status == model.ThreadStatusDraft || thread.Status == model.ThreadStatusScheduled
+}
+
+func containsStatus(statuses []model.ThreadStatus, target model.ThreadStatus) bool {
+	for _, status := range statuses {
+		if status == target {
+			return true
+		}
+	}
+	return false
+}
+
+func pointsTo(ref *int, threadID int) bool {
+	return ref != nil && *ref == threadID
+}
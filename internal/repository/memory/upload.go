@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+// 테스트에서 첨부할 업로드를 준비할 때 사용하며 UploadRepository.CreateUpload와 같은 동작을 함
+func (s *Store) CreateUpload(ctx context.Context, entity dto.UploadEntity) (*model.UploadModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[entity.UserID]; !exists {
+		return nil, model.ErrNotFound
+	}
+	if _, exists := s.uploads[entity.ID]; exists {
+		return nil, uniqueConstraintError("id")
+	}
+	for _, upload := range s.uploads {
+		if upload.BlobKey == entity.BlobKey {
+			return nil, uniqueConstraintError("blobKey")
+		}
+	}
+
+	upload := model.InnerUpload{
+		ID:                   entity.ID,
+		UserID:               entity.UserID,
+		Filename:             entity.Filename,
+		ContentType:          entity.ContentType,
+		Size:                 entity.Size,
+		Width:                entity.Width,
+		Height:               entity.Height,
+		BlobKey:              entity.BlobKey,
+		ThumbnailKey:         entity.ThumbnailKey,
+		ThumbnailContentType: entity.ThumbnailContentType,
+		CreatedAt:            time.Now(),
+	}
+	s.uploads[upload.ID] = upload
+	return &model.UploadModel{InnerUpload: upload}, nil
+}
+
+func (s *Store) ListUploadsByIDs(ctx context.Context, uploadIDs []string) ([]model.UploadModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	uploads := make([]model.UploadModel, 0, len(uploadIDs))
+	for _, upload := range s.uploads {
+		if containsString(uploadIDs, upload.ID) {
+			uploads = append(uploads, model.UploadModel{InnerUpload: upload})
+		}
+	}
+	sort.Slice(uploads, func(i, j int) bool { return uploads[i].ID < uploads[j].ID })
+	return uploads, nil
+}
+
+func (s *Store) ListAttachedUploadIDs(ctx context.Context, uploadIDs []string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attachedIDs := make([]string, 0, len(uploadIDs))
+	for _, attachment := range s.attachments {
+		if containsString(uploadIDs, attachment.UploadID) {
+			attachedIDs = append(attachedIDs, attachment.UploadID)
+		}
+	}
+	sort.Strings(attachedIDs)
+	return attachedIDs, nil
+}
+
+// 업로드를 지우면 스키마의 onDelete: Cascade를 따라 첨부도 함께 지움
+func (s *Store) deleteUpload(uploadID string) {
+	delete(s.uploads, uploadID)
+	for attachmentID, attachment := range s.attachments {
+		if attachment.UploadID == uploadID {
+			delete(s.attachments, attachmentID)
+		}
+	}
+}
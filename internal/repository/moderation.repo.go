@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type ModerationRepository struct {
+	client *model.PrismaClient
+}
+
+func NewModerationRepository(prismaClient *model.PrismaClient) *ModerationRepository {
+	return &ModerationRepository{client: prismaClient}
+}
+
+func (r *ModerationRepository) GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error) {
+	return r.client.Users.FindUnique(
+		model.Users.Handle.Equals(handle),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) GetUserByID(ctx context.Context, userID string) (*model.UsersModel, error) {
+	return r.client.Users.FindUnique(
+		model.Users.ID.Equals(userID),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	return r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) CreateReport(ctx context.Context, reporterID, targetKey string, req *dto.CreateReportRequest, targetUserID *string) (*model.ReportModel, error) {
+	params := []model.ReportSetParam{
+		model.Report.Detail.Set(req.Detail),
+	}
+	if req.ThreadID != nil {
+		params = append(params, model.Report.Thread.Link(model.Thread.ID.Equals(*req.ThreadID)))
+	}
+	if targetUserID != nil {
+		params = append(params, model.Report.TargetUser.Link(model.Users.ID.Equals(*targetUserID)))
+	}
+
+	return r.client.Report.CreateOne(
+		model.Report.TargetType.Set(model.ReportTargetType(req.TargetType)),
+		model.Report.TargetKey.Set(targetKey),
+		model.Report.Reason.Set(model.ReportReason(req.Reason)),
+		model.Report.Reporter.Link(model.Users.ID.Equals(reporterID)),
+		params...,
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) GetReportByID(ctx context.Context, reportID int) (*model.ReportModel, error) {
+	return r.client.Report.FindUnique(
+		model.Report.ID.Equals(reportID),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) ListReports(ctx context.Context, status string, pageNumber, pageSize int) ([]model.ReportModel, error) {
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (pageNumber - 1) * pageSize
+
+	params := make([]model.ReportWhereParam, 0)
+	if status != "" {
+		params = append(params, model.Report.Status.Equals(model.ReportStatus(status)))
+	}
+	return r.client.Report.FindMany(params...).OrderBy(
+		model.Report.CreatedAt.Order(model.SortOrderAsc),
+	).Take(pageSize).Skip(offset).Exec(ctx)
+}
+
+func (r *ModerationRepository) ListOpenThreadReports(ctx context.Context, threadID int) ([]model.ReportModel, error) {
+	return r.client.Report.FindMany(
+		model.Report.ThreadID.Equals(threadID),
+		model.Report.Status.Equals(model.ReportStatusOpen),
+	).Exec(ctx)
+}
+
+// 처리되지 않은 신고만 상태를 바꿀 수 있음. 변경된 신고가 없으면 false를 반환
+func (r *ModerationRepository) UpdateReportStatus(ctx context.Context, reportID int, status model.ReportStatus, handledBy string) (bool, error) {
+	result, err := r.client.Report.FindMany(
+		model.Report.ID.Equals(reportID),
+		model.Report.Status.Equals(model.ReportStatusOpen),
+	).Update(
+		model.Report.Status.Set(status),
+		model.Report.HandledBy.Set(handledBy),
+		model.Report.HandledAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+func (r *ModerationRepository) ResolveThreadReports(ctx context.Context, threadID int, handledBy string) error {
+	_, err := r.client.Report.FindMany(
+		model.Report.ThreadID.Equals(threadID),
+		model.Report.Status.Equals(model.ReportStatusOpen),
+	).Update(
+		model.Report.Status.Set(model.ReportStatusActioned),
+		model.Report.HandledBy.Set(handledBy),
+		model.Report.HandledAt.Set(time.Now()),
+	).Exec(ctx)
+	return err
+}
+
+func (r *ModerationRepository) SetThreadHidden(ctx context.Context, threadID int, hiddenAt *time.Time) error {
+	_, err := r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).Update(
+		model.Thread.HiddenAt.SetOptional(hiddenAt),
+	).Exec(ctx)
+	return err
+}
+
+func (r *ModerationRepository) SetThreadLocked(ctx context.Context, threadID int, lockedAt *time.Time, lockedBy *string) error {
+	_, err := r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).Update(
+		model.Thread.LockedAt.SetOptional(lockedAt),
+		model.Thread.LockedBy.SetOptional(lockedBy),
+	).Exec(ctx)
+	return err
+}
+
+func (r *ModerationRepository) SetThreadPinned(ctx context.Context, threadID int, pinnedUntil *time.Time) error {
+	_, err := r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).Update(
+		model.Thread.PinnedUntil.SetOptional(pinnedUntil),
+	).Exec(ctx)
+	return err
+}
+
+func (r *ModerationRepository) CreateAction(ctx context.Context, entity dto.ModerationActionEntity) (*model.ModerationActionModel, error) {
+	params := []model.ModerationActionSetParam{
+		model.ModerationAction.ModeratorID.SetIfPresent(entity.ModeratorID),
+		model.ModerationAction.ThreadID.SetIfPresent(entity.ThreadID),
+		model.ModerationAction.TargetUserID.SetIfPresent(entity.TargetUserID),
+		model.ModerationAction.ReportID.SetIfPresent(entity.ReportID),
+		model.ModerationAction.ExpiresAt.SetIfPresent(entity.ExpiresAt),
+	}
+	if entity.Note != "" {
+		params = append(params, model.ModerationAction.Note.Set(entity.Note))
+	}
+
+	return r.client.ModerationAction.CreateOne(
+		model.ModerationAction.Action.Set(entity.Action),
+		params...,
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) ListActions(ctx context.Context, pageNumber, pageSize int) ([]model.ModerationActionModel, error) {
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (pageNumber - 1) * pageSize
+
+	return r.client.ModerationAction.FindMany().OrderBy(
+		model.ModerationAction.CreatedAt.Order(model.SortOrderDesc),
+	).Take(pageSize).Skip(offset).Exec(ctx)
+}
+
+func (r *ModerationRepository) CreateBan(ctx context.Context, userID, reason, createdBy string, expiresAt *time.Time) (*model.BanModel, error) {
+	return r.client.Ban.CreateOne(
+		model.Ban.Reason.Set(reason),
+		model.Ban.CreatedBy.Set(createdBy),
+		model.Ban.User.Link(model.Users.ID.Equals(userID)),
+		model.Ban.ExpiresAt.SetIfPresent(expiresAt),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) ListActiveBans(ctx context.Context) ([]model.BanModel, error) {
+	return r.client.Ban.FindMany(
+		activeBanParams()...,
+	).OrderBy(
+		model.Ban.CreatedAt.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) RevokeBans(ctx context.Context, userID, revokedBy string) (int, error) {
+	params := append(activeBanParams(), model.Ban.UserID.Equals(userID))
+	result, err := r.client.Ban.FindMany(params...).Update(
+		model.Ban.RevokedAt.Set(time.Now()),
+		model.Ban.RevokedBy.Set(revokedBy),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+func (r *ModerationRepository) CreateIPBan(ctx context.Context, cidr, reason, createdBy string, expiresAt *time.Time) (*model.NetworkBanModel, error) {
+	return r.client.NetworkBan.CreateOne(
+		model.NetworkBan.Cidr.Set(cidr),
+		model.NetworkBan.Reason.Set(reason),
+		model.NetworkBan.CreatedBy.Set(createdBy),
+		model.NetworkBan.ExpiresAt.SetIfPresent(expiresAt),
+	).Exec(ctx)
+}
+
+func (r *ModerationRepository) ListActiveIPBans(ctx context.Context) ([]model.NetworkBanModel, error) {
+	return listActiveIPBans(ctx, r.client)
+}
+
+func (r *ModerationRepository) RevokeIPBan(ctx context.Context, banID int, revokedBy string) (bool, error) {
+	result, err := r.client.NetworkBan.FindMany(
+		model.NetworkBan.ID.Equals(banID),
+		model.NetworkBan.RevokedAt.IsNull(),
+	).Update(
+		model.NetworkBan.RevokedAt.Set(time.Now()),
+		model.NetworkBan.RevokedBy.Set(revokedBy),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 해제되지 않았고, 영구 정지이거나 아직 만료되지 않은 정지
+func activeBanParams() []model.BanWhereParam {
+	return []model.BanWhereParam{
+		model.Ban.RevokedAt.IsNull(),
+		model.Ban.Or(
+			model.Ban.ExpiresAt.IsNull(),
+			model.Ban.ExpiresAt.After(time.Now()),
+		),
+	}
+}
+
+func listActiveIPBans(ctx context.Context, client *model.PrismaClient) ([]model.NetworkBanModel, error) {
+	return client.NetworkBan.FindMany(
+		model.NetworkBan.RevokedAt.IsNull(),
+		model.NetworkBan.Or(
+			model.NetworkBan.ExpiresAt.IsNull(),
+			model.NetworkBan.ExpiresAt.After(time.Now()),
+		),
+	).OrderBy(
+		model.NetworkBan.CreatedAt.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type PollRepository struct {
+	client *model.PrismaClient
+}
+
+func NewPollRepository(prismaClient *model.PrismaClient) *PollRepository {
+	return &PollRepository{client: prismaClient}
+}
+
+func (r *PollRepository) GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	return r.client.Thread.FindUnique(model.Thread.ID.Equals(threadID)).Exec(ctx)
+}
+
+// 투표는 쓰레드 ID를 그대로 키로 사용하므로 선택지까지 하나의 트랜잭션으로 생성할 수 있음
+func (r *PollRepository) CreatePoll(ctx context.Context, req *dto.CreatePollRequest) error {
+	txns := []model.PrismaTransaction{
+		r.client.Poll.CreateOne(
+			model.Poll.Question.Set(req.Question),
+			model.Poll.Thread.Link(model.Thread.ID.Equals(req.ThreadID)),
+			model.Poll.MultipleChoice.Set(req.MultipleChoice),
+			model.Poll.HideResults.Set(req.HideResults),
+			model.Poll.ClosesAt.SetIfPresent(req.ClosesAt),
+		).Tx(),
+	}
+	for position, text := range req.Options {
+		txns = append(txns, r.client.PollOption.CreateOne(
+			model.PollOption.Position.Set(position),
+			model.PollOption.Text.Set(text),
+			model.PollOption.Poll.Link(model.Poll.ThreadID.Equals(req.ThreadID)),
+		).Tx())
+	}
+	return r.client.Prisma.Transaction(txns...).Exec(ctx)
+}
+
+func (r *PollRepository) GetPoll(ctx context.Context, threadID int) (*model.PollModel, error) {
+	return r.client.Poll.FindUnique(
+		model.Poll.ThreadID.Equals(threadID),
+	).With(
+		model.Poll.Options.Fetch().OrderBy(
+			model.PollOption.Position.Order(model.SortOrderAsc),
+		),
+	).Exec(ctx)
+}
+
+func (r *PollRepository) ListChoices(ctx context.Context, threadID int, userID string) ([]model.PollChoiceModel, error) {
+	return r.client.PollChoice.FindMany(
+		model.PollChoice.ThreadID.Equals(threadID),
+		model.PollChoice.UserID.Equals(userID),
+	).Exec(ctx)
+}
+
+/*
+투표 기록(PollBallot)의 (threadID, userID) 유니크 제약으로 유저당 한 번만 투표할 수 있도록 함.
+같은 유저가 동시에 투표해도 트랜잭션 전체가 롤백되므로 득표 수가 중복으로 올라가지 않음.
+*/
+func (r *PollRepository) Vote(ctx context.Context, threadID int, userID string, optionIDs []int) error {
+	txns := []model.PrismaTransaction{
+		r.client.PollBallot.CreateOne(
+			model.PollBallot.Poll.Link(model.Poll.ThreadID.Equals(threadID)),
+			model.PollBallot.User.Link(model.Users.ID.Equals(userID)),
+		).Tx(),
+	}
+	for _, optionID := range optionIDs {
+		txns = append(txns,
+			r.client.PollChoice.CreateOne(
+				model.PollChoice.Poll.Link(model.Poll.ThreadID.Equals(threadID)),
+				model.PollChoice.Option.Link(model.PollOption.ID.Equals(optionID)),
+				model.PollChoice.User.Link(model.Users.ID.Equals(userID)),
+			).Tx(),
+			r.client.PollOption.FindUnique(
+				model.PollOption.ID.Equals(optionID),
+			).Update(
+				model.PollOption.Votes.Increment(1),
+			).Tx(),
+		)
+	}
+	txns = append(txns, r.client.Poll.FindUnique(
+		model.Poll.ThreadID.Equals(threadID),
+	).Update(
+		model.Poll.VoterCount.Increment(1),
+	).Tx())
+	return r.client.Prisma.Transaction(txns...).Exec(ctx)
+}
+
+func (r *PollRepository) ClosePoll(ctx context.Context, threadID int, userID string) (*model.PollModel, error) {
+	return r.client.Poll.FindUnique(
+		model.Poll.ThreadID.Equals(threadID),
+	).Update(
+		model.Poll.ClosedAt.Set(time.Now()),
+		model.Poll.ClosedBy.Set(userID),
+	).Exec(ctx)
+}
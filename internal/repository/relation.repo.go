@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type RelationRepository struct {
+	client *model.PrismaClient
+}
+
+func NewRelationRepository(prismaClient *model.PrismaClient) *RelationRepository {
+	return &RelationRepository{client: prismaClient}
+}
+
+func (r *RelationRepository) GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error) {
+	return r.client.Users.FindUnique(
+		model.Users.Handle.Equals(handle),
+	).Exec(ctx)
+}
+
+func (r *RelationRepository) CreateRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error {
+	_, err := r.client.UserRelation.CreateOne(
+		model.UserRelation.Type.Set(relationType),
+		model.UserRelation.User.Link(model.Users.ID.Equals(userID)),
+		model.UserRelation.Target.Link(model.Users.ID.Equals(targetID)),
+	).Exec(ctx)
+
+	// 이미 같은 관계가 있다면 그대로 성공으로 처리
+	if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+		return nil
+	}
+	return err
+}
+
+func (r *RelationRepository) DeleteRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error {
+	_, err := r.client.UserRelation.FindMany(
+		model.UserRelation.UserID.Equals(userID),
+		model.UserRelation.TargetID.Equals(targetID),
+		model.UserRelation.Type.Equals(relationType),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func (r *RelationRepository) ListRelations(ctx context.Context, userID string, relationType model.RelationType) ([]model.UserRelationModel, error) {
+	return r.client.UserRelation.FindMany(
+		model.UserRelation.UserID.Equals(userID),
+		model.UserRelation.Type.Equals(relationType),
+	).With(
+		model.UserRelation.Target.Fetch(),
+	).OrderBy(
+		model.UserRelation.CreatedAt.Order(model.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (r *RelationRepository) ListRelationsByOwner(ctx context.Context, userID string) ([]model.UserRelationModel, error) {
+	return r.client.UserRelation.FindMany(
+		model.UserRelation.UserID.Equals(userID),
+	).Exec(ctx)
+}
+
+func (r *RelationRepository) IsBlocked(ctx context.Context, ownerID, targetID string) (bool, error) {
+	_, err := r.client.UserRelation.FindFirst(
+		model.UserRelation.UserID.Equals(ownerID),
+		model.UserRelation.TargetID.Equals(targetID),
+		model.UserRelation.Type.Equals(model.RelationTypeBlock),
+	).Exec(ctx)
+	if err == model.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *RelationRepository) HasBlockBetween(ctx context.Context, userID string, otherIDs []string) (bool, error) {
+	_, err := r.client.UserRelation.FindFirst(
+		model.UserRelation.Type.Equals(model.RelationTypeBlock),
+		model.UserRelation.Or(
+			model.UserRelation.And(
+				model.UserRelation.UserID.Equals(userID),
+				model.UserRelation.TargetID.In(otherIDs),
+			),
+			model.UserRelation.And(
+				model.UserRelation.UserID.In(otherIDs),
+				model.UserRelation.TargetID.Equals(userID),
+			),
+		),
+	).Exec(ctx)
+	if err == model.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
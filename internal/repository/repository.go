@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+/*
+서비스가 Prisma 클라이언트에 직접 묶이지 않도록 서비스에서 사용하는 저장소 동작을 인터페이스로 분리함.
+Prisma 구현(*XRepository)과 테스트용 메모리 구현(repository/memory)이 같은 인터페이스를 구현하므로,
+Prisma 트랜잭션 타입(model.PrismaTransaction 등)은 인터페이스 밖으로 드러내지 않음.
+*/
+
+type AuthStore interface {
+	CreateUser(ctx context.Context, req dto.RegisterRequest) (*model.UsersModel, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.UsersModel, error)
+	GetUserPasswordByEmail(ctx context.Context, email string) (*dto.PasswordEntity, error)
+	GetUserPasswordByID(ctx context.Context, ID string) (*dto.PasswordEntity, error)
+	UpdateUserHandle(ctx context.Context, ID string, handle string) error
+	GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error)
+	UpdateUserRole(ctx context.Context, ID string, role model.UserRoles) error
+	UpdateUserPassword(ctx context.Context, ID, salt, plainPassword string) error
+	DeleteUser(ctx context.Context, ID string) (bool, error)
+	GetActiveBan(ctx context.Context, userID string) (*model.BanModel, error)
+	ListActiveIPBans(ctx context.Context) ([]model.NetworkBanModel, error)
+}
+
+type ThreadStore interface {
+	CreateThread(ctx context.Context, req *dto.CreateThreadRequest, status model.ThreadStatus) (*model.ThreadModel, error)
+	LinkThread(ctx context.Context, threadID int, req *dto.CreateThreadRequest) error
+	ListThread(ctx context.Context, offset, limit int) ([]model.ThreadModel, error)
+	ListPinnedThread(ctx context.Context) ([]model.ThreadModel, error)
+	ListAttachments(ctx context.Context, threadID int) ([]model.AttachmentModel, error)
+	ListThreadByHandle(ctx context.Context, handle string) ([]model.ThreadModel, error)
+	GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, error)
+	CommentsByID(ctx context.Context, threadID int) ([]model.ThreadModel, error)
+	GetDraftByID(ctx context.Context, threadID int) (*model.ThreadModel, error)
+	ListDrafts(ctx context.Context, userID string) ([]model.ThreadModel, error)
+	UpdateDraft(ctx context.Context, threadID int, update dto.DraftUpdateEntity) (bool, error)
+	ListDueDrafts(ctx context.Context, now time.Time, pageSize int) ([]model.ThreadModel, error)
+	PublishDraft(ctx context.Context, threadID int, fromStatus ...model.ThreadStatus) (bool, error)
+	RemoveThreadByID(ctx context.Context, userID string, threadID int) (bool, error)
+	ApplyInteractions(ctx context.Context, deltas []dto.InteractionDeltaEntity) error
+}
+
+type RelationStore interface {
+	GetUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error)
+	CreateRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error
+	DeleteRelation(ctx context.Context, userID, targetID string, relationType model.RelationType) error
+	ListRelations(ctx context.Context, userID string, relationType model.RelationType) ([]model.UserRelationModel, error)
+	ListRelationsByOwner(ctx context.Context, userID string) ([]model.UserRelationModel, error)
+	IsBlocked(ctx context.Context, ownerID, targetID string) (bool, error)
+	HasBlockBetween(ctx context.Context, userID string, otherIDs []string) (bool, error)
+}
+
+type AuditStore interface {
+	CreateAuditLog(ctx context.Context, entry dto.AuditEntry, diff []byte) (*model.AuditLogModel, error)
+	ListAuditLogs(ctx context.Context, filter dto.AuditLogFilter, cursor, pageSize int) ([]model.AuditLogModel, error)
+}
+
+// 쓰레드 작성 시 첨부할 파일을 확인하는 조회만 모아둠
+type UploadLookup interface {
+	ListUploadsByIDs(ctx context.Context, uploadIDs []string) ([]model.UploadModel, error)
+	ListAttachedUploadIDs(ctx context.Context, uploadIDs []string) ([]string, error)
+}
+
+// 목록에 작성자 정보를 붙이는 조회만 모아둠
+type UserLookup interface {
+	ListUsersByIDs(ctx context.Context, IDs []string) ([]model.UsersModel, error)
+}
+
+// 쓰레드 조회 시 북마크 여부를 붙이는 조회만 모아둠
+type BookmarkLookup interface {
+	ListBookmarkedThreadIDs(ctx context.Context, userID string, threadIDs []int) ([]int, error)
+}
+
+var (
+	_ AuthStore      = (*AuthRepository)(nil)
+	_ ThreadStore    = (*ThreadRepository)(nil)
+	_ RelationStore  = (*RelationRepository)(nil)
+	_ AuditStore     = (*AuditRepository)(nil)
+	_ UserLookup     = (*AuthRepository)(nil)
+	_ UploadLookup   = (*UploadRepository)(nil)
+	_ BookmarkLookup = (*BookmarkRepository)(nil)
+)
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/internal/model"
@@ -15,31 +17,103 @@ func NewThreadRepository(prismaClient *model.PrismaClient) *ThreadRepository {
 	return &ThreadRepository{client: prismaClient}
 }
 
-func (r *ThreadRepository) CreateThread(ctx context.Context, req *dto.CreateThreadRequest) (*model.ThreadModel, error) {
+func (r *ThreadRepository) CreateThread(ctx context.Context, req *dto.CreateThreadRequest, status model.ThreadStatus) (*model.ThreadModel, error) {
 	thread, err := r.client.Thread.CreateOne(
 		model.Thread.Title.Set(req.Title),
 		model.Thread.Content.Set(req.Content),
 		model.Thread.User.Link(model.Users.ID.Equals(req.UserID)),
 		model.Thread.ImgURL.SetIfPresent(req.ImgUrl),
+		model.Thread.Status.Set(status),
+		model.Thread.PublishAt.SetIfPresent(req.PublishAt),
 	).Exec(ctx)
 
 	return thread, err
 }
 
-func (r *ThreadRepository) ListThread(ctx context.Context, pageNumber int, pageSize int) ([]model.ThreadModel, error) {
-	if pageNumber <= 0 {
-		pageNumber = 1
-	}
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	offset := (pageNumber - 1) * pageSize
+func (r *ThreadRepository) ListThread(ctx context.Context, offset, limit int) ([]model.ThreadModel, error) {
+	// 고정된 쓰레드는 ListPinnedThread로 따로 가져오므로 제외. 페이지 계산은 고정 쓰레드 수를 아는 서비스에서 함
 	listThread, err := r.client.Thread.FindMany(
 		model.Thread.ParentThread.IsNull(),
-	).Take(pageSize).Skip(offset).Exec(ctx)
+		model.Thread.HiddenAt.IsNull(),
+		model.Thread.Status.Equals(model.ThreadStatusPublished),
+		model.Thread.Or(
+			model.Thread.PinnedUntil.IsNull(),
+			model.Thread.PinnedUntil.Before(time.Now()),
+		),
+	).With(
+		fetchAttachments(),
+		fetchAuthor(),
+	).Take(limit).Skip(offset).Exec(ctx)
 	return listThread, err
 }
 
+func (r *ThreadRepository) ListPinnedThread(ctx context.Context) ([]model.ThreadModel, error) {
+	pinnedThread, err := r.client.Thread.FindMany(
+		model.Thread.ParentThread.IsNull(),
+		model.Thread.HiddenAt.IsNull(),
+		model.Thread.Status.Equals(model.ThreadStatusPublished),
+		model.Thread.PinnedUntil.After(time.Now()),
+	).OrderBy(
+		model.Thread.PinnedUntil.Order(model.SortOrderDesc),
+	).With(
+		fetchAttachments(),
+		fetchAuthor(),
+	).Exec(ctx)
+	return pinnedThread, err
+}
+
+func (r *ThreadRepository) CreateAttachment(ctx context.Context, threadID, position int, req dto.AttachmentRequest) model.AttachmentUniqueTxResult {
+	params := []model.AttachmentSetParam{}
+	if req.AltText != "" {
+		params = append(params, model.Attachment.AltText.Set(req.AltText))
+	}
+	return r.client.Attachment.CreateOne(
+		model.Attachment.Position.Set(position),
+		model.Attachment.Thread.Link(model.Thread.ID.Equals(threadID)),
+		model.Attachment.Upload.Link(model.Upload.ID.Equals(req.UploadID)),
+		params...,
+	).Tx()
+}
+
+// 생성한 쓰레드에 연결할 쓰레드와 첨부 파일을 한 트랜잭션으로 저장함
+func (r *ThreadRepository) LinkThread(ctx context.Context, threadID int, req *dto.CreateThreadRequest) error {
+	txns := make([]model.PrismaTransaction, 0)
+	if req.ParentThread != nil {
+		txns = append(txns, r.LinkParentThread(ctx, threadID, *req.ParentThread))
+	} else if req.NextThread != nil {
+		txns = append(txns, r.LinkNextThread(ctx, threadID, *req.NextThread))
+	} else if req.PrevThread != nil {
+		txns = append(txns, r.LinkPrevThread(ctx, threadID, *req.PrevThread))
+	}
+	for position, attachment := range req.Attachments {
+		txns = append(txns, r.CreateAttachment(ctx, threadID, position, attachment))
+	}
+	return r.client.Prisma.Transaction(txns...).Exec(ctx)
+}
+
+func (r *ThreadRepository) ListAttachments(ctx context.Context, threadID int) ([]model.AttachmentModel, error) {
+	return r.client.Attachment.FindMany(
+		model.Attachment.ThreadID.Equals(threadID),
+	).OrderBy(
+		model.Attachment.Position.Order(model.SortOrderAsc),
+	).With(
+		model.Attachment.Upload.Fetch(),
+	).Exec(ctx)
+}
+
+func fetchAttachments() model.ThreadRelationWith {
+	return model.Thread.Attachments.Fetch().OrderBy(
+		model.Attachment.Position.Order(model.SortOrderAsc),
+	).With(
+		model.Attachment.Upload.Fetch(),
+	)
+}
+
+// 목록 조회에서 작성자를 쓰레드마다 따로 조회하지 않도록 같은 쿼리에서 함께 가져옴
+func fetchAuthor() model.ThreadRelationWith {
+	return model.Thread.User.Fetch()
+}
+
 func (r *ThreadRepository) ListThreadByHandle(ctx context.Context, handle string) ([]model.ThreadModel, error) {
 	user, err := r.getUserByHandle(ctx, handle)
 	if err != nil {
@@ -48,19 +122,11 @@ func (r *ThreadRepository) ListThreadByHandle(ctx context.Context, handle string
 
 	listThread, err := r.client.Thread.FindMany(
 		model.Thread.UserID.Equals(user.ID),
-	).Select(
-		model.Thread.ID.Field(),
-		model.Thread.Title.Field(),
-		model.Thread.ImgURL.Field(),
-		model.Thread.Content.Field(),
-		model.Thread.ParentThread.Field(),
-		model.Thread.NextThread.Field(),
-		model.Thread.PrevThread.Field(),
-		model.Thread.Views.Field(),
-		model.Thread.Likes.Field(),
-		model.Thread.Dislikes.Field(),
-		model.Thread.CreatedAt.Field(),
-		model.Thread.UpdatedAt.Field(),
+		model.Thread.HiddenAt.IsNull(),
+		model.Thread.Status.Equals(model.ThreadStatusPublished),
+	).With(
+		fetchAttachments(),
+		fetchAuthor(),
 	).Exec(ctx)
 
 	return listThread, err
@@ -77,11 +143,88 @@ func (r *ThreadRepository) GetThreadByID(ctx context.Context, threadID int) (*mo
 func (r *ThreadRepository) CommentsByID(ctx context.Context, threadID int) ([]model.ThreadModel, error) {
 	commentThreads, err := r.client.Thread.FindMany(
 		model.Thread.ParentThread.Equals(threadID),
+		model.Thread.HiddenAt.IsNull(),
+		model.Thread.Status.Equals(model.ThreadStatusPublished),
+	).With(
+		fetchAttachments(),
+		fetchAuthor(),
 	).Exec(ctx)
 
 	return commentThreads, err
 }
 
+func (r *ThreadRepository) GetDraftByID(ctx context.Context, threadID int) (*model.ThreadModel, error) {
+	return r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).With(
+		fetchAttachments(),
+	).Exec(ctx)
+}
+
+func (r *ThreadRepository) ListDrafts(ctx context.Context, userID string) ([]model.ThreadModel, error) {
+	return r.client.Thread.FindMany(
+		model.Thread.UserID.Equals(userID),
+		model.Thread.Status.In([]model.ThreadStatus{model.ThreadStatusDraft, model.ThreadStatusScheduled}),
+	).OrderBy(
+		model.Thread.UpdatedAt.Order(model.SortOrderDesc),
+	).With(
+		fetchAttachments(),
+	).Exec(ctx)
+}
+
+// 발행 중인 글을 덮어쓰지 않도록 아직 발행되지 않은 글일 때만 수정함
+func (r *ThreadRepository) UpdateDraft(ctx context.Context, threadID int, update dto.DraftUpdateEntity) (bool, error) {
+	params := []model.ThreadSetParam{
+		model.Thread.Title.SetIfPresent(update.Title),
+		model.Thread.Content.SetIfPresent(update.Content),
+	}
+	if update.Status != nil {
+		params = append(params,
+			model.Thread.Status.Set(*update.Status),
+			model.Thread.PublishAt.SetOptional(update.PublishAt),
+		)
+	}
+
+	result, err := r.client.Thread.FindMany(
+		model.Thread.ID.Equals(threadID),
+		model.Thread.Status.In([]model.ThreadStatus{model.ThreadStatusDraft, model.ThreadStatusScheduled}),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+func (r *ThreadRepository) ListDueDrafts(ctx context.Context, now time.Time, pageSize int) ([]model.ThreadModel, error) {
+	return r.client.Thread.FindMany(
+		model.Thread.Status.Equals(model.ThreadStatusScheduled),
+		model.Thread.PublishAt.Lte(now),
+	).OrderBy(
+		model.Thread.PublishAt.Order(model.SortOrderAsc),
+	).Take(pageSize).Exec(ctx)
+}
+
+/*
+여러 인스턴스의 스케줄러가 같은 글을 동시에 발행하려 해도 상태 조건이 걸린 UPDATE는 하나만 성공하므로
+변경된 행이 있을 때만 발행에 성공한 것으로 봄.
+목록이 작성 순서로 보이도록 작성 시각도 발행 시각으로 바꿈.
+*/
+func (r *ThreadRepository) PublishDraft(ctx context.Context, threadID int, fromStatus ...model.ThreadStatus) (bool, error) {
+	now := time.Now()
+	result, err := r.client.Thread.FindMany(
+		model.Thread.ID.Equals(threadID),
+		model.Thread.Status.In(fromStatus),
+	).Update(
+		model.Thread.Status.Set(model.ThreadStatusPublished),
+		model.Thread.PublishAt.SetOptional(nil),
+		model.Thread.CreatedAt.Set(now),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
 func (r *ThreadRepository) RemoveThreadByID(ctx context.Context, userID string, threadID int) (bool, error) {
 	_, err := r.client.Thread.FindUnique(
 		model.Thread.ID.Equals(threadID),
@@ -101,6 +244,14 @@ func (r *ThreadRepository) IncrementViews(ctx context.Context, threadID int, amo
 	).Tx()
 }
 
+func (r *ThreadRepository) IncrementRawViews(ctx context.Context, threadID int, amount int) model.ThreadUniqueTxResult {
+	return r.client.Thread.FindUnique(
+		model.Thread.ID.Equals(threadID),
+	).Update(
+		model.Thread.RawViews.Increment(amount),
+	).Tx()
+}
+
 func (r *ThreadRepository) IncrementLikes(ctx context.Context, threadID int, amount int) model.ThreadUniqueTxResult {
 	return r.client.Thread.FindUnique(
 		model.Thread.ID.Equals(threadID),
@@ -133,11 +284,24 @@ func (r *ThreadRepository) DecrementDislikes(ctx context.Context, threadID int,
 	).Tx()
 }
 
-func (r *ThreadRepository) RunTransaction(ctx context.Context, txns []model.PrismaTransaction) error {
-	if err := r.client.Prisma.Transaction(txns...).Exec(ctx); err != nil {
-		return err
+// 모아둔 인터렉션 값을 한 트랜잭션으로 DB에 반영함
+func (r *ThreadRepository) ApplyInteractions(ctx context.Context, deltas []dto.InteractionDeltaEntity) error {
+	incrementFuncs := map[string]func(context.Context, int, int) model.ThreadUniqueTxResult{
+		"views":    r.IncrementViews,
+		"rawViews": r.IncrementRawViews,
+		"likes":    r.IncrementLikes,
+		"dislikes": r.IncrementDislikes,
 	}
-	return nil
+
+	txns := make([]model.PrismaTransaction, 0, len(deltas))
+	for _, delta := range deltas {
+		incrementFunc, exists := incrementFuncs[delta.Field]
+		if !exists {
+			return fmt.Errorf("unknown interaction field: %s", delta.Field)
+		}
+		txns = append(txns, incrementFunc(ctx, delta.ThreadID, delta.Amount))
+	}
+	return r.client.Prisma.Transaction(txns...).Exec(ctx)
 }
 
 func (r *ThreadRepository) LinkParentThread(ctx context.Context, threadID, parentID int) model.ThreadUniqueTxResult {
@@ -170,10 +334,6 @@ func (r *ThreadRepository) LinkPrevThread(ctx context.Context, threadID, prevID
 	).Tx()
 }
 
-func (r *ThreadRepository) GetUserByID(ctx context.Context, id string) (*model.UsersModel, error) {
-	return r.client.Users.FindUnique(model.Users.ID.Equals(id)).Exec(ctx)
-}
-
 func (r *ThreadRepository) getUserByHandle(ctx context.Context, handle string) (*model.UsersModel, error) {
 	user, err := r.client.Users.FindUnique(
 		model.Users.Handle.Equals(handle),
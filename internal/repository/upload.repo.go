@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+type UploadRepository struct {
+	client *model.PrismaClient
+}
+
+func NewUploadRepository(prismaClient *model.PrismaClient) *UploadRepository {
+	return &UploadRepository{client: prismaClient}
+}
+
+func (r *UploadRepository) CreateUpload(ctx context.Context, entity dto.UploadEntity) (*model.UploadModel, error) {
+	return r.client.Upload.CreateOne(
+		model.Upload.Filename.Set(entity.Filename),
+		model.Upload.ContentType.Set(entity.ContentType),
+		model.Upload.Size.Set(entity.Size),
+		model.Upload.BlobKey.Set(entity.BlobKey),
+		model.Upload.User.Link(model.Users.ID.Equals(entity.UserID)),
+		model.Upload.ID.Set(entity.ID),
+		model.Upload.Width.SetIfPresent(entity.Width),
+		model.Upload.Height.SetIfPresent(entity.Height),
+		model.Upload.ThumbnailKey.SetIfPresent(entity.ThumbnailKey),
+		model.Upload.ThumbnailContentType.SetIfPresent(entity.ThumbnailContentType),
+	).Exec(ctx)
+}
+
+func (r *UploadRepository) GetUploadByID(ctx context.Context, uploadID string) (*model.UploadModel, error) {
+	return r.client.Upload.FindUnique(
+		model.Upload.ID.Equals(uploadID),
+	).Exec(ctx)
+}
+
+func (r *UploadRepository) ListUploadsByIDs(ctx context.Context, uploadIDs []string) ([]model.UploadModel, error) {
+	return r.client.Upload.FindMany(
+		model.Upload.ID.In(uploadIDs),
+	).Exec(ctx)
+}
+
+// 이미 다른 쓰레드에 첨부된 업로드 ID만 골라서 반환
+func (r *UploadRepository) ListAttachedUploadIDs(ctx context.Context, uploadIDs []string) ([]string, error) {
+	attachments, err := r.client.Attachment.FindMany(
+		model.Attachment.UploadID.In(uploadIDs),
+	).Select(
+		model.Attachment.UploadID.Field(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachedIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachedIDs = append(attachedIDs, attachment.UploadID)
+	}
+	return attachedIDs, nil
+}
+
+func (r *UploadRepository) GetStorageUsage(ctx context.Context, userID string) (int64, error) {
+	uploads, err := r.client.Upload.FindMany(
+		model.Upload.UserID.Equals(userID),
+	).Select(
+		model.Upload.Size.Field(),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var usage int64
+	for _, upload := range uploads {
+		usage += int64(upload.Size)
+	}
+	return usage, nil
+}
+
+// 첨부된 업로드도 함께 조회되므로 ID 커서로 끝까지 훑으면서 호출하는 쪽에서 첨부 여부를 확인함
+func (r *UploadRepository) ListStaleUploads(ctx context.Context, createdBefore time.Time, cursor string, pageSize int) ([]model.UploadModel, error) {
+	params := []model.UploadWhereParam{
+		model.Upload.CreatedAt.Before(createdBefore),
+	}
+	if cursor != "" {
+		params = append(params, model.Upload.ID.Gt(cursor))
+	}
+	return r.client.Upload.FindMany(params...).OrderBy(
+		model.Upload.ID.Order(model.SortOrderAsc),
+	).Take(pageSize).Exec(ctx)
+}
+
+func (r *UploadRepository) DeleteUpload(ctx context.Context, uploadID string) error {
+	_, err := r.client.Upload.FindUnique(
+		model.Upload.ID.Equals(uploadID),
+	).Delete().Exec(ctx)
+	return err
+}
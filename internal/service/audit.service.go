@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+const (
+	auditQueueSize     = 1024
+	auditWriteTimeout  = 5 * time.Second
+	auditExportBatch   = 500
+	auditUserAgentSize = 512
+)
+
+type AuditService struct {
+	auditRepo repository.AuditStore
+	queue     chan dto.AuditEntry
+}
+
+func NewAuditService(repo repository.AuditStore) *AuditService {
+	return &AuditService{
+		auditRepo: repo,
+		queue:     make(chan dto.AuditEntry, auditQueueSize),
+	}
+}
+
+/*
+감사 로그 저장이 요청 처리 시간을 늘리지 않도록 큐에 넣고 바로 반환함.
+큐가 가득 찬 경우에도 요청을 막지 않고 로그만 남긴 뒤 버림.
+요청 IP, User-Agent는 컨트롤러가 RequestMeta로 넘겨준 값을 사용함.
+*/
+func (s *AuditService) Record(meta dto.RequestMeta, entry dto.AuditEntry) {
+	entry.IP = meta.IP
+	entry.UserAgent = truncateRunes(meta.UserAgent, auditUserAgentSize)
+
+	select {
+	case s.queue <- entry:
+	default:
+		log.Printf("audit: queue is full, dropped %s event", entry.Event)
+	}
+}
+
+// 종료할 때는 큐에 남은 기록을 모두 저장한 뒤 반환함
+func (s *AuditService) Run(ctx context.Context) {
+	for {
+		select {
+		case entry := <-s.queue:
+			s.write(entry)
+		case <-ctx.Done():
+			for {
+				select {
+				case entry := <-s.queue:
+					s.write(entry)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *AuditService) write(entry dto.AuditEntry) {
+	var diff []byte
+	if entry.Diff != nil {
+		marshaled, err := json.Marshal(entry.Diff)
+		if err != nil {
+			log.Printf("audit: failed to marshal diff of %s event: %v", entry.Event, err)
+		}
+		diff = marshaled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+	if _, err := s.auditRepo.CreateAuditLog(ctx, entry, diff); err != nil {
+		log.Printf("audit: failed to write %s event: %v", entry.Event, err)
+	}
+}
+
+func (s *AuditService) AuditFilter(req *dto.ListAuditLogRequest) (dto.AuditLogFilter, *exception.ErrResponseCtx) {
+	filter := dto.AuditLogFilter{
+		ActorID:  req.ActorID,
+		TargetID: req.TargetID,
+	}
+	if req.Event != "" {
+		event := model.AuditEvent(req.Event)
+		filter.Event = &event
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return filter, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 감사 로그 조회 실패. 시작 시각 형식이 올바르지 않습니다.", err)
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return filter, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 감사 로그 조회 실패. 종료 시각 형식이 올바르지 않습니다.", err)
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 감사 로그 조회 실패. 시작 시각은 종료 시각보다 앞서야 합니다.", exception.ErrInvalidParameter)
+	}
+	return filter, nil
+}
+
+func (s *AuditService) ListAuditLogs(ctx context.Context, filter dto.AuditLogFilter, cursor, pageSize int) ([]dto.AuditLogResponse, *int, *exception.ErrResponseCtx) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	logs, err := s.auditRepo.ListAuditLogs(ctx, filter, cursor, pageSize)
+	if err != nil {
+		return nil, nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 감사 로그 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	listAuditLog := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, auditLog := range logs {
+		listAuditLog = append(listAuditLog, auditLogResponse(auditLog))
+	}
+
+	var nextCursor *int
+	if len(logs) == pageSize {
+		lastID := logs[len(logs)-1].ID
+		nextCursor = &lastID
+	}
+	return listAuditLog, nextCursor, nil
+}
+
+// 응답을 스트리밍하는 중에 호출되므로 상태 코드를 바꿀 수 없어 error만 반환함
+func (s *AuditService) ExportAuditLogs(ctx context.Context, filter dto.AuditLogFilter, format string, w io.Writer) error {
+	var (
+		csvWriter   *csv.Writer
+		jsonEncoder *json.Encoder
+	)
+	switch format {
+	case "csv":
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write([]string{"id", "event", "actorID", "targetType", "targetID", "ip", "userAgent", "diff", "createdAt"}); err != nil {
+			return err
+		}
+	default:
+		jsonEncoder = json.NewEncoder(w)
+	}
+
+	cursor := 0
+	for {
+		logs, err := s.auditRepo.ListAuditLogs(ctx, filter, cursor, auditExportBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, auditLog := range logs {
+			response := auditLogResponse(auditLog)
+			if csvWriter != nil {
+				err = csvWriter.Write([]string{
+					strconv.Itoa(response.ID),
+					csvCell(response.Event),
+					csvCell(response.ActorID),
+					csvCell(response.TargetType),
+					csvCell(response.TargetID),
+					csvCell(response.IP),
+					csvCell(response.UserAgent),
+					csvCell(string(response.Diff)),
+					response.CreatedAt.Format(time.RFC3339),
+				})
+			} else {
+				err = jsonEncoder.Encode(response)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		if len(logs) < auditExportBatch {
+			return nil
+		}
+		cursor = logs[len(logs)-1].ID
+	}
+}
+
+func auditLogResponse(auditLog model.AuditLogModel) dto.AuditLogResponse {
+	actorID, _ := auditLog.ActorID()
+	targetType, _ := auditLog.TargetType()
+	targetID, _ := auditLog.TargetID()
+	ip, _ := auditLog.IP()
+	userAgent, _ := auditLog.UserAgent()
+	response := dto.AuditLogResponse{
+		ID:         auditLog.ID,
+		Event:      string(auditLog.Event),
+		ActorID:    actorID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         ip,
+		UserAgent:  userAgent,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+	if diff, ok := auditLog.Diff(); ok {
+		response.Diff = json.RawMessage(diff)
+	}
+	return response
+}
+
+// User-Agent처럼 사용자가 정한 값이 스프레드시트에서 수식으로 실행되지 않도록 수식으로 읽히는 첫 글자 앞에 '를 붙임
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// userAgent 컬럼(VarChar(512))은 글자 수 기준이고, 바이트 단위로 자르면 멀티바이트 문자가 깨지므로 글자 단위로 자름
+func truncateRunes(value string, maxLength int) string {
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+	return string([]rune(value)[:maxLength])
+}
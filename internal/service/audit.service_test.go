@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+)
+
+func TestRecordTruncatesUserAgent(t *testing.T) {
+	env := newTestEnv(t)
+
+	// 한글은 글자당 3바이트이므로 바이트 단위로 자르면 마지막 글자가 깨짐
+	userAgent := "a" + strings.Repeat("가", auditUserAgentSize)
+	env.audit.Record(dto.RequestMeta{IP: "203.0.113.10", UserAgent: userAgent}, dto.AuditEntry{Event: model.AuditEventLoginFailure})
+
+	logs := env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventLoginFailure)})
+	if len(logs) != 1 {
+		t.Fatalf("audit logs = %d, want 1", len(logs))
+	}
+	stored, _ := logs[0].UserAgent()
+	if !utf8.ValidString(stored) || utf8.RuneCountInString(stored) != auditUserAgentSize {
+		t.Errorf("stored user agent has %d runes (valid UTF-8: %t), want %d", utf8.RuneCountInString(stored), utf8.ValidString(stored), auditUserAgentSize)
+	}
+	if ip, _ := logs[0].IP(); ip != "203.0.113.10" {
+		t.Errorf("stored ip = %q, want 203.0.113.10", ip)
+	}
+}
+
+func TestExportAuditLogsEscapesFormulas(t *testing.T) {
+	env := newTestEnv(t)
+
+	formula := `=HYPERLINK("https://evil.example","click")`
+	env.audit.Record(dto.RequestMeta{IP: "203.0.113.10", UserAgent: formula}, dto.AuditEntry{Event: model.AuditEventLoginFailure, TargetID: "@admin"})
+	env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventLoginFailure)})
+
+	var buf bytes.Buffer
+	if err := env.audit.ExportAuditLogs(context.Background(), dto.AuditLogFilter{}, "csv", &buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("csv rows = %d, want header and 1 row", len(records))
+	}
+	// 헤더 순서: id, event, actorID, targetType, targetID, ip, userAgent, diff, createdAt
+	row := records[1]
+	if row[6] != "'"+formula {
+		t.Errorf("userAgent cell = %q, want it prefixed with '", row[6])
+	}
+	if row[4] != "'@admin" {
+		t.Errorf("targetID cell = %q, want it prefixed with '", row[4])
+	}
+	if row[5] != "203.0.113.10" {
+		t.Errorf("ip cell = %q, want it unchanged", row[5])
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	for value, want := range map[string]string{
+		"":             "",
+		"Mozilla/5.0":  "Mozilla/5.0",
+		"=1+1":         "'=1+1",
+		"+1":           "'+1",
+		"-1":           "'-1",
+		"@SUM(A1)":     "'@SUM(A1)",
+		"\tcmd":        "'\tcmd",
+		"\rcmd":        "'\rcmd",
+		"a=1":          "a=1",
+		`{"role":"x"}`: `{"role":"x"}`,
+	} {
+		if got := csvCell(value); got != want {
+			t.Errorf("csvCell(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
@@ -10,30 +12,45 @@ import (
 	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/internal/model"
 	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/cache"
 	"github.com/kitae0522/gommunity/pkg/crypt"
 	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
 )
 
 type AuthService struct {
-	authRepo   *repository.AuthRepository
-	redisCache *redis.Client
+	authRepo     repository.AuthStore
+	auditService *AuditService
+	cacheStore   cache.Cache
+	redisCache   *redis.Client
 }
 
-func NewAuthService(repo *repository.AuthRepository, rdconn *redis.Client) *AuthService {
+// 세션 차단 정보는 미들웨어가 Redis에서 직접 읽으므로 redisCache에 쓰고, 나머지 캐시는 cacheStore를 사용함
+func NewAuthService(repo repository.AuthStore, auditService *AuditService, cacheStore cache.Cache, rdconn *redis.Client) *AuthService {
 	return &AuthService{
-		authRepo:   repo,
-		redisCache: rdconn,
+		authRepo:     repo,
+		auditService: auditService,
+		cacheStore:   cacheStore,
+		redisCache:   rdconn,
 	}
 }
 
-func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest) *exception.ErrResponseCtx {
+func (s *AuthService) Register(ctx context.Context, meta dto.RequestMeta, req dto.RegisterRequest) *exception.ErrResponseCtx {
 	if err := s.comparePassword(req.Password, req.PasswordConfirm); err != nil {
 		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 회원가입 실패. 패스워드가 일치하지 않습니다.", err)
 	}
 
+	banned, err := s.isIPBanned(ctx, meta.IP)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 회원가입 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
+	if banned {
+		return exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 회원가입 실패. 차단된 네트워크에서는 가입할 수 없습니다.", exception.ErrIPBanned)
+	}
+
 	if _, err := s.authRepo.CreateUser(ctx, req); err != nil {
 		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
-			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 회원가입 실패. 중복된 유저가 존재합니다.", err)
+			return s.duplicatedUser(ctx, req.Handle)
 		}
 		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 회원가입 실패. Repository에서 문제 발생", err)
 	}
@@ -41,19 +58,27 @@ func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest) *ex
 	return nil
 }
 
-func (s *AuthService) Login(ctx context.Context, email, password string) (string, *exception.ErrResponseCtx) {
+func (s *AuthService) Login(ctx context.Context, meta dto.RequestMeta, email, password string) (string, *exception.ErrResponseCtx) {
 	passwordInfo, err := s.authRepo.GetUserPasswordByEmail(ctx, email)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return "", exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 로그인 실패. 존재하지 않는 사용자입니다.", err)
+			// 로그인 실패 기록에 이메일 원문을 남기지 않고, 같은 이메일로 반복된 시도만 구분할 수 있도록 해시값을 남김
+			s.recordLoginFailure(meta, "", map[string]interface{}{"emailHash": crypt.NewSHA256(strings.ToLower(email), config.Envs.JWTSecret), "reason": "user not found"})
+			return "", exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 로그인 실패. 이메일 또는 패스워드가 일치하지 않습니다.", exception.ErrInvalidCredentials)
 		default:
 			return "", exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 로그인 실패. Repository에서 문제 발생", err)
 		}
 	}
 
 	if !crypt.VerifyPassword(passwordInfo.HashPassword, password, passwordInfo.Salt) {
-		return "", exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 로그인 실패. 패스워드가 일치하지 않습니다.", err)
+		s.recordLoginFailure(meta, passwordInfo.ID, map[string]interface{}{"reason": "wrong password"})
+		return "", exception.GenerateErrorCtx(fiber.StatusUnauthorized, "❌ 로그인 실패. 이메일 또는 패스워드가 일치하지 않습니다.", exception.ErrInvalidCredentials)
+	}
+
+	if errCtx := s.checkBan(ctx, passwordInfo.ID); errCtx != nil {
+		s.recordLoginFailure(meta, passwordInfo.ID, map[string]interface{}{"reason": "banned"})
+		return "", errCtx
 	}
 
 	token, err := crypt.NewToken(string(passwordInfo.Role), passwordInfo.ID, []byte(config.Envs.JWTSecret))
@@ -61,47 +86,73 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (string
 		return "", exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 로그인 실패. 토큰 생성 중 문제가 발생했습니다.", err)
 	}
 
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventLoginSuccess,
+		ActorID:    &passwordInfo.ID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   passwordInfo.ID,
+	})
 	return token, nil
 }
 
-func (s *AuthService) HandleReset(ctx context.Context, req dto.HandleResetEntity) error {
+func (s *AuthService) HandleReset(ctx context.Context, meta dto.RequestMeta, req dto.HandleResetEntity) error {
+	user, err := s.authRepo.GetUserPasswordByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.authRepo.UpdateUserHandle(ctx, req.ID, req.Handle); err != nil {
 		return err
 	}
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventHandleChange,
+		ActorID:    &req.ID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   req.ID,
+		Diff:       map[string]interface{}{"handle": dto.AuditChange{From: user.Handle, To: req.Handle}},
+	})
 	return nil
 }
 
-func (s *AuthService) PasswordReset(ctx context.Context, req dto.PasswordResetEntity) *exception.ErrResponseCtx {
+func (s *AuthService) PasswordReset(ctx context.Context, meta dto.RequestMeta, req dto.PasswordResetEntity) *exception.ErrResponseCtx {
 	if err := s.comparePassword(req.PasswordPayload.NewPassword, req.PasswordPayload.NewPasswordConfirm); err != nil {
-		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 비밀번호 초기화 실패. 패스워드가 일치하지 않습니다.", err)
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 비밀번호 초기화 실패. 패스워드가 일치하지 않습니다.", err)
 	}
 
 	passwordInfo, err := s.authRepo.GetUserPasswordByID(ctx, req.ID)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 비밀번호 초기화 실패. 존재하지 않는 사용자입니다.", err)
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 비밀번호 초기화 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
 		default:
 			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 비밀번호 초기화 실패. Repository에서 문제 발생", err)
 		}
 	}
 
 	if !crypt.VerifyPassword(passwordInfo.HashPassword, req.PasswordPayload.OldPassword, passwordInfo.Salt) {
-		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 비밀번호 초기화 실패. 패스워드가 일치하지 않습니다.", err)
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 비밀번호 초기화 실패. 기존 패스워드가 일치하지 않습니다.", exception.ErrWrongPassword)
 	}
 
 	if err := s.authRepo.UpdateUserPassword(ctx, passwordInfo.ID, passwordInfo.Salt, req.PasswordPayload.NewPassword); err != nil {
 		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 비밀번호 초기화 실패. Repository에서 문제 발생", err)
 	}
 
+	// 비밀번호는 해시값이라도 기록하지 않음
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventPasswordChange,
+		ActorID:    &passwordInfo.ID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   passwordInfo.ID,
+	})
 	return nil
 }
 
-func (s *AuthService) Withdraw(ctx context.Context, ID string) *exception.ErrResponseCtx {
+func (s *AuthService) Withdraw(ctx context.Context, meta dto.RequestMeta, ID string) *exception.ErrResponseCtx {
 	if ok, err := s.authRepo.DeleteUser(ctx, ID); err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 유저 탈퇴 실패. 존재하지 않는 사용자입니다.", err)
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 유저 탈퇴 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
 		default:
 			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 탈퇴 실패. Repository에서 문제 발생", err)
 		}
@@ -109,9 +160,118 @@ func (s *AuthService) Withdraw(ctx context.Context, ID string) *exception.ErrRes
 		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 탈퇴 실패. 유저를 삭제할 수 없습니다.", err)
 	}
 
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventWithdraw,
+		ActorID:    &ID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   ID,
+	})
+	return nil
+}
+
+func (s *AuthService) ChangeRole(ctx context.Context, meta dto.RequestMeta, req *dto.ChangeRoleRequest) *exception.ErrResponseCtx {
+	user, err := s.authRepo.GetUserByHandle(ctx, req.Handle)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 권한 변경 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+		default:
+			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 권한 변경 실패. Repository에서 문제 발생", err)
+		}
+	}
+	if user.ID == req.AdminID {
+		return exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 권한 변경 실패. 자기 자신의 권한은 변경할 수 없습니다.", exception.ErrForbiddenRole)
+	}
+
+	role := model.UserRoles(req.Role)
+	if user.Role == role {
+		return nil
+	}
+	if err := s.authRepo.UpdateUserRole(ctx, user.ID, role); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 권한 변경 실패. Repository에서 문제 발생", err)
+	}
+	// 기존 토큰에는 이전 권한이 남아 있으므로 다시 로그인하도록 만료시킴
+	if err := revokeTokens(ctx, s.redisCache, user.ID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 권한 변경 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventRoleChange,
+		ActorID:    &req.AdminID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   user.ID,
+		Diff:       map[string]interface{}{"role": dto.AuditChange{From: user.Role, To: role}},
+	})
 	return nil
 }
 
+func (s *AuthService) recordLoginFailure(meta dto.RequestMeta, userID string, detail map[string]interface{}) {
+	entry := dto.AuditEntry{
+		Event: model.AuditEventLoginFailure,
+		Diff:  detail,
+	}
+	if userID != "" {
+		entry.TargetType = dto.AuditTargetUser
+		entry.TargetID = userID
+	}
+	s.auditService.Record(meta, entry)
+}
+
+func (s *AuthService) checkBan(ctx context.Context, userID string) *exception.ErrResponseCtx {
+	ban, err := s.authRepo.GetActiveBan(ctx, userID)
+	if err == model.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 로그인 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	banEntity := dto.BanEntity{Reason: ban.Reason}
+	if expiresAt, ok := ban.ExpiresAt(); ok {
+		banEntity.ExpiresAt = &expiresAt
+	}
+	// 캐시가 유실된 경우를 대비해 로그인 시점에 다시 동기화
+	if err := applyBanToSession(ctx, s.redisCache, userID, banEntity); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 로그인 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	return exception.GenerateErrorCtx(fiber.StatusForbidden, utils.BanMessage(banEntity.Reason, banEntity.ExpiresAt), exception.ErrUserBanned).WithDetail(banEntity)
+}
+
+// unique 제약 위반만으로는 어느 값이 겹쳤는지 알 수 없으므로 핸들을 다시 조회해서 구분함
+func (s *AuthService) duplicatedUser(ctx context.Context, handle string) *exception.ErrResponseCtx {
+	if _, err := s.authRepo.GetUserByHandle(ctx, handle); err == nil {
+		return exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 회원가입 실패. 이미 사용 중인 핸들입니다.", exception.ErrHandleTaken)
+	}
+	return exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 회원가입 실패. 이미 가입된 이메일입니다.", exception.ErrEmailTaken)
+}
+
+func (s *AuthService) isIPBanned(ctx context.Context, ip string) (bool, error) {
+	var ipBans []dto.IPBanResponse
+	if err := cache.GetJSON(ctx, s.cacheStore, utils.IPBanListCacheKey(), &ipBans); err != nil {
+		return false, err
+	}
+
+	if ipBans == nil {
+		bans, err := s.authRepo.ListActiveIPBans(ctx)
+		if err != nil {
+			return false, err
+		}
+		ipBans = ipBanResponses(bans)
+		if err := cache.SetJSON(ctx, s.cacheStore, utils.IPBanListCacheKey(), ipBans, 5*time.Minute); err != nil {
+			return false, err
+		}
+	}
+
+	for _, ban := range ipBans {
+		if ban.ExpiresAt != nil && ban.ExpiresAt.Before(time.Now()) {
+			continue
+		}
+		if utils.ContainsIP(ban.CIDR, ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *AuthService) comparePassword(password, confirmPassword string) error {
 	if password != confirmPassword {
 		return exception.ErrIncorrectConfirmPassword
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/crypt"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+func TestRegister(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.registerUser(t, "alice")
+
+	if user.Role != model.UserRolesUser {
+		t.Errorf("role = %s, want %s", user.Role, model.UserRolesUser)
+	}
+	if user.HashPassword == testPassword || !crypt.VerifyPassword(user.HashPassword, testPassword, user.Salt) {
+		t.Errorf("password is not stored as a salted hash")
+	}
+}
+
+func TestRegisterRejectsDuplicateUser(t *testing.T) {
+	env := newTestEnv(t)
+	env.registerUser(t, "alice")
+
+	errCtx := env.authService().Register(context.Background(), testMeta, dto.RegisterRequest{
+		Handle:          "alice",
+		Name:            "other",
+		Password:        testPassword,
+		PasswordConfirm: testPassword,
+		Email:           "other@example.com",
+	})
+	if errCtx == nil || errCtx.Code != exception.CodeHandleTaken || errCtx.StatusCode != fiber.StatusConflict {
+		t.Fatalf("errCtx = %+v, want 409 %s", errCtx, exception.CodeHandleTaken)
+	}
+	if _, err := env.store.GetUserByEmail(context.Background(), "other@example.com"); err != model.ErrNotFound {
+		t.Errorf("duplicate user was created: %v", err)
+	}
+}
+
+func TestRegisterRejectsPasswordMismatch(t *testing.T) {
+	env := newTestEnv(t)
+
+	errCtx := env.authService().Register(context.Background(), testMeta, dto.RegisterRequest{
+		Handle:          "alice",
+		Name:            "alice",
+		Password:        testPassword,
+		PasswordConfirm: "different",
+		Email:           "alice@example.com",
+	})
+	if errCtx == nil || errCtx.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("errCtx = %+v, want 400", errCtx)
+	}
+	if _, err := env.store.GetUserByHandle(context.Background(), "alice"); err != model.ErrNotFound {
+		t.Errorf("user was created despite password mismatch: %v", err)
+	}
+}
+
+func TestRegisterRejectsBannedNetwork(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	if _, err := env.store.CreateIPBan(ctx, "198.51.100.0/24", "spam", "admin", nil); err != nil {
+		t.Fatalf("create ip ban: %v", err)
+	}
+
+	errCtx := env.authService().Register(ctx, dto.RequestMeta{IP: "198.51.100.7"}, dto.RegisterRequest{
+		Handle:          "alice",
+		Name:            "alice",
+		Password:        testPassword,
+		PasswordConfirm: testPassword,
+		Email:           "alice@example.com",
+	})
+	if errCtx == nil || errCtx.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("errCtx = %+v, want 403", errCtx)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.registerUser(t, "alice")
+
+	token, errCtx := env.authService().Login(context.Background(), testMeta, "alice@example.com", testPassword)
+	if errCtx != nil {
+		t.Fatalf("login: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if token == "" {
+		t.Fatal("expected a token")
+	}
+
+	logs := env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventLoginSuccess), TargetID: user.ID})
+	if len(logs) != 1 {
+		t.Errorf("login success audit logs = %d, want 1", len(logs))
+	}
+}
+
+func TestLoginFailures(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.registerUser(t, "alice")
+	ctx := context.Background()
+
+	// 가입 여부가 드러나지 않도록 두 경우 모두 같은 에러로 응답함
+	for _, email := range []string{"alice@example.com", "nobody@example.com"} {
+		_, errCtx := env.authService().Login(ctx, testMeta, email, "wrong")
+		if errCtx == nil || !errors.Is(errCtx, exception.ErrInvalidCredentials) || errCtx.StatusCode != fiber.StatusUnauthorized {
+			t.Errorf("login %s: errCtx = %+v, want 401 %s", email, errCtx, exception.CodeInvalidCredentials)
+		}
+	}
+
+	logs := env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventLoginFailure), TargetID: user.ID})
+	if len(logs) != 1 {
+		t.Errorf("login failure audit logs for user = %d, want 1", len(logs))
+	}
+
+	// 가입하지 않은 이메일도 원문은 남기지 않고, 요청 정보는 컨트롤러가 넘겨준 값을 그대로 기록함
+	logs = env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventLoginFailure)})
+	for _, auditLog := range logs {
+		if diff, _ := auditLog.Diff(); strings.Contains(string(diff), "@example.com") {
+			t.Errorf("login failure diff contains a raw email: %s", diff)
+		}
+		if ip, _ := auditLog.IP(); ip != testMeta.IP {
+			t.Errorf("login failure ip = %q, want %q", ip, testMeta.IP)
+		}
+	}
+}
+
+func TestLoginRejectsBannedUser(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.registerUser(t, "alice")
+	ctx := context.Background()
+	if _, err := env.store.CreateBan(ctx, user.ID, "abuse", "admin", nil); err != nil {
+		t.Fatalf("create ban: %v", err)
+	}
+
+	_, errCtx := env.authService().Login(ctx, testMeta, "alice@example.com", testPassword)
+	if errCtx == nil || errCtx.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("errCtx = %+v, want 403", errCtx)
+	}
+	// 로그인 시점에 세션 차단 정보가 Redis에 다시 동기화되어야 함
+	if !env.redis.Exists(utils.BanCacheKey(user.ID)) {
+		t.Error("ban was not synced to the session cache")
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.registerUser(t, "alice")
+	ctx := context.Background()
+	service := env.authService()
+
+	errCtx := service.PasswordReset(ctx, testMeta, dto.PasswordResetEntity{
+		ID: user.ID,
+		PasswordPayload: &dto.PasswordResetRequest{
+			OldPassword:        "wrong",
+			NewPassword:        "newpassword",
+			NewPasswordConfirm: "newpassword",
+		},
+	})
+	if errCtx == nil {
+		t.Fatal("expected reset with wrong old password to fail")
+	}
+
+	errCtx = service.PasswordReset(ctx, testMeta, dto.PasswordResetEntity{
+		ID: user.ID,
+		PasswordPayload: &dto.PasswordResetRequest{
+			OldPassword:        testPassword,
+			NewPassword:        "newpassword",
+			NewPasswordConfirm: "newpassword",
+		},
+	})
+	if errCtx != nil {
+		t.Fatalf("reset: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	if _, errCtx := service.Login(ctx, testMeta, "alice@example.com", testPassword); errCtx == nil {
+		t.Error("old password still works after reset")
+	}
+	if _, errCtx := service.Login(ctx, testMeta, "alice@example.com", "newpassword"); errCtx != nil {
+		t.Errorf("login with new password: %s %v", errCtx.Message, errCtx.Detail)
+	}
+}
+
+func TestHandleReset(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	env.registerUser(t, "bob")
+	ctx := context.Background()
+	service := env.authService()
+
+	err := service.HandleReset(ctx, testMeta, dto.HandleResetEntity{ID: alice.ID, Handle: "bob"})
+	if _, uniqueErr := model.IsErrUniqueConstraint(err); !uniqueErr {
+		t.Errorf("taking another user's handle: err = %v, want unique constraint error", err)
+	}
+
+	if err := service.HandleReset(ctx, testMeta, dto.HandleResetEntity{ID: alice.ID, Handle: "alice2"}); err != nil {
+		t.Fatalf("handle reset: %v", err)
+	}
+	if user, err := env.store.GetUserByHandle(ctx, "alice2"); err != nil || user.ID != alice.ID {
+		t.Errorf("renamed user = %+v, %v", user, err)
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	ctx := context.Background()
+
+	threads := env.threadService()
+	thread, errCtx := threads.CreateThread(ctx, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "first"})
+	if errCtx != nil {
+		t.Fatalf("create thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	reply, errCtx := threads.CreateThread(ctx, &dto.CreateThreadRequest{UserID: bob.ID, Title: "re", Content: "reply", ParentThread: &thread.ID})
+	if errCtx != nil {
+		t.Fatalf("create reply: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	if errCtx := env.authService().Withdraw(ctx, testMeta, alice.ID); errCtx != nil {
+		t.Fatalf("withdraw: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	if _, err := env.store.GetUserByID(ctx, alice.ID); err != model.ErrNotFound {
+		t.Errorf("user still exists: %v", err)
+	}
+	// 작성한 쓰레드와 그 답글까지 함께 지워져야 함
+	if _, err := env.store.GetThreadByID(ctx, thread.ID); err != model.ErrNotFound {
+		t.Errorf("thread still exists: %v", err)
+	}
+	if _, err := env.store.GetThreadByID(ctx, reply.ID); err != model.ErrNotFound {
+		t.Errorf("reply still exists: %v", err)
+	}
+	if errCtx := env.authService().Withdraw(ctx, testMeta, alice.ID); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("second withdraw: errCtx = %+v, want 404", errCtx)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/dataloader"
+)
+
+type authorLoader = dataloader.Loader[string, dto.AuthorResponse]
+
+type authorLoaderKey struct{}
+
+// 요청 단위 로더는 요청 ctx의 UserValue에 붙여두고 같은 요청 안에서 다시 꺼내 씀
+type requestValues interface {
+	UserValue(key interface{}) interface{}
+	SetUserValue(key interface{}, value interface{})
+}
+
+/*
+목록, 댓글, 북마크 등 작성자를 보여주는 곳에서 유저를 하나씩 조회하지 않도록 요청 단위 로더를 사용함.
+같은 요청 안에서는 여러 서비스가 하나의 로더를 공유하므로 이미 불러온 작성자는 다시 조회하지 않음.
+요청 ctx가 아닌 경우(스케줄러 등)에는 호출할 때마다 새 로더를 만듦.
+*/
+func authorLoaderFrom(ctx context.Context, userRepo repository.UserLookup) *authorLoader {
+	values, ok := ctx.(requestValues)
+	if ok {
+		if loader, ok := values.UserValue(authorLoaderKey{}).(*authorLoader); ok {
+			return loader
+		}
+	}
+
+	loader := dataloader.New(func(ctx context.Context, userIDs []string) (map[string]dto.AuthorResponse, error) {
+		users, err := userRepo.ListUsersByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		authors := make(map[string]dto.AuthorResponse, len(users))
+		for i := range users {
+			authors[users[i].ID] = authorResponse(&users[i])
+		}
+		return authors, nil
+	})
+	if ok {
+		values.SetUserValue(authorLoaderKey{}, loader)
+	}
+	return loader
+}
+
+// 탈퇴 등으로 찾지 못한 작성자는 ID만 채워서 돌려줌
+func loadAuthors(ctx context.Context, userRepo repository.UserLookup, userIDs []string) (map[string]dto.AuthorResponse, error) {
+	authors, err := authorLoaderFrom(ctx, userRepo).LoadMany(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		if _, exists := authors[userID]; !exists {
+			authors[userID] = dto.AuthorResponse{ID: userID}
+		}
+	}
+	return authors, nil
+}
+
+// 같은 쿼리에서 작성자를 함께 가져온 경우 로더에도 넣어두어 같은 요청의 다른 조회가 재사용하도록 함
+func primeAuthor(ctx context.Context, userRepo repository.UserLookup, user *model.UsersModel) dto.AuthorResponse {
+	author := authorResponse(user)
+	authorLoaderFrom(ctx, userRepo).Prime(author.ID, author)
+	return author
+}
+
+func authorResponse(user *model.UsersModel) dto.AuthorResponse {
+	profilePic, _ := user.ProfilePic()
+	return dto.AuthorResponse{
+		ID:         user.ID,
+		Handle:     user.Handle,
+		Name:       user.Name,
+		ProfilePic: profilePic,
+	}
+}
+
+/*
+fetchAuthor()로 함께 가져온 작성자는 로더에 넣어두고, 작성자를 함께 가져오지 못한 쓰레드는 로더로 한 번에 조회함.
+작성자 모델은 요약 정보로만 바꿔서 사용하므로 비밀번호 해시 등이 응답이나 캐시에 섞이지 않음.
+*/
+func threadAuthors(ctx context.Context, userRepo repository.UserLookup, threads []model.ThreadModel) (map[string]dto.AuthorResponse, error) {
+	authors := make(map[string]dto.AuthorResponse, len(threads))
+	missing := make([]string, 0)
+	for _, thread := range threads {
+		if user := thread.RelationsThread.User; user != nil {
+			authors[thread.UserID] = primeAuthor(ctx, userRepo, user)
+		} else {
+			missing = append(missing, thread.UserID)
+		}
+	}
+	if len(missing) == 0 {
+		return authors, nil
+	}
+
+	loaded, err := loadAuthors(ctx, userRepo, missing)
+	if err != nil {
+		return nil, err
+	}
+	for userID, author := range loaded {
+		authors[userID] = author
+	}
+	return authors, nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type BanService struct {
+	moderationRepo *repository.ModerationRepository
+	auditService   *AuditService
+	cacheStore     cache.Cache
+	redisCache     *redis.Client
+}
+
+func NewBanService(repo *repository.ModerationRepository, auditService *AuditService, cacheStore cache.Cache, rdconn *redis.Client) *BanService {
+	return &BanService{
+		moderationRepo: repo,
+		auditService:   auditService,
+		cacheStore:     cacheStore,
+		redisCache:     rdconn,
+	}
+}
+
+func (s *BanService) BanUser(ctx context.Context, meta dto.RequestMeta, req *dto.BanUserRequest) *exception.ErrResponseCtx {
+	user, errCtx := s.getUser(ctx, req.Handle, "유저 정지")
+	if errCtx != nil {
+		return errCtx
+	}
+	if user.ID == req.AdminID {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 유저 정지 실패. 자기 자신은 정지할 수 없습니다.", exception.ErrInvalidParameter)
+	}
+
+	expiresAt := expiresAfterHours(req.DurationHours)
+	if _, err := s.moderationRepo.CreateBan(ctx, user.ID, req.Reason, req.AdminID, expiresAt); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if err := applyBanToSession(ctx, s.redisCache, user.ID, dto.BanEntity{Reason: req.Reason, ExpiresAt: expiresAt}); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventUserBan,
+		ActorID:    &req.AdminID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   user.ID,
+		Diff:       map[string]interface{}{"reason": req.Reason, "expiresAt": expiresAt},
+	})
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID:  &req.AdminID,
+		Action:       model.ModerationActionTypeBanUser,
+		TargetUserID: &user.ID,
+		Note:         req.Reason,
+		ExpiresAt:    expiresAt,
+	}, "유저 정지")
+}
+
+func (s *BanService) UnbanUser(ctx context.Context, meta dto.RequestMeta, req *dto.UnbanUserRequest) *exception.ErrResponseCtx {
+	user, errCtx := s.getUser(ctx, req.Handle, "유저 정지 해제")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	revoked, err := s.moderationRepo.RevokeBans(ctx, user.ID, req.AdminID)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 해제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if revoked == 0 {
+		return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 유저 정지 해제 실패. 정지된 사용자가 아닙니다.", model.ErrNotFound)
+	}
+	if err := s.redisCache.Del(ctx, utils.BanCacheKey(user.ID)).Err(); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 해제 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventUserUnban,
+		ActorID:    &req.AdminID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   user.ID,
+		Diff:       map[string]interface{}{"revokedBans": revoked},
+	})
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID:  &req.AdminID,
+		Action:       model.ModerationActionTypeUnbanUser,
+		TargetUserID: &user.ID,
+	}, "유저 정지 해제")
+}
+
+func (s *BanService) ListBans(ctx context.Context) ([]dto.BanResponse, *exception.ErrResponseCtx) {
+	bans, err := s.moderationRepo.ListActiveBans(ctx)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 정지 목록 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	listBan := make([]dto.BanResponse, 0, len(bans))
+	for _, ban := range bans {
+		response := dto.BanResponse{
+			ID:        ban.ID,
+			UserID:    ban.UserID,
+			Reason:    ban.Reason,
+			CreatedBy: ban.CreatedBy,
+			CreatedAt: ban.CreatedAt,
+		}
+		if expiresAt, ok := ban.ExpiresAt(); ok {
+			response.ExpiresAt = &expiresAt
+		}
+		listBan = append(listBan, response)
+	}
+	return listBan, nil
+}
+
+func (s *BanService) BanIP(ctx context.Context, meta dto.RequestMeta, req *dto.BanIPRequest) *exception.ErrResponseCtx {
+	ipNet, err := utils.ParseCIDR(req.CIDR)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ IP 차단 실패. 올바르지 않은 IP 또는 CIDR입니다.", exception.ErrInvalidCIDR)
+	}
+
+	expiresAt := expiresAfterHours(req.DurationHours)
+	ban, err := s.moderationRepo.CreateIPBan(ctx, ipNet.String(), req.Reason, req.AdminID, expiresAt)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ IP 차단 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.cacheStore.Delete(ctx, utils.IPBanListCacheKey())
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventIPBan,
+		ActorID:    &req.AdminID,
+		TargetType: dto.AuditTargetIP,
+		TargetID:   ban.Cidr,
+		Diff:       map[string]interface{}{"banID": ban.ID, "reason": req.Reason, "expiresAt": expiresAt},
+	})
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.AdminID,
+		Action:      model.ModerationActionTypeBanIP,
+		Note:        fmt.Sprintf("ipBan=%d cidr=%s reason=%s", ban.ID, ban.Cidr, req.Reason),
+		ExpiresAt:   expiresAt,
+	}, "IP 차단")
+}
+
+func (s *BanService) UnbanIP(ctx context.Context, meta dto.RequestMeta, req *dto.UnbanIPRequest) *exception.ErrResponseCtx {
+	revoked, err := s.moderationRepo.RevokeIPBan(ctx, req.BanID, req.AdminID)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ IP 차단 해제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if !revoked {
+		return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ IP 차단 해제 실패. 존재하지 않는 차단입니다.", model.ErrNotFound)
+	}
+	s.cacheStore.Delete(ctx, utils.IPBanListCacheKey())
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventIPUnban,
+		ActorID:    &req.AdminID,
+		TargetType: dto.AuditTargetIP,
+		Diff:       map[string]interface{}{"banID": req.BanID},
+	})
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.AdminID,
+		Action:      model.ModerationActionTypeUnbanIP,
+		Note:        fmt.Sprintf("ipBan=%d", req.BanID),
+	}, "IP 차단 해제")
+}
+
+func (s *BanService) ListIPBans(ctx context.Context) ([]dto.IPBanResponse, *exception.ErrResponseCtx) {
+	bans, err := s.moderationRepo.ListActiveIPBans(ctx)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ IP 차단 목록 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return ipBanResponses(bans), nil
+}
+
+func (s *BanService) getUser(ctx context.Context, handle, action string) (*model.UsersModel, *exception.ErrResponseCtx) {
+	user, err := s.moderationRepo.GetUserByHandle(ctx, handle)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+	return user, nil
+}
+
+func (s *BanService) recordAction(ctx context.Context, entity dto.ModerationActionEntity, action string) *exception.ErrResponseCtx {
+	if _, err := s.moderationRepo.CreateAction(ctx, entity); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. 조치 기록을 저장하지 못했습니다.", err)
+	}
+	return nil
+}
+
+// 기간이 0이면 영구 정지
+func expiresAfterHours(hours int) *time.Time {
+	if hours <= 0 {
+		return nil
+	}
+	expiresAt := time.Now().Add(time.Duration(hours) * time.Hour)
+	return &expiresAt
+}
+
+/*
+정지 정보는 DB에 저장하지만 JWTMiddleware는 DB에 접근하지 않으므로 Redis에도 함께 저장함. (auth:ban:{uuid})
+기간 정지는 만료 시각까지만 캐시에 남도록 TTL을 걸어둠.
+정지와 동시에 기존에 발급된 토큰도 모두 폐기함.
+*/
+func applyBanToSession(ctx context.Context, rdconn *redis.Client, userID string, ban dto.BanEntity) error {
+	var ttl time.Duration
+	if ban.ExpiresAt != nil {
+		if ttl = time.Until(*ban.ExpiresAt); ttl <= 0 {
+			return nil
+		}
+	}
+	if err := utils.SetCache(rdconn, ctx, utils.BanCacheKey(userID), ban, ttl); err != nil {
+		return err
+	}
+	return revokeTokens(ctx, rdconn, userID)
+}
+
+// 지금까지 발급된 토큰은 모두 만료 처리. 토큰 유효기간이 지나면 기록이 필요 없으므로 같은 TTL을 사용
+func revokeTokens(ctx context.Context, rdconn *redis.Client, userID string) error {
+	ttl := time.Duration(config.Envs.JWTExpirationInSeconds) * time.Second
+	return rdconn.Set(ctx, utils.TokenRevokedCacheKey(userID), time.Now().UnixMilli(), ttl).Err()
+}
+
+func ipBanResponses(bans []model.NetworkBanModel) []dto.IPBanResponse {
+	listBan := make([]dto.IPBanResponse, 0, len(bans))
+	for _, ban := range bans {
+		response := dto.IPBanResponse{
+			ID:        ban.ID,
+			CIDR:      ban.Cidr,
+			Reason:    ban.Reason,
+			CreatedBy: ban.CreatedBy,
+			CreatedAt: ban.CreatedAt,
+		}
+		if expiresAt, ok := ban.ExpiresAt(); ok {
+			response.ExpiresAt = &expiresAt
+		}
+		listBan = append(listBan, response)
+	}
+	return listBan
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+const defaultBookmarkPageSize = 20
+
+type BookmarkService struct {
+	bookmarkRepo *repository.BookmarkRepository
+	userRepo     repository.UserLookup
+	cacheStore   cache.Cache
+	redisCache   *redis.Client
+}
+
+func NewBookmarkService(repo *repository.BookmarkRepository, userRepo repository.UserLookup, cacheStore cache.Cache, rdconn *redis.Client) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo: repo,
+		userRepo:     userRepo,
+		cacheStore:   cacheStore,
+		redisCache:   rdconn,
+	}
+}
+
+func (s *BookmarkService) CreateBookmark(ctx context.Context, req *dto.CreateBookmarkRequest) (*dto.BookmarkResponse, *exception.ErrResponseCtx) {
+	thread, err := s.bookmarkRepo.GetThreadByID(ctx, req.ThreadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 북마크 추가 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 추가 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+	if _, hidden := thread.HiddenAt(); hidden || thread.Status != model.ThreadStatusPublished {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 북마크 추가 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
+	if req.FolderID != nil {
+		if _, errCtx := s.getFolder(ctx, req.UserID, *req.FolderID, "북마크 추가"); errCtx != nil {
+			return nil, errCtx
+		}
+	}
+
+	if err := s.bookmarkRepo.CreateBookmark(ctx, req, thread.Title); err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 북마크 추가 실패. 이미 북마크한 쓰레드입니다.", exception.ErrAlreadyBookmarked)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 추가 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	bookmark, err := s.bookmarkRepo.GetBookmarkByThread(ctx, req.UserID, req.ThreadID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 추가 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.onBookmarkCountChanged(ctx, req.ThreadID)
+
+	responses, err := s.bookmarkResponses(ctx, []model.BookmarkModel{*bookmark})
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 추가 실패. 작성자 정보를 불러오지 못했습니다.", err)
+	}
+	return &responses[0], nil
+}
+
+func (s *BookmarkService) UpdateBookmark(ctx context.Context, req *dto.UpdateBookmarkRequest) (*dto.BookmarkResponse, *exception.ErrResponseCtx) {
+	if _, errCtx := s.getBookmark(ctx, req.UserID, req.BookmarkID, "북마크 수정"); errCtx != nil {
+		return nil, errCtx
+	}
+
+	params := []model.BookmarkSetParam{
+		model.Bookmark.Note.SetIfPresent(req.Note),
+	}
+	if req.FolderID != nil {
+		if *req.FolderID == 0 {
+			params = append(params, model.Bookmark.Folder.Unlink())
+		} else {
+			if _, errCtx := s.getFolder(ctx, req.UserID, *req.FolderID, "북마크 수정"); errCtx != nil {
+				return nil, errCtx
+			}
+			params = append(params, model.Bookmark.Folder.Link(model.BookmarkFolder.ID.Equals(*req.FolderID)))
+		}
+	}
+
+	if err := s.bookmarkRepo.UpdateBookmark(ctx, req.BookmarkID, params...); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 수정 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	bookmark, errCtx := s.getBookmark(ctx, req.UserID, req.BookmarkID, "북마크 수정")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	responses, err := s.bookmarkResponses(ctx, []model.BookmarkModel{*bookmark})
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 수정 실패. 작성자 정보를 불러오지 못했습니다.", err)
+	}
+	return &responses[0], nil
+}
+
+func (s *BookmarkService) DeleteBookmark(ctx context.Context, req *dto.BookmarkRequest) *exception.ErrResponseCtx {
+	bookmark, errCtx := s.getBookmark(ctx, req.UserID, req.BookmarkID, "북마크 삭제")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.bookmarkRepo.DeleteBookmark(ctx, bookmark); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 삭제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if threadID, ok := bookmark.ThreadID(); ok {
+		s.onBookmarkCountChanged(ctx, threadID)
+	}
+	return nil
+}
+
+func (s *BookmarkService) ListBookmarks(ctx context.Context, req *dto.ListBookmarkRequest) ([]dto.BookmarkResponse, *int, *exception.ErrResponseCtx) {
+	if req.FolderID > 0 {
+		if _, errCtx := s.getFolder(ctx, req.UserID, req.FolderID, "북마크 조회"); errCtx != nil {
+			return nil, nil, errCtx
+		}
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultBookmarkPageSize
+	}
+
+	bookmarks, err := s.bookmarkRepo.ListBookmarks(ctx, req.UserID, req.FolderID, req.Cursor, pageSize)
+	if err != nil {
+		return nil, nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	bookmarkList, err := s.bookmarkResponses(ctx, bookmarks)
+	if err != nil {
+		return nil, nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 조회 실패. 작성자 정보를 불러오지 못했습니다.", err)
+	}
+
+	var nextCursor *int
+	if len(bookmarks) == pageSize {
+		lastID := bookmarks[len(bookmarks)-1].ID
+		nextCursor = &lastID
+	}
+	return bookmarkList, nextCursor, nil
+}
+
+func (s *BookmarkService) CreateFolder(ctx context.Context, req *dto.CreateBookmarkFolderRequest) (*dto.BookmarkFolderResponse, *exception.ErrResponseCtx) {
+	folder, err := s.bookmarkRepo.CreateFolder(ctx, req)
+	if err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 북마크 폴더 생성 실패. 같은 이름의 폴더가 이미 있습니다.", exception.ErrFolderNameTaken)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 폴더 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	response := bookmarkFolderResponse(folder)
+	return &response, nil
+}
+
+func (s *BookmarkService) ListFolders(ctx context.Context, userID string) ([]dto.BookmarkFolderResponse, *exception.ErrResponseCtx) {
+	folders, err := s.bookmarkRepo.ListFolders(ctx, userID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 폴더 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	folderList := make([]dto.BookmarkFolderResponse, 0, len(folders))
+	for i := range folders {
+		folderList = append(folderList, bookmarkFolderResponse(&folders[i]))
+	}
+	return folderList, nil
+}
+
+func (s *BookmarkService) DeleteFolder(ctx context.Context, req *dto.BookmarkFolderRequest) *exception.ErrResponseCtx {
+	if _, errCtx := s.getFolder(ctx, req.UserID, req.FolderID, "북마크 폴더 삭제"); errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.bookmarkRepo.DeleteFolder(ctx, req.FolderID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 북마크 폴더 삭제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return nil
+}
+
+// 다른 유저의 북마크는 존재 여부도 드러나지 않도록 없는 북마크와 똑같이 처리함
+func (s *BookmarkService) getBookmark(ctx context.Context, userID string, bookmarkID int, action string) (*model.BookmarkModel, *exception.ErrResponseCtx) {
+	bookmark, err := s.bookmarkRepo.GetBookmarkByID(ctx, bookmarkID)
+	if err != nil && err != model.ErrNotFound {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+	}
+	if err == model.ErrNotFound || bookmark.UserID != userID {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 북마크입니다.", action), model.ErrNotFound)
+	}
+	return bookmark, nil
+}
+
+func (s *BookmarkService) getFolder(ctx context.Context, userID string, folderID int, action string) (*model.BookmarkFolderModel, *exception.ErrResponseCtx) {
+	folder, err := s.bookmarkRepo.GetFolderByID(ctx, folderID)
+	if err != nil && err != model.ErrNotFound {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+	}
+	if err == model.ErrNotFound || folder.UserID != userID {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 폴더입니다.", action), model.ErrNotFound)
+	}
+	return folder, nil
+}
+
+/*
+북마크 수는 다른 인터렉션과 달리 유저당 한 번만 올라가므로 Redis에 모으지 않고 북마크 트랜잭션에서 바로 DB에 반영함.
+대신 쓰레드 상세 캐시를 지워 다음 조회부터 바뀐 값이 보이도록 하고, 구독 중인 클라이언트에는 현재 값을 알림.
+*/
+func (s *BookmarkService) onBookmarkCountChanged(ctx context.Context, threadID int) {
+	s.cacheStore.Delete(ctx, threadCacheKey(threadID))
+
+	thread, err := s.bookmarkRepo.GetThreadByID(ctx, threadID)
+	if err != nil {
+		return
+	}
+	publishRealtimeEvent(s.redisCache, ctx, RealtimeThreadChannel(threadID), dto.RealtimeEvent{
+		Type:     dto.EventThreadCounter,
+		ThreadID: threadID,
+		Payload: dto.CounterPayload{
+			Field:  "bookmarks",
+			Amount: int64(thread.Bookmarks),
+		},
+		CreatedAt: time.Now(),
+	})
+}
+
+// 볼 수 있는 쓰레드의 작성자만 모아서 한 번에 조회함
+func (s *BookmarkService) bookmarkResponses(ctx context.Context, bookmarks []model.BookmarkModel) ([]dto.BookmarkResponse, error) {
+	responses := make([]dto.BookmarkResponse, 0, len(bookmarks))
+	authorIDs := make([]string, 0, len(bookmarks))
+	for i := range bookmarks {
+		response := bookmarkResponse(&bookmarks[i])
+		if response.Available {
+			thread, _ := bookmarks[i].Thread()
+			authorIDs = append(authorIDs, thread.UserID)
+		}
+		responses = append(responses, response)
+	}
+	if len(authorIDs) == 0 {
+		return responses, nil
+	}
+
+	authors, err := loadAuthors(ctx, s.userRepo, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		if responses[i].Thread != nil {
+			thread, _ := bookmarks[i].Thread()
+			responses[i].Thread.Author = authors[thread.UserID]
+		}
+	}
+	return responses, nil
+}
+
+func bookmarkResponse(bookmark *model.BookmarkModel) dto.BookmarkResponse {
+	note, _ := bookmark.Note()
+	response := dto.BookmarkResponse{
+		ID:          bookmark.ID,
+		ThreadTitle: bookmark.ThreadTitle,
+		Note:        note,
+		CreatedAt:   bookmark.CreatedAt,
+	}
+	if threadID, ok := bookmark.ThreadID(); ok {
+		response.ThreadID = &threadID
+	}
+	if folderID, ok := bookmark.FolderID(); ok {
+		response.FolderID = &folderID
+	}
+
+	// 쓰레드가 삭제되면 threadID가 비워지고, 숨김 처리되거나 다시 임시 저장된 쓰레드도 볼 수 없는 북마크로 보여줌
+	thread, ok := bookmark.Thread()
+	if !ok {
+		return response
+	}
+	if _, hidden := thread.HiddenAt(); hidden || thread.Status != model.ThreadStatusPublished {
+		return response
+	}
+	imgURL, _ := thread.ImgURL()
+	response.Available = true
+	response.Thread = &dto.BookmarkThreadResponse{
+		ID:        thread.ID,
+		Title:     thread.Title,
+		ImgURL:    imgURL,
+		Views:     thread.Views,
+		Likes:     thread.Likes,
+		Bookmarks: thread.Bookmarks,
+		CreatedAt: thread.CreatedAt,
+	}
+	return response
+}
+
+func bookmarkFolderResponse(folder *model.BookmarkFolderModel) dto.BookmarkFolderResponse {
+	return dto.BookmarkFolderResponse{
+		ID:        folder.ID,
+		Name:      folder.Name,
+		CreatedAt: folder.CreatedAt,
+	}
+}
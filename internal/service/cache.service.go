@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+const threadListCacheTag = "thread:list"
+
+/*
+쓰레드 캐시에 저장하는 응답 DTO(dto.ThreadResponse 등)의 형식 버전.
+버전이 키에 들어가므로 DTO의 필드를 바꾸면서 버전을 올리면 이전 형식으로 저장된 값은 읽히지 않고 TTL이 지나면 사라짐.
+*/
+const threadPayloadVersion = 2
+
+/*
+세션, 차단 정보처럼 지워지면 보안에 영향이 있는 키와 아직 DB에 반영되지 않은 인터렉션 값(thread:{id}:views 등)은
+관리자라도 패턴으로 지울 수 없도록 DB에서 다시 만들 수 있는 캐시만 허용함.
+*/
+var purgeablePrefixes = []string{"thread:list:", "thread:content:", "preview:", "poll:"}
+
+type CacheService struct {
+	cacheStore cache.Cache
+}
+
+func NewCacheService(cacheStore cache.Cache) *CacheService {
+	return &CacheService{cacheStore: cacheStore}
+}
+
+/*
+평소의 무효화는 세대 번호와 정확한 키 삭제로 처리하고, 이 함수는 배포 직후 캐시 형식이 바뀌었을 때처럼
+관리자가 직접 비워야 하는 경우에만 사용함.
+*/
+func (s *CacheService) Purge(ctx context.Context, pattern string) (int64, *exception.ErrResponseCtx) {
+	if !isPurgeablePattern(pattern) {
+		return 0, exception.GenerateErrorCtx(fiber.StatusBadRequest, fmt.Sprintf("❌ 캐시 삭제 실패. %s 로 시작하는 패턴만 삭제할 수 있습니다.", strings.Join(purgeablePrefixes, ", ")), exception.ErrInvalidParameter)
+	}
+
+	deleted, err := s.cacheStore.Purge(ctx, pattern)
+	if err != nil {
+		return deleted, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 캐시 삭제 실패. 캐시를 비우는 과정에서 문제가 발생했습니다.", err)
+	}
+	if strings.HasPrefix(pattern, threadListCacheTag) {
+		if err := s.cacheStore.BumpGeneration(ctx, threadListCacheTag); err != nil {
+			return deleted, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 캐시 삭제 실패. 캐시를 비우는 과정에서 문제가 발생했습니다.", err)
+		}
+	}
+	return deleted, nil
+}
+
+func isPurgeablePattern(pattern string) bool {
+	for _, prefix := range purgeablePrefixes {
+		if strings.HasPrefix(pattern, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+쓰레드가 생성, 삭제되거나 상태가 바뀌면 해당 쓰레드가 보일 수 있는 캐시를 모두 비움.
+전체 목록은 페이지 수만큼 키가 생기므로 세대 번호를 올리고, 작성자 목록과 상세는 키가 정해져 있으므로 바로 지움.
+*/
+func invalidateThreadCache(ctx context.Context, cacheStore cache.Cache, thread *model.ThreadModel, handle string) error {
+	if err := cacheStore.BumpGeneration(ctx, threadListCacheTag); err != nil {
+		return err
+	}
+
+	keys := []string{
+		threadCacheKey(thread.ID),
+		threadAttachmentsCacheKey(thread.ID),
+	}
+	if handle != "" {
+		keys = append(keys, threadListByHandleCacheKey(handle))
+	}
+	if parentThread, isReply := thread.ParentThread(); isReply {
+		keys = append(keys, threadCacheKey(parentThread))
+	}
+	return cacheStore.Delete(ctx, keys...)
+}
+
+// 캐시 종류마다 설정된 TTL(초)에 공통 stale 구간과 jitter 비율을 붙여서 사용함
+func cacheTTL(freshSeconds int64) cache.TTL {
+	return cache.TTL{
+		Fresh:  time.Duration(freshSeconds) * time.Second,
+		Stale:  time.Duration(config.Envs.CacheStaleSeconds) * time.Second,
+		Jitter: float64(config.Envs.CacheJitterPercent) / 100,
+	}
+}
+
+// stale 구간 없이 바로 만료되는 캐시에도 jitter만 적용함
+func jitteredTTL(freshSeconds int64) time.Duration {
+	ttl := cacheTTL(freshSeconds)
+	return cache.JitterTTL(ttl.Fresh, ttl.Jitter)
+}
+
+// 인터렉션 값(thread:{id}:views 등)은 DTO가 아니므로 버전 없이 기존 키를 그대로 사용함
+func threadCacheKey(threadID int) string {
+	return fmt.Sprintf("thread:v%d:%d", threadPayloadVersion, threadID)
+}
+
+func threadAttachmentsCacheKey(threadID int) string {
+	return fmt.Sprintf("thread:v%d:%d:attachments", threadPayloadVersion, threadID)
+}
+
+func threadListCacheKey(generation int64, pageNumber, pageSize int) string {
+	return fmt.Sprintf("%s:v%d:g%d:page:%d:size:%d", threadListCacheTag, threadPayloadVersion, generation, pageNumber, pageSize)
+}
+
+func threadListByHandleCacheKey(handle string) string {
+	return fmt.Sprintf("%s:v%d:handle:%s", threadListCacheTag, threadPayloadVersion, handle)
+}
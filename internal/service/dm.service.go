@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+// 한 번에 불러오는 메시지 수의 기본값
+const defaultMessagePageSize = 30
+
+type DirectMessageService struct {
+	dmRepo       *repository.DirectMessageRepository
+	relationRepo repository.RelationStore
+	redisCache   *redis.Client
+}
+
+func NewDirectMessageService(repo *repository.DirectMessageRepository, relationRepo repository.RelationStore, rdconn *redis.Client) *DirectMessageService {
+	return &DirectMessageService{
+		dmRepo:       repo,
+		relationRepo: relationRepo,
+		redisCache:   rdconn,
+	}
+}
+
+func (s *DirectMessageService) CreateConversation(ctx context.Context, req *dto.CreateConversationRequest) (*dto.ConversationResponse, *exception.ErrResponseCtx) {
+	users, err := s.dmRepo.GetUsersByHandles(ctx, req.Handles)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	participantIDs := []string{req.UserID}
+	for _, user := range users {
+		if user.ID != req.UserID {
+			participantIDs = append(participantIDs, user.ID)
+		}
+	}
+	if len(users) != len(req.Handles) || len(participantIDs) < 2 {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 대화방 생성 실패. 대화 상대가 올바르지 않습니다.", exception.ErrInvalidParticipants)
+	}
+
+	blocked, err := s.relationRepo.HasBlockBetween(ctx, req.UserID, participantIDs[1:])
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if blocked {
+		return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 대화방 생성 실패. 차단 관계에 있는 사용자가 포함되어 있습니다.", exception.ErrBlockedByUser)
+	}
+
+	isGroup := len(participantIDs) > 2
+	if !isGroup {
+		conversation, err := s.dmRepo.FindDirectConversation(ctx, participantIDs[0], participantIDs[1])
+		if err == nil {
+			return s.singleConversationResponse(ctx, conversation, req.UserID, "대화방 생성")
+		} else if err != model.ErrNotFound {
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 생성 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	conversation, err := s.dmRepo.CreateConversation(ctx, isGroup, participantIDs)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return s.singleConversationResponse(ctx, conversation, req.UserID, "대화방 생성")
+}
+
+func (s *DirectMessageService) ListConversations(ctx context.Context, userID string) ([]dto.ConversationResponse, int, *exception.ErrResponseCtx) {
+	conversations, err := s.dmRepo.ListConversations(ctx, userID)
+	if err != nil {
+		return nil, 0, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	unread, err := s.dmRepo.CountUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, 0, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	totalUnread := 0
+	listConversation := make([]dto.ConversationResponse, 0, len(conversations))
+	for i := range conversations {
+		conversation := conversationResponse(&conversations[i], userID, unread)
+		if !conversation.Muted {
+			totalUnread += conversation.UnreadCount
+		}
+		listConversation = append(listConversation, conversation)
+	}
+	return listConversation, totalUnread, nil
+}
+
+func (s *DirectMessageService) ListMessages(ctx context.Context, req *dto.ListDirectMessageRequest) ([]dto.DirectMessageResponse, int, *exception.ErrResponseCtx) {
+	conversation, errCtx := s.getConversationForParticipant(ctx, req.ConversationID, req.UserID, "메시지 조회")
+	if errCtx != nil {
+		return nil, 0, errCtx
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultMessagePageSize
+	}
+	messages, err := s.dmRepo.ListMessages(ctx, req.ConversationID, req.Cursor, pageSize)
+	if err != nil {
+		return nil, 0, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, req.UserID)
+	if err != nil {
+		return nil, 0, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 조회 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
+
+	// 페이지를 다 채우지 못했다면 더 오래된 메시지가 없으므로 커서를 0으로 돌려줌
+	nextCursor := 0
+	if len(messages) == pageSize {
+		nextCursor = messages[len(messages)-1].ID
+	}
+
+	hidden := hiddenUserSet(relations.Blocked)
+	listMessage := make([]dto.DirectMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		if senderID, ok := message.SenderID(); ok {
+			if _, isHidden := hidden[senderID]; isHidden {
+				continue
+			}
+		}
+		listMessage = append(listMessage, s.messageResponse(&message, conversation.Participants()))
+	}
+	return listMessage, nextCursor, nil
+}
+
+func (s *DirectMessageService) SendMessage(ctx context.Context, req *dto.SendDirectMessageRequest) (*dto.DirectMessageResponse, *exception.ErrResponseCtx) {
+	conversation, errCtx := s.getConversationForParticipant(ctx, req.ConversationID, req.UserID, "메시지 전송")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+
+	otherIDs := make([]string, 0)
+	for _, participant := range conversation.Participants() {
+		if participant.UserID != req.UserID {
+			otherIDs = append(otherIDs, participant.UserID)
+		}
+	}
+
+	// 1:1 대화방은 어느 한쪽이라도 차단했다면 메시지를 보낼 수 없음
+	if !conversation.IsGroup && len(otherIDs) > 0 {
+		blocked, err := s.relationRepo.HasBlockBetween(ctx, req.UserID, otherIDs)
+		if err != nil {
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 전송 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+		if blocked {
+			return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 메시지 전송 실패. 차단 관계에 있는 사용자입니다.", exception.ErrBlockedByUser)
+		}
+	}
+
+	message, err := s.dmRepo.CreateMessage(ctx, req.ConversationID, req.UserID, req.Content)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 전송 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	response := s.messageResponse(message, conversation.Participants())
+	for _, participant := range conversation.Participants() {
+		if participant.UserID == req.UserID {
+			continue
+		}
+		// 알림을 끈 대화방이거나 보낸 사람을 차단한 참여자에게는 실시간 푸시를 보내지 않음
+		if _, muted := participant.MutedAt(); muted {
+			continue
+		}
+		if blocked, err := s.relationRepo.IsBlocked(ctx, participant.UserID, req.UserID); err != nil || blocked {
+			continue
+		}
+		publishRealtimeEvent(s.redisCache, ctx, RealtimeUserChannel(participant.UserID), dto.RealtimeEvent{
+			Type:           dto.EventDirectMessage,
+			ConversationID: req.ConversationID,
+			Payload:        response,
+			CreatedAt:      time.Now(),
+		})
+	}
+	return &response, nil
+}
+
+func (s *DirectMessageService) MarkRead(ctx context.Context, req *dto.MarkReadRequest) *exception.ErrResponseCtx {
+	if _, errCtx := s.getConversationForParticipant(ctx, req.ConversationID, req.UserID, "메시지 읽음 처리"); errCtx != nil {
+		return errCtx
+	}
+
+	// 아직 없는 ID(ex. 2147483647)나 다른 대화방의 메시지를 허용하면 앞으로 올 메시지까지 읽은 것으로 처리되므로 대화방에 있는 메시지인지 확인함
+	messageID := req.MessageID
+	if messageID > 0 {
+		if _, err := s.dmRepo.GetMessage(ctx, req.ConversationID, messageID); err == model.ErrNotFound {
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 메시지 읽음 처리 실패. 존재하지 않는 메시지입니다.", exception.ErrMessageNotFound)
+		} else if err != nil {
+			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 읽음 처리 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	} else {
+		latest, err := s.dmRepo.GetLatestMessage(ctx, req.ConversationID)
+		if err == model.ErrNotFound {
+			return nil
+		} else if err != nil {
+			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 읽음 처리 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+		messageID = latest.ID
+	}
+
+	if err := s.dmRepo.MarkRead(ctx, req.ConversationID, req.UserID, messageID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 메시지 읽음 처리 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return nil
+}
+
+func (s *DirectMessageService) MuteConversation(ctx context.Context, req *dto.MuteConversationRequest) *exception.ErrResponseCtx {
+	if _, errCtx := s.getConversationForParticipant(ctx, req.ConversationID, req.UserID, "대화방 알림 설정"); errCtx != nil {
+		return errCtx
+	}
+
+	var mutedAt *time.Time
+	if req.Muted {
+		now := time.Now()
+		mutedAt = &now
+	}
+	if err := s.dmRepo.SetMuted(ctx, req.ConversationID, req.UserID, mutedAt); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 알림 설정 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return nil
+}
+
+func (s *DirectMessageService) LeaveConversation(ctx context.Context, req *dto.ConversationRequest) *exception.ErrResponseCtx {
+	if _, errCtx := s.getConversationForParticipant(ctx, req.ConversationID, req.UserID, "대화방 나가기"); errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.dmRepo.RemoveParticipant(ctx, req.ConversationID, req.UserID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 대화방 나가기 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return nil
+}
+
+func (s *DirectMessageService) getConversationForParticipant(ctx context.Context, conversationID int, userID, action string) (*model.ConversationModel, *exception.ErrResponseCtx) {
+	conversation, err := s.dmRepo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 대화방입니다.", err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	for _, participant := range conversation.Participants() {
+		if participant.UserID == userID {
+			return conversation, nil
+		}
+	}
+	// 참여하지 않은 대화방의 존재 여부를 노출하지 않도록 404로 응답
+	return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 대화방입니다.", exception.ErrNotParticipant)
+}
+
+func (s *DirectMessageService) singleConversationResponse(ctx context.Context, conversation *model.ConversationModel, userID, action string) (*dto.ConversationResponse, *exception.ErrResponseCtx) {
+	unread, err := s.dmRepo.CountUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	response := conversationResponse(conversation, userID, unread)
+	return &response, nil
+}
+
+func conversationResponse(conversation *model.ConversationModel, userID string, unread map[int]int) dto.ConversationResponse {
+	response := dto.ConversationResponse{
+		ID:            conversation.ID,
+		IsGroup:       conversation.IsGroup,
+		LastMessageAt: conversation.LastMessageAt,
+		UnreadCount:   unread[conversation.ID],
+		Participants:  make([]dto.ParticipantResponse, 0),
+	}
+
+	for _, participant := range conversation.Participants() {
+		user := participant.User()
+		response.Participants = append(response.Participants, dto.ParticipantResponse{
+			UserID:            user.ID,
+			Handle:            user.Handle,
+			Name:              user.Name,
+			LastReadMessageID: participant.LastReadMessageID,
+		})
+		if participant.UserID == userID {
+			_, response.Muted = participant.MutedAt()
+		}
+	}
+	return response
+}
+
+func (s *DirectMessageService) messageResponse(message *model.DirectMessageModel, participants []model.ConversationParticipantModel) dto.DirectMessageResponse {
+	senderID, _ := message.SenderID()
+	readBy := make([]string, 0)
+	for _, participant := range participants {
+		if participant.UserID != senderID && participant.LastReadMessageID >= message.ID {
+			readBy = append(readBy, participant.UserID)
+		}
+	}
+
+	return dto.DirectMessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       senderID,
+		Content:        message.Content,
+		ReadBy:         readBy,
+		CreatedAt:      message.CreatedAt,
+	}
+}
@@ -0,0 +1,470 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/cache"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+type ModerationService struct {
+	moderationRepo *repository.ModerationRepository
+	auditService   *AuditService
+	cacheStore     cache.Cache
+	redisCache     *redis.Client
+}
+
+func NewModerationService(repo *repository.ModerationRepository, auditService *AuditService, cacheStore cache.Cache, rdconn *redis.Client) *ModerationService {
+	return &ModerationService{
+		moderationRepo: repo,
+		auditService:   auditService,
+		cacheStore:     cacheStore,
+		redisCache:     rdconn,
+	}
+}
+
+func (s *ModerationService) CreateReport(ctx context.Context, req *dto.CreateReportRequest) (*dto.ReportResponse, *exception.ErrResponseCtx) {
+	var (
+		targetKey    string
+		targetUserID *string
+		thread       *model.ThreadModel
+	)
+
+	switch model.ReportTargetType(req.TargetType) {
+	case model.ReportTargetTypeThread:
+		reportedThread, err := s.moderationRepo.GetThreadByID(ctx, *req.ThreadID)
+		if err != nil {
+			switch err {
+			case model.ErrNotFound:
+				return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 신고 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+			default:
+				return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 실패. Repository에서 문제가 발생했습니다.", err)
+			}
+		}
+		if reportedThread.UserID == req.UserID {
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 신고 실패. 자신의 쓰레드는 신고할 수 없습니다.", exception.ErrInvalidParameter)
+		}
+		thread = reportedThread
+		targetKey = fmt.Sprintf("thread:%d", reportedThread.ID)
+	case model.ReportTargetTypeUser:
+		user, err := s.moderationRepo.GetUserByHandle(ctx, req.Handle)
+		if err != nil {
+			switch err {
+			case model.ErrNotFound:
+				return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 신고 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+			default:
+				return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 실패. Repository에서 문제가 발생했습니다.", err)
+			}
+		}
+		if user.ID == req.UserID {
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 신고 실패. 자기 자신은 신고할 수 없습니다.", exception.ErrInvalidParameter)
+		}
+		req.ThreadID = nil
+		targetUserID = &user.ID
+		targetKey = fmt.Sprintf("user:%s", user.ID)
+	}
+
+	report, err := s.moderationRepo.CreateReport(ctx, req.UserID, targetKey, req, targetUserID)
+	if err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 신고 실패. 이미 신고한 대상입니다.", exception.ErrAlreadyReported)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	if thread != nil {
+		s.autoHideThread(ctx, thread)
+	}
+
+	response := s.reportResponse(report)
+	return &response, nil
+}
+
+func (s *ModerationService) ListReports(ctx context.Context, req *dto.ListReportRequest) ([]dto.ReportResponse, *exception.ErrResponseCtx) {
+	reports, err := s.moderationRepo.ListReports(ctx, req.Status, req.PageNumber, req.PageSize)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 목록 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	listReport := make([]dto.ReportResponse, 0, len(reports))
+	for i := range reports {
+		listReport = append(listReport, s.reportResponse(&reports[i]))
+	}
+	return listReport, nil
+}
+
+func (s *ModerationService) UpdateReport(ctx context.Context, req *dto.UpdateReportRequest) *exception.ErrResponseCtx {
+	if _, err := s.moderationRepo.GetReportByID(ctx, req.ReportID); err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 신고 처리 실패. 존재하지 않는 신고입니다.", err)
+		default:
+			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 처리 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	updated, err := s.moderationRepo.UpdateReportStatus(ctx, req.ReportID, model.ReportStatus(req.Status), req.ModeratorID)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 신고 처리 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if !updated {
+		return exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 신고 처리 실패. 이미 처리된 신고입니다.", exception.ErrInvalidStatusTransition)
+	}
+
+	if model.ReportStatus(req.Status) == model.ReportStatusDismissed {
+		return s.recordAction(ctx, dto.ModerationActionEntity{
+			ModeratorID: &req.ModeratorID,
+			Action:      model.ModerationActionTypeDismissReport,
+			ReportID:    &req.ReportID,
+			Note:        req.Note,
+		}, "신고 처리")
+	}
+	return nil
+}
+
+func (s *ModerationService) HideThread(ctx context.Context, req *dto.ModerateThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 숨김")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	now := time.Now()
+	if err := s.moderationRepo.SetThreadHidden(ctx, req.ThreadID, &now); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 숨김 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if err := s.moderationRepo.ResolveThreadReports(ctx, req.ThreadID, req.ModeratorID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 숨김 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypeHideThread,
+		ThreadID:    &req.ThreadID,
+		ReportID:    req.ReportID,
+		Note:        req.Note,
+	}, "쓰레드 숨김")
+}
+
+func (s *ModerationService) UnhideThread(ctx context.Context, req *dto.ModerateThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 숨김 해제")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.moderationRepo.SetThreadHidden(ctx, req.ThreadID, nil); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 숨김 해제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypeUnhideThread,
+		ThreadID:    &req.ThreadID,
+		ReportID:    req.ReportID,
+		Note:        req.Note,
+	}, "쓰레드 숨김 해제")
+}
+
+func (s *ModerationService) LockThread(ctx context.Context, req *dto.ModerateThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 잠금")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	now := time.Now()
+	if err := s.moderationRepo.SetThreadLocked(ctx, req.ThreadID, &now, &req.ModeratorID); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 잠금 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.markReportActioned(ctx, req.ReportID, req.ModeratorID)
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypeLockThread,
+		ThreadID:    &req.ThreadID,
+		ReportID:    req.ReportID,
+		Note:        req.Note,
+	}, "쓰레드 잠금")
+}
+
+func (s *ModerationService) UnlockThread(ctx context.Context, req *dto.ModerateThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 잠금 해제")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.moderationRepo.SetThreadLocked(ctx, req.ThreadID, nil, nil); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 잠금 해제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypeUnlockThread,
+		ThreadID:    &req.ThreadID,
+		ReportID:    req.ReportID,
+		Note:        req.Note,
+	}, "쓰레드 잠금 해제")
+}
+
+func (s *ModerationService) PinThread(ctx context.Context, req *dto.PinThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 고정")
+	if errCtx != nil {
+		return errCtx
+	}
+	if _, isReply := thread.ParentThread(); isReply {
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 쓰레드 고정 실패. 답글은 고정할 수 없습니다.", exception.ErrNotRootThread)
+	}
+	if _, hidden := thread.HiddenAt(); hidden {
+		return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 고정 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
+
+	pinnedUntil := expiresAfterHours(req.DurationHours)
+	if err := s.moderationRepo.SetThreadPinned(ctx, req.ThreadID, pinnedUntil); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 고정 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypePinThread,
+		ThreadID:    &req.ThreadID,
+		ExpiresAt:   pinnedUntil,
+		Note:        req.Note,
+	}, "쓰레드 고정")
+}
+
+func (s *ModerationService) UnpinThread(ctx context.Context, req *dto.ModerateThreadRequest) *exception.ErrResponseCtx {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "쓰레드 고정 해제")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.moderationRepo.SetThreadPinned(ctx, req.ThreadID, nil); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 고정 해제 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID: &req.ModeratorID,
+		Action:      model.ModerationActionTypeUnpinThread,
+		ThreadID:    &req.ThreadID,
+		Note:        req.Note,
+	}, "쓰레드 고정 해제")
+}
+
+func (s *ModerationService) WarnUser(ctx context.Context, req *dto.ModerateUserRequest) *exception.ErrResponseCtx {
+	user, errCtx := s.getModeratedUser(ctx, req, "유저 경고")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	s.markReportActioned(ctx, req.ReportID, req.ModeratorID)
+	publishRealtimeEvent(s.redisCache, ctx, RealtimeUserChannel(user.ID), dto.RealtimeEvent{
+		Type:      dto.EventModerationWarning,
+		Payload:   dto.WarningPayload{Reason: req.Reason},
+		CreatedAt: time.Now(),
+	})
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID:  &req.ModeratorID,
+		Action:       model.ModerationActionTypeWarnUser,
+		TargetUserID: &user.ID,
+		ReportID:     req.ReportID,
+		Note:         req.Reason,
+	}, "유저 경고")
+}
+
+func (s *ModerationService) SuspendUser(ctx context.Context, meta dto.RequestMeta, req *dto.ModerateUserRequest) *exception.ErrResponseCtx {
+	user, errCtx := s.getModeratedUser(ctx, req, "유저 정지")
+	if errCtx != nil {
+		return errCtx
+	}
+
+	expiresAt := expiresAfterHours(req.DurationHours)
+	if _, err := s.moderationRepo.CreateBan(ctx, user.ID, req.Reason, req.ModeratorID, expiresAt); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if err := applyBanToSession(ctx, s.redisCache, user.ID, dto.BanEntity{Reason: req.Reason, ExpiresAt: expiresAt}); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 정지 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	s.markReportActioned(ctx, req.ReportID, req.ModeratorID)
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventUserBan,
+		ActorID:    &req.ModeratorID,
+		TargetType: dto.AuditTargetUser,
+		TargetID:   user.ID,
+		Diff:       map[string]interface{}{"reason": req.Reason, "expiresAt": expiresAt, "reportID": req.ReportID},
+	})
+
+	return s.recordAction(ctx, dto.ModerationActionEntity{
+		ModeratorID:  &req.ModeratorID,
+		Action:       model.ModerationActionTypeSuspendUser,
+		TargetUserID: &user.ID,
+		ReportID:     req.ReportID,
+		Note:         req.Reason,
+		ExpiresAt:    expiresAt,
+	}, "유저 정지")
+}
+
+func (s *ModerationService) ListActions(ctx context.Context, req *dto.ListModerationActionRequest) ([]dto.ModerationActionResponse, *exception.ErrResponseCtx) {
+	actions, err := s.moderationRepo.ListActions(ctx, req.PageNumber, req.PageSize)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 조치 기록 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	listAction := make([]dto.ModerationActionResponse, 0, len(actions))
+	for _, action := range actions {
+		moderatorID, _ := action.ModeratorID()
+		targetUserID, _ := action.TargetUserID()
+		note, _ := action.Note()
+		response := dto.ModerationActionResponse{
+			ID:           action.ID,
+			ModeratorID:  moderatorID,
+			Action:       string(action.Action),
+			TargetUserID: targetUserID,
+			Note:         note,
+			CreatedAt:    action.CreatedAt,
+		}
+		if threadID, ok := action.ThreadID(); ok {
+			response.ThreadID = &threadID
+		}
+		if reportID, ok := action.ReportID(); ok {
+			response.ReportID = &reportID
+		}
+		if expiresAt, ok := action.ExpiresAt(); ok {
+			response.ExpiresAt = &expiresAt
+		}
+		listAction = append(listAction, response)
+	}
+	return listAction, nil
+}
+
+/*
+같은 쓰레드에 처리되지 않은 신고가 일정 수 이상 쌓이면 모더레이터 검토 전까지 자동으로 숨김 처리함.
+신고는 OPEN 상태로 남겨두어서 모더레이터가 검토 후 숨김 유지 또는 해제를 결정할 수 있도록 함.
+자동 숨김이 실패해도 신고 자체는 접수되었으므로 로그만 남김.
+*/
+func (s *ModerationService) autoHideThread(ctx context.Context, thread *model.ThreadModel) {
+	if _, hidden := thread.HiddenAt(); hidden {
+		return
+	}
+
+	reports, err := s.moderationRepo.ListOpenThreadReports(ctx, thread.ID)
+	if err != nil {
+		log.Printf("moderation: failed to count reports of thread %d: %v", thread.ID, err)
+		return
+	}
+	if int64(len(reports)) < config.Envs.ReportAutoHideCount {
+		return
+	}
+
+	now := time.Now()
+	if err := s.moderationRepo.SetThreadHidden(ctx, thread.ID, &now); err != nil {
+		log.Printf("moderation: failed to auto-hide thread %d: %v", thread.ID, err)
+		return
+	}
+	s.invalidateThreadCache(ctx, thread)
+
+	if _, err := s.moderationRepo.CreateAction(ctx, dto.ModerationActionEntity{
+		Action:   model.ModerationActionTypeHideThread,
+		ThreadID: &thread.ID,
+		Note:     fmt.Sprintf("auto-hidden after %d reports", len(reports)),
+	}); err != nil {
+		log.Printf("moderation: failed to record auto-hide of thread %d: %v", thread.ID, err)
+	}
+}
+
+func (s *ModerationService) markReportActioned(ctx context.Context, reportID *int, moderatorID string) {
+	if reportID == nil {
+		return
+	}
+	if _, err := s.moderationRepo.UpdateReportStatus(ctx, *reportID, model.ReportStatusActioned, moderatorID); err != nil {
+		log.Printf("moderation: failed to resolve report %d: %v", *reportID, err)
+	}
+}
+
+func (s *ModerationService) recordAction(ctx context.Context, entity dto.ModerationActionEntity, action string) *exception.ErrResponseCtx {
+	if _, err := s.moderationRepo.CreateAction(ctx, entity); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. 조치 기록을 저장하지 못했습니다.", err)
+	}
+	return nil
+}
+
+func (s *ModerationService) getThread(ctx context.Context, threadID int, action string) (*model.ThreadModel, *exception.ErrResponseCtx) {
+	thread, err := s.moderationRepo.GetThreadByID(ctx, threadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+	return thread, nil
+}
+
+func (s *ModerationService) getModeratedUser(ctx context.Context, req *dto.ModerateUserRequest, action string) (*model.UsersModel, *exception.ErrResponseCtx) {
+	user, err := s.moderationRepo.GetUserByHandle(ctx, req.Handle)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	if user.ID == req.ModeratorID || user.Role == model.UserRolesAdmin {
+		return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ "+action+" 실패. 해당 사용자에게는 조치할 수 없습니다.", exception.ErrForbiddenRole)
+	}
+	return user, nil
+}
+
+func (s *ModerationService) invalidateThreadCache(ctx context.Context, thread *model.ThreadModel) {
+	var handle string
+	if author, err := s.moderationRepo.GetUserByID(ctx, thread.UserID); err == nil {
+		handle = author.Handle
+	} else {
+		log.Printf("moderation: failed to load author of thread %d for cache invalidation: %v", thread.ID, err)
+	}
+	if err := invalidateThreadCache(ctx, s.cacheStore, thread, handle); err != nil {
+		log.Printf("moderation: failed to invalidate cache of thread %d: %v", thread.ID, err)
+	}
+}
+
+func (s *ModerationService) reportResponse(report *model.ReportModel) dto.ReportResponse {
+	targetUserID, _ := report.TargetUserID()
+	detail, _ := report.Detail()
+	handledBy, _ := report.HandledBy()
+	response := dto.ReportResponse{
+		ID:           report.ID,
+		ReporterID:   report.ReporterID,
+		TargetType:   string(report.TargetType),
+		TargetUserID: targetUserID,
+		Reason:       string(report.Reason),
+		Detail:       detail,
+		Status:       string(report.Status),
+		HandledBy:    handledBy,
+		CreatedAt:    report.CreatedAt,
+	}
+	if threadID, ok := report.ThreadID(); ok {
+		response.ThreadID = &threadID
+	}
+	if handledAt, ok := report.HandledAt(); ok {
+		response.HandledAt = &handledAt
+	}
+	return response
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+const pollTallyTTL = 10 * time.Minute
+
+// 집계 캐시가 없을 때 올린 값만 남으면 다른 선택지가 0표로 보이므로, 캐시가 있을 때만 올림
+var pollTallyIncrScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV do
+	redis.call("HINCRBY", KEYS[1], ARGV[i], 1)
+end
+return 1
+`)
+
+type PollService struct {
+	pollRepo   *repository.PollRepository
+	redisCache *redis.Client
+}
+
+func NewPollService(repo *repository.PollRepository, rdconn *redis.Client) *PollService {
+	return &PollService{
+		pollRepo:   repo,
+		redisCache: rdconn,
+	}
+}
+
+func (s *PollService) CreatePoll(ctx context.Context, req *dto.CreatePollRequest) (*dto.PollResponse, *exception.ErrResponseCtx) {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "투표 생성")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	if thread.UserID != req.UserID {
+		return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 투표 생성 실패. 작성자만 투표를 만들 수 있습니다.", exception.ErrForbiddenRole)
+	}
+	if _, isReply := thread.ParentThread(); isReply {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 투표 생성 실패. 답글에는 투표를 만들 수 없습니다.", exception.ErrNotRootThread)
+	}
+	if req.ClosesAt != nil && !req.ClosesAt.After(time.Now()) {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 투표 생성 실패. 마감 시각은 현재 이후여야 합니다.", exception.ErrInvalidParameter)
+	}
+
+	if err := s.pollRepo.CreatePoll(ctx, req); err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 투표 생성 실패. 이미 투표가 있는 쓰레드입니다.", exception.ErrPollAlreadyExists)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 투표 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	return s.GetPoll(ctx, req.UserID, req.ThreadID)
+}
+
+func (s *PollService) GetPoll(ctx context.Context, viewerID string, threadID int) (*dto.PollResponse, *exception.ErrResponseCtx) {
+	poll, errCtx := s.getPoll(ctx, threadID, "투표 조회")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+
+	myChoices := make([]int, 0)
+	if viewerID != "" {
+		choices, err := s.pollRepo.ListChoices(ctx, threadID, viewerID)
+		if err != nil {
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 투표 조회 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+		for _, choice := range choices {
+			myChoices = append(myChoices, choice.OptionID)
+		}
+	}
+
+	response := pollResponse(poll, myChoices)
+	if !response.ResultsVisible {
+		return response, nil
+	}
+
+	tally, err := s.getTally(ctx, poll)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 투표 조회 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	applyTally(response, tally)
+	return response, nil
+}
+
+func (s *PollService) Vote(ctx context.Context, req *dto.VotePollRequest) (*dto.PollResponse, *exception.ErrResponseCtx) {
+	if _, errCtx := s.getThread(ctx, req.ThreadID, "투표"); errCtx != nil {
+		return nil, errCtx
+	}
+	poll, errCtx := s.getPoll(ctx, req.ThreadID, "투표")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	if isPollClosed(poll) {
+		return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 투표 실패. 이미 마감된 투표입니다.", exception.ErrPollClosed)
+	}
+
+	optionIDs, errCtx := validatePollOptions(poll, req.OptionIDs)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+
+	if err := s.pollRepo.Vote(ctx, req.ThreadID, req.UserID, optionIDs); err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 투표 실패. 이미 투표에 참여했습니다.", exception.ErrAlreadyVoted)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 투표 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	fields := []interface{}{"voters"}
+	for _, optionID := range optionIDs {
+		fields = append(fields, pollOptionField(optionID))
+	}
+	if err := pollTallyIncrScript.Run(ctx, s.redisCache, []string{pollTallyKey(req.ThreadID)}, fields...).Err(); err != nil {
+		// 집계 캐시를 올리지 못했다면 다음 조회 때 DB에서 다시 채우도록 지워둠
+		s.redisCache.Del(ctx, pollTallyKey(req.ThreadID))
+	}
+
+	response, errCtx := s.GetPoll(ctx, req.UserID, req.ThreadID)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	if !poll.HideResults {
+		s.publishTally(ctx, dto.EventPollVoted, response)
+	}
+	return response, nil
+}
+
+func (s *PollService) ClosePoll(ctx context.Context, req *dto.ClosePollRequest) (*dto.PollResponse, *exception.ErrResponseCtx) {
+	thread, errCtx := s.getThread(ctx, req.ThreadID, "투표 마감")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	if thread.UserID != req.UserID && req.Role != model.UserRolesAdmin {
+		return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 투표 마감 실패. 작성자 또는 관리자만 마감할 수 있습니다.", exception.ErrForbiddenRole)
+	}
+	poll, errCtx := s.getPoll(ctx, req.ThreadID, "투표 마감")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	if isPollClosed(poll) {
+		return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 투표 마감 실패. 이미 마감된 투표입니다.", exception.ErrPollClosed)
+	}
+
+	if _, err := s.pollRepo.ClosePoll(ctx, req.ThreadID, req.UserID); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 투표 마감 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	response, errCtx := s.GetPoll(ctx, req.UserID, req.ThreadID)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	// 마감되면 결과를 숨겨둔 투표도 공개되므로 항상 알림
+	s.publishTally(ctx, dto.EventPollClosed, response)
+	return response, nil
+}
+
+func (s *PollService) getThread(ctx context.Context, threadID int, action string) (*model.ThreadModel, *exception.ErrResponseCtx) {
+	thread, err := s.pollRepo.GetThreadByID(ctx, threadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 쓰레드입니다.", action), exception.ErrThreadNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+		}
+	}
+	if _, hidden := thread.HiddenAt(); hidden || thread.Status != model.ThreadStatusPublished {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 쓰레드입니다.", action), exception.ErrThreadNotFound)
+	}
+	return thread, nil
+}
+
+func (s *PollService) getPoll(ctx context.Context, threadID int, action string) (*model.PollModel, *exception.ErrResponseCtx) {
+	poll, err := s.pollRepo.GetPoll(ctx, threadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 투표가 없는 쓰레드입니다.", action), err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+		}
+	}
+	return poll, nil
+}
+
+/*
+득표 수는 투표 트랜잭션에서 DB(PollOption.votes)에 함께 반영하고, 조회는 Redis 해시(poll:{threadID}:tally)에서 함.
+캐시가 없으면 DB 값으로 다시 채우는데, 그 사이에 들어온 투표는 캐시에 반영되지 않을 수 있으므로
+TTL을 짧게 두어 주기적으로 DB 값과 맞춤.
+*/
+func (s *PollService) getTally(ctx context.Context, poll *model.PollModel) (*dto.PollTally, error) {
+	cacheKey := pollTallyKey(poll.ThreadID)
+	cached, err := s.redisCache.HGetAll(ctx, cacheKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tally := &dto.PollTally{Votes: make(map[int]int)}
+	if len(cached) > 0 {
+		for field, value := range cached {
+			amount, _ := strconv.Atoi(value)
+			if field == "voters" {
+				tally.VoterCount = amount
+			} else if optionID, err := strconv.Atoi(strings.TrimPrefix(field, "option:")); err == nil {
+				tally.Votes[optionID] = amount
+			}
+		}
+		return tally, nil
+	}
+
+	fields := map[string]interface{}{"voters": poll.VoterCount}
+	tally.VoterCount = poll.VoterCount
+	for _, option := range poll.Options() {
+		fields[pollOptionField(option.ID)] = option.Votes
+		tally.Votes[option.ID] = option.Votes
+	}
+	if _, err := s.redisCache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, cacheKey, fields)
+		pipe.Expire(ctx, cacheKey, pollTallyTTL)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tally, nil
+}
+
+func (s *PollService) publishTally(ctx context.Context, eventType dto.RealtimeEventType, poll *dto.PollResponse) {
+	publishRealtimeEvent(s.redisCache, ctx, RealtimeThreadChannel(poll.ThreadID), dto.RealtimeEvent{
+		Type:      eventType,
+		ThreadID:  poll.ThreadID,
+		Payload:   poll.Options,
+		CreatedAt: time.Now(),
+	})
+}
+
+func validatePollOptions(poll *model.PollModel, optionIDs []int) ([]int, *exception.ErrResponseCtx) {
+	validIDs := make(map[int]struct{})
+	for _, option := range poll.Options() {
+		validIDs[option.ID] = struct{}{}
+	}
+
+	uniqueIDs := make([]int, 0, len(optionIDs))
+	seen := make(map[int]struct{})
+	for _, optionID := range optionIDs {
+		if _, ok := validIDs[optionID]; !ok {
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 투표 실패. 존재하지 않는 선택지입니다.", exception.ErrInvalidPollOption)
+		}
+		if _, duplicated := seen[optionID]; !duplicated {
+			seen[optionID] = struct{}{}
+			uniqueIDs = append(uniqueIDs, optionID)
+		}
+	}
+	if !poll.MultipleChoice && len(uniqueIDs) > 1 {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 투표 실패. 하나의 선택지만 고를 수 있는 투표입니다.", exception.ErrInvalidPollOption)
+	}
+	return uniqueIDs, nil
+}
+
+func isPollClosed(poll *model.PollModel) bool {
+	if _, closed := poll.ClosedAt(); closed {
+		return true
+	}
+	closesAt, ok := poll.ClosesAt()
+	return ok && !closesAt.After(time.Now())
+}
+
+// 결과 숨김 투표는 직접 투표했거나 마감된 뒤에만 득표 수를 보여줌
+func pollResponse(poll *model.PollModel, myChoices []int) *dto.PollResponse {
+	isClosed := isPollClosed(poll)
+	response := &dto.PollResponse{
+		ThreadID:       poll.ThreadID,
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		HideResults:    poll.HideResults,
+		IsClosed:       isClosed,
+		ResultsVisible: !poll.HideResults || isClosed || len(myChoices) > 0,
+		Options:        make([]dto.PollOptionResponse, 0),
+		MyChoices:      myChoices,
+		CreatedAt:      poll.CreatedAt,
+	}
+	if closesAt, ok := poll.ClosesAt(); ok {
+		response.ClosesAt = &closesAt
+	}
+	if closedAt, ok := poll.ClosedAt(); ok {
+		response.ClosedAt = &closedAt
+	}
+	for _, option := range poll.Options() {
+		response.Options = append(response.Options, dto.PollOptionResponse{
+			ID:       option.ID,
+			Position: option.Position,
+			Text:     option.Text,
+		})
+	}
+	return response
+}
+
+func applyTally(response *dto.PollResponse, tally *dto.PollTally) {
+	voterCount := tally.VoterCount
+	response.VoterCount = &voterCount
+	for i := range response.Options {
+		votes := tally.Votes[response.Options[i].ID]
+		response.Options[i].Votes = &votes
+	}
+}
+
+func pollTallyKey(threadID int) string {
+	return fmt.Sprintf("poll:%d:tally", threadID)
+}
+
+func pollOptionField(optionID int) string {
+	return fmt.Sprintf("option:%d", optionID)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/pkg/unfurl"
+)
+
+const (
+	previewQueueSize  = 256
+	previewWorkers    = 4
+	previewMaxLinks   = 3
+	previewCacheTTL   = 24 * time.Hour
+	previewFailureTTL = time.Hour
+	previewLockTTL    = time.Minute
+)
+
+type LinkPreviewService struct {
+	unfurler   *unfurl.Unfurler
+	redisCache *redis.Client
+	queue      chan string
+}
+
+func NewLinkPreviewService(rdconn *redis.Client) *LinkPreviewService {
+	return &LinkPreviewService{
+		unfurler: unfurl.New(unfurl.Options{
+			Timeout:      time.Duration(config.Envs.UnfurlTimeoutSeconds) * time.Second,
+			MaxBytes:     config.Envs.UnfurlMaxBytes,
+			MaxRedirects: int(config.Envs.UnfurlMaxRedirects),
+			UserAgent:    "gommunity-unfurl/1.0",
+		}),
+		redisCache: rdconn,
+		queue:      make(chan string, previewQueueSize),
+	}
+}
+
+/*
+미리보기는 응답을 늦추지 않도록 캐시에 있는 것만 붙여서 내려줌.
+캐시에 없는 링크는 백그라운드에서 가져오도록 큐에 넣어두고 다음 조회부터 붙음.
+미리보기가 없어도 쓰레드 조회는 정상이어야 하므로 캐시 오류는 로그만 남김.
+*/
+func (s *LinkPreviewService) Previews(ctx context.Context, links []string) []dto.LinkPreview {
+	previews := make([]dto.LinkPreview, 0)
+	if len(links) == 0 {
+		return previews
+	}
+	if len(links) > previewMaxLinks {
+		links = links[:previewMaxLinks]
+	}
+
+	keys := make([]string, len(links))
+	for i, link := range links {
+		keys[i] = previewCacheKey(link)
+	}
+	cached, err := s.redisCache.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Printf("preview: failed to load cached previews: %v", err)
+		return previews
+	}
+
+	var missing []string
+	for i, value := range cached {
+		raw, ok := value.(string)
+		if !ok {
+			missing = append(missing, links[i])
+			continue
+		}
+		var preview dto.LinkPreview
+		if err := json.Unmarshal([]byte(raw), &preview); err != nil || preview.URL == "" {
+			continue
+		}
+		previews = append(previews, preview)
+	}
+	s.Enqueue(missing)
+	return previews
+}
+
+func (s *LinkPreviewService) Enqueue(links []string) {
+	if len(links) > previewMaxLinks {
+		links = links[:previewMaxLinks]
+	}
+	for _, link := range links {
+		select {
+		case s.queue <- link:
+		default:
+			log.Printf("preview: queue is full, dropped %s", link)
+		}
+	}
+}
+
+// 종료할 때는 가져오는 중인 요청도 취소하고, 큐에 남은 링크는 다음 조회 때 다시 들어오므로 버림
+func (s *LinkPreviewService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < previewWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case link := <-s.queue:
+					s.fetch(ctx, link)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// 여러 쓰레드에 같은 링크가 있거나 여러 서버가 동시에 요청해도 한 번만 가져오도록 잠금을 걸어둠
+func (s *LinkPreviewService) fetch(ctx context.Context, link string) {
+	fetchCtx, cancel := context.WithTimeout(ctx, previewLockTTL)
+	defer cancel()
+
+	cacheKey := previewCacheKey(link)
+	if exists, err := s.redisCache.Exists(fetchCtx, cacheKey).Result(); err != nil || exists > 0 {
+		return
+	}
+	locked, err := s.redisCache.SetNX(fetchCtx, cacheKey+":lock", 1, previewLockTTL).Result()
+	if err != nil || !locked {
+		return
+	}
+	defer s.redisCache.Del(fetchCtx, cacheKey+":lock")
+
+	// 가져오지 못한 링크도 빈 값으로 캐시해서 매 조회마다 다시 요청하지 않도록 함
+	preview, ttl := dto.LinkPreview{}, previewFailureTTL
+	result, err := s.unfurler.Unfurl(fetchCtx, link)
+	if ctx.Err() != nil {
+		// 서버 종료로 취소된 경우는 가져오지 못한 링크로 캐시하지 않음
+		return
+	}
+	if err != nil {
+		log.Printf("preview: failed to unfurl %s: %v", link, err)
+	} else {
+		preview, ttl = dto.LinkPreview{
+			Link:        link,
+			URL:         result.URL,
+			Title:       result.Title,
+			Description: result.Description,
+			ImageURL:    result.Image,
+			SiteName:    result.SiteName,
+		}, previewCacheTTL
+	}
+
+	data, err := json.Marshal(preview)
+	if err != nil {
+		return
+	}
+	if err := s.redisCache.Set(fetchCtx, cacheKey, data, ttl).Err(); err != nil {
+		log.Printf("preview: failed to cache %s: %v", link, err)
+	}
+}
+
+func previewCacheKey(link string) string {
+	hash := sha256.Sum256([]byte(link))
+	return "preview:" + hex.EncodeToString(hash[:])
+}
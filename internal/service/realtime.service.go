@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+const (
+	realtimeChannelPattern = "realtime:*"
+	realtimeBufferSize     = 32
+)
+
+func RealtimeBoardChannel() string {
+	return "realtime:board"
+}
+
+func RealtimeThreadChannel(threadID int) string {
+	return fmt.Sprintf("realtime:thread:%d", threadID)
+}
+
+func RealtimeUserChannel(userID string) string {
+	return fmt.Sprintf("realtime:user:%s", userID)
+}
+
+/*
+여러 인스턴스가 로드밸런서 뒤에 있어도 이벤트가 전달되도록 Redis Pub/Sub을 사용함.
+인스턴스마다 Redis 구독은 하나만 유지하고 (PSubscribe realtime:*),
+받은 메시지를 해당 채널을 구독 중인 로컬 클라이언트들에게 뿌려주는 구조임.
+느린 클라이언트 때문에 전체가 막히지 않도록 버퍼가 가득 차면 이벤트를 버림.
+*/
+type RealtimeService struct {
+	relationRepo repository.RelationStore
+	redisCache   *redis.Client
+	mu           sync.RWMutex
+	subscribers  map[string]map[chan []byte]struct{}
+}
+
+func NewRealtimeService(relationRepo repository.RelationStore, rdconn *redis.Client) *RealtimeService {
+	s := &RealtimeService{
+		relationRepo: relationRepo,
+		redisCache:   rdconn,
+		subscribers:  make(map[string]map[chan []byte]struct{}),
+	}
+	go s.listen(context.Background())
+	return s
+}
+
+func (s *RealtimeService) Subscribe(channels ...string) (<-chan []byte, func()) {
+	events := make(chan []byte, realtimeBufferSize)
+
+	s.mu.Lock()
+	for _, channel := range channels {
+		if _, exists := s.subscribers[channel]; !exists {
+			s.subscribers[channel] = make(map[chan []byte]struct{})
+		}
+		s.subscribers[channel][events] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, channel := range channels {
+				delete(s.subscribers[channel], events)
+				if len(s.subscribers[channel]) == 0 {
+					delete(s.subscribers, channel)
+				}
+			}
+			close(events)
+		})
+	}
+	return events, unsubscribe
+}
+
+func (s *RealtimeService) listen(ctx context.Context) {
+	pubsub := s.redisCache.PSubscribe(ctx, realtimeChannelPattern)
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		s.broadcast(msg.Channel, []byte(msg.Payload))
+	}
+	log.Printf("realtime: redis subscription closed")
+}
+
+func (s *RealtimeService) broadcast(channel string, payload []byte) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for events := range s.subscribers[channel] {
+		select {
+		case events <- payload:
+		default:
+		}
+	}
+}
+
+/*
+Pub/Sub은 모든 구독자에게 같은 이벤트를 보내므로 차단, 뮤트는 구독자별 스트림에서 걸러냄.
+게시판(피드)은 목록과 같이 차단과 뮤트한 사용자를 모두 숨기고, 쓰레드의 답글은 댓글과 같이 차단한 사용자만 숨김.
+*/
+func (s *RealtimeService) HiddenAuthors(ctx context.Context, viewerID string, includeMuted bool) (map[string]struct{}, *exception.ErrResponseCtx) {
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, viewerID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 실시간 구독 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
+	if includeMuted {
+		return hiddenUserSet(relations.Blocked, relations.Muted), nil
+	}
+	return hiddenUserSet(relations.Blocked), nil
+}
+
+// 작성자가 없는 이벤트(카운터, 삭제 등)나 읽을 수 없는 이벤트는 그대로 전달함
+func IsVisibleEvent(payload []byte, hidden map[string]struct{}) bool {
+	if len(hidden) == 0 {
+		return true
+	}
+	var event struct {
+		AuthorID string `json:"authorID"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.AuthorID == "" {
+		return true
+	}
+	_, isHidden := hidden[event.AuthorID]
+	return !isHidden
+}
+
+// 실시간 이벤트 발행 실패는 요청 자체를 실패시키지 않고 로그만 남김
+func publishRealtimeEvent(rdconn *redis.Client, ctx context.Context, channel string, event dto.RealtimeEvent) {
+	if err := utils.Publish(rdconn, ctx, channel, event); err != nil {
+		log.Printf("realtime: failed to publish %s to %s: %v", event.Type, channel, err)
+	}
+}
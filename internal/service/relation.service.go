@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+type RelationService struct {
+	relationRepo repository.RelationStore
+	redisCache   *redis.Client
+}
+
+func NewRelationService(repo repository.RelationStore, rdconn *redis.Client) *RelationService {
+	return &RelationService{
+		relationRepo: repo,
+		redisCache:   rdconn,
+	}
+}
+
+func (s *RelationService) Block(ctx context.Context, req *dto.RelationRequest) *exception.ErrResponseCtx {
+	return s.createRelation(ctx, req, model.RelationTypeBlock, "유저 차단")
+}
+
+func (s *RelationService) Unblock(ctx context.Context, req *dto.RelationRequest) *exception.ErrResponseCtx {
+	return s.deleteRelation(ctx, req, model.RelationTypeBlock, "유저 차단 해제")
+}
+
+func (s *RelationService) Mute(ctx context.Context, req *dto.RelationRequest) *exception.ErrResponseCtx {
+	return s.createRelation(ctx, req, model.RelationTypeMute, "유저 뮤트")
+}
+
+func (s *RelationService) Unmute(ctx context.Context, req *dto.RelationRequest) *exception.ErrResponseCtx {
+	return s.deleteRelation(ctx, req, model.RelationTypeMute, "유저 뮤트 해제")
+}
+
+func (s *RelationService) ListRelations(ctx context.Context, userID string, relationType model.RelationType) ([]dto.RelationUserResponse, *exception.ErrResponseCtx) {
+	relations, err := s.relationRepo.ListRelations(ctx, userID, relationType)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 유저 목록 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	listUser := make([]dto.RelationUserResponse, 0, len(relations))
+	for _, relation := range relations {
+		target := relation.Target()
+		listUser = append(listUser, dto.RelationUserResponse{
+			UserID:    target.ID,
+			Handle:    target.Handle,
+			Name:      target.Name,
+			CreatedAt: relation.CreatedAt,
+		})
+	}
+	return listUser, nil
+}
+
+func (s *RelationService) createRelation(ctx context.Context, req *dto.RelationRequest, relationType model.RelationType, action string) *exception.ErrResponseCtx {
+	target, errCtx := s.getTarget(ctx, req, action)
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.relationRepo.CreateRelation(ctx, req.UserID, target.ID, relationType); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.redisCache.Del(ctx, viewerRelationsCacheKey(req.UserID))
+	return nil
+}
+
+func (s *RelationService) deleteRelation(ctx context.Context, req *dto.RelationRequest, relationType model.RelationType, action string) *exception.ErrResponseCtx {
+	target, errCtx := s.getTarget(ctx, req, action)
+	if errCtx != nil {
+		return errCtx
+	}
+
+	if err := s.relationRepo.DeleteRelation(ctx, req.UserID, target.ID, relationType); err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.redisCache.Del(ctx, viewerRelationsCacheKey(req.UserID))
+	return nil
+}
+
+func (s *RelationService) getTarget(ctx context.Context, req *dto.RelationRequest, action string) (*model.UsersModel, *exception.ErrResponseCtx) {
+	target, err := s.relationRepo.GetUserByHandle(ctx, req.Handle)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ "+action+" 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ "+action+" 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	if target.ID == req.UserID {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ "+action+" 실패. 자기 자신은 대상으로 지정할 수 없습니다.", exception.ErrInvalidParameter)
+	}
+	return target, nil
+}
+
+func viewerRelationsCacheKey(userID string) string {
+	return fmt.Sprintf("user:%s:relations", userID)
+}
+
+/*
+쓰레드 목록 캐시는 모든 유저가 공유하기 때문에 차단/뮤트 필터링을 캐시 이전에 적용하면 캐시가 오염됨.
+그래서 공유 캐시에서 꺼낸 뒤에 조회하는 유저의 관계 정보로 한 번 더 걸러내는 방식으로 처리함.
+유저별 관계 정보도 매 요청마다 DB를 조회하지 않도록 별도로 캐시해둠. (ex. user:{id}:relations)
+*/
+func loadViewerRelations(ctx context.Context, rdconn *redis.Client, repo repository.RelationStore, viewerID string) (*dto.ViewerRelations, error) {
+	if viewerID == "" {
+		return &dto.ViewerRelations{}, nil
+	}
+
+	var relations *dto.ViewerRelations
+	if err := utils.GetCache(rdconn, ctx, viewerRelationsCacheKey(viewerID), &relations); err != nil {
+		return nil, err
+	}
+	if relations != nil {
+		return relations, nil
+	}
+
+	listRelation, err := repo.ListRelationsByOwner(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	relations = &dto.ViewerRelations{Blocked: make([]string, 0), Muted: make([]string, 0)}
+	for _, relation := range listRelation {
+		switch relation.Type {
+		case model.RelationTypeBlock:
+			relations.Blocked = append(relations.Blocked, relation.TargetID)
+		case model.RelationTypeMute:
+			relations.Muted = append(relations.Muted, relation.TargetID)
+		}
+	}
+
+	if err := utils.SetCache(rdconn, ctx, viewerRelationsCacheKey(viewerID), relations, 10*time.Minute); err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+func hiddenUserSet(userIDs ...[]string) map[string]struct{} {
+	hidden := make(map[string]struct{})
+	for _, ids := range userIDs {
+		for _, id := range ids {
+			hidden[id] = struct{}{}
+		}
+	}
+	return hidden
+}
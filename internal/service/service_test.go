@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository/memory"
+	"github.com/kitae0522/gommunity/pkg/cache"
+)
+
+const testPassword = "password1234"
+
+var testMeta = dto.RequestMeta{IP: "203.0.113.10", UserAgent: "gommunity-test"}
+
+/*
+서비스 테스트는 DB 대신 메모리 저장소를, 실제 Redis 대신 miniredis를 사용함.
+세션 차단, 실시간 이벤트처럼 Redis를 직접 쓰는 부분도 그대로 동작하도록 cacheStore도 같은 miniredis를 바라보게 함.
+*/
+type testEnv struct {
+	store      *memory.Store
+	redis      *miniredis.Miniredis
+	rdconn     *redis.Client
+	cacheStore cache.Cache
+	audit      *AuditService
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdconn := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdconn.Close() })
+
+	store := memory.NewStore()
+	audit := NewAuditService(store)
+	ctx, cancel := context.WithCancel(context.Background())
+	wait := StartWorkers(ctx, audit)
+	t.Cleanup(func() {
+		cancel()
+		wait()
+	})
+
+	return &testEnv{
+		store:      store,
+		redis:      mr,
+		rdconn:     rdconn,
+		cacheStore: cache.NewRedisCache(rdconn),
+		audit:      audit,
+	}
+}
+
+func (e *testEnv) authService() *AuthService {
+	return NewAuthService(e.store, e.audit, e.cacheStore, e.rdconn)
+}
+
+func (e *testEnv) threadService() *ThreadService {
+	return NewThreadService(e.store, e.store, e.store, e.store, e.store, e.audit, NewLinkPreviewService(e.rdconn), e.cacheStore, e.rdconn)
+}
+
+func (e *testEnv) registerUser(t *testing.T, handle string) *model.UsersModel {
+	t.Helper()
+
+	ctx := context.Background()
+	req := dto.RegisterRequest{
+		Handle:          handle,
+		Name:            handle,
+		Password:        testPassword,
+		PasswordConfirm: testPassword,
+		Email:           handle + "@example.com",
+	}
+	if errCtx := e.authService().Register(ctx, testMeta, req); errCtx != nil {
+		t.Fatalf("register %s: %s %v", handle, errCtx.Message, errCtx.Detail)
+	}
+	user, err := e.store.GetUserByHandle(ctx, handle)
+	if err != nil {
+		t.Fatalf("load registered user %s: %v", handle, err)
+	}
+	return user
+}
+
+// 감사 로그는 비동기로 저장되므로 기록될 때까지 잠시 기다림
+func (e *testEnv) waitAuditLog(t *testing.T, filter dto.AuditLogFilter) []model.AuditLogModel {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		logs, err := e.store.ListAuditLogs(context.Background(), filter, 0, 0)
+		if err != nil {
+			t.Fatalf("list audit logs: %v", err)
+		}
+		if len(logs) > 0 || time.Now().After(deadline) {
+			return logs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func auditEvent(event model.AuditEvent) *model.AuditEvent {
+	return &event
+}
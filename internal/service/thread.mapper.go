@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/exception"
+)
+
+/*
+쓰레드 모델을 응답으로 바꾸는 곳은 이 파일 하나로 모아서 목록, 상세, 작성, 발행, 캐시, 실시간 이벤트가 모두 같은 형식을 사용함.
+관계 필드와 숨김 시각, 연결된 쓰레드 ID 같은 내부 컬럼은 응답에 나가지 않으며,
+응답 형식을 바꾸면 캐시된 이전 형식을 읽지 않도록 threadPayloadVersion을 올려야 함.
+*/
+func threadResponse(thread *model.ThreadModel, author dto.AuthorResponse, rendered dto.RenderedContent) dto.ThreadResponse {
+	imgURL, _ := thread.ImgURL()
+	_, isLocked := thread.LockedAt()
+	response := dto.ThreadResponse{
+		ID:              thread.ID,
+		Author:          author,
+		Status:          string(thread.Status),
+		Title:           thread.Title,
+		Content:         thread.Content,
+		ImgURL:          imgURL,
+		Attachments:     attachmentResponses(thread.Attachments()),
+		Views:           thread.Views,
+		RawViews:        thread.RawViews,
+		Likes:           thread.Likes,
+		Dislikes:        thread.Dislikes,
+		Bookmarks:       thread.Bookmarks,
+		IsLocked:        isLocked,
+		CreatedAt:       thread.CreatedAt,
+		UpdatedAt:       thread.UpdatedAt,
+		RenderedContent: rendered,
+	}
+	if pinnedUntil, ok := thread.PinnedUntil(); ok && pinnedUntil.After(time.Now()) {
+		response.PinnedUntil = &pinnedUntil
+	}
+	return response
+}
+
+func commentResponse(comment *model.ThreadModel, author dto.AuthorResponse, rendered dto.RenderedContent) dto.CommentResponse {
+	imgURL, _ := comment.ImgURL()
+	return dto.CommentResponse{
+		ID:              comment.ID,
+		Author:          author,
+		Title:           comment.Title,
+		Content:         comment.Content,
+		ImgURL:          imgURL,
+		Attachments:     attachmentResponses(comment.Attachments()),
+		Likes:           comment.Likes,
+		Dislikes:        comment.Dislikes,
+		CreatedAt:       comment.CreatedAt,
+		UpdatedAt:       comment.UpdatedAt,
+		RenderedContent: rendered,
+	}
+}
+
+// 작성자는 로더로 한 번에 조회하고, 본문은 렌더링 캐시를 거쳐서 채움
+func mapThreads[T any](ctx context.Context, s *ThreadService, threads []model.ThreadModel, mapper func(*model.ThreadModel, dto.AuthorResponse, dto.RenderedContent) T) ([]T, error) {
+	authors, err := threadAuthors(ctx, s.userRepo, threads)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]T, 0, len(threads))
+	for i := range threads {
+		rendered, errCtx := s.RenderContent(ctx, threads[i].Content)
+		if errCtx != nil {
+			return nil, fmt.Errorf("%s %v", errCtx.Message, errCtx.Detail)
+		}
+		responses = append(responses, mapper(&threads[i], authors[threads[i].UserID], *rendered))
+	}
+	return responses, nil
+}
+
+/*
+단건 조회에서는 첨부 파일을 함께 가져오지 않으므로 첨부 캐시에서 채움.
+첨부는 쓰레드가 만들어진 뒤 바뀌지 않으므로 작성, 발행 직후에도 같은 캐시를 사용해도 됨.
+*/
+func (s *ThreadService) threadDetail(ctx context.Context, thread *model.ThreadModel, action string) (*dto.ThreadResponse, *exception.ErrResponseCtx) {
+	responses, err := mapThreads(ctx, s, []model.ThreadModel{*thread}, threadResponse)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. 응답을 만들지 못했습니다.", action), err)
+	}
+	response := responses[0]
+
+	attachments, errCtx := s.ListAttachments(ctx, thread.ID)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	response.Attachments = attachments
+	return &response, nil
+}
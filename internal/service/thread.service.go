@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -12,128 +16,297 @@ import (
 	"github.com/kitae0522/gommunity/internal/dto"
 	"github.com/kitae0522/gommunity/internal/model"
 	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/cache"
 	"github.com/kitae0522/gommunity/pkg/exception"
-	"github.com/kitae0522/gommunity/pkg/utils"
+	"github.com/kitae0522/gommunity/pkg/markdown"
+)
+
+const (
+	draftSchedulerInterval = 30 * time.Second
+	draftSchedulerBatch    = 50
+	defaultThreadPageSize  = 10
 )
 
 type ThreadService struct {
-	threadRepo *repository.ThreadRepository
-	redisCache *redis.Client
-	txnsItr    []model.PrismaTransaction
+	threadRepo     repository.ThreadStore
+	relationRepo   repository.RelationStore
+	userRepo       repository.UserLookup
+	uploadRepo     repository.UploadLookup
+	bookmarkRepo   repository.BookmarkLookup
+	auditService   *AuditService
+	previewService *LinkPreviewService
+	renderer       *markdown.Renderer
+	cacheStore     cache.Cache
+	cacheLoader    *cache.Loader
+	redisCache     *redis.Client
+	txnsItr        []dto.InteractionDeltaEntity
 }
 
-func NewThreadService(repo *repository.ThreadRepository, rdconn *redis.Client) *ThreadService {
+func NewThreadService(repo repository.ThreadStore, relationRepo repository.RelationStore, userRepo repository.UserLookup, uploadRepo repository.UploadLookup, bookmarkRepo repository.BookmarkLookup, auditService *AuditService, previewService *LinkPreviewService, cacheStore cache.Cache, rdconn *redis.Client) *ThreadService {
 	return &ThreadService{
-		threadRepo: repo,
-		redisCache: rdconn,
+		threadRepo:     repo,
+		relationRepo:   relationRepo,
+		userRepo:       userRepo,
+		uploadRepo:     uploadRepo,
+		bookmarkRepo:   bookmarkRepo,
+		auditService:   auditService,
+		previewService: previewService,
+		renderer:       markdown.NewRenderer(),
+		cacheStore:     cacheStore,
+		cacheLoader:    cache.NewLoader(cacheStore),
+		redisCache:     rdconn,
 	}
 }
 
-func (s *ThreadService) CreateThread(ctx context.Context, req *dto.CreateThreadRequest) (*model.ThreadModel, *exception.ErrResponseCtx) {
-	thread, err := s.threadRepo.CreateThread(ctx, req)
+func (s *ThreadService) CreateThread(ctx context.Context, req *dto.CreateThreadRequest) (*dto.ThreadResponse, *exception.ErrResponseCtx) {
+	var parent *model.ThreadModel
+	if req.ParentThread != nil {
+		replyTarget, errCtx := s.getReplyTarget(ctx, req.UserID, *req.ParentThread)
+		if errCtx != nil {
+			return nil, errCtx
+		}
+		parent = replyTarget
+	}
+
+	status := model.ThreadStatusPublished
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(time.Now()) {
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 쓰레드 생성 실패. 예약 시각은 현재 이후여야 합니다.", exception.ErrInvalidParameter)
+		}
+		status = model.ThreadStatusScheduled
+	} else if req.IsDraft {
+		status = model.ThreadStatusDraft
+	}
+	if status != model.ThreadStatusPublished && req.ParentThread != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 쓰레드 생성 실패. 답글은 임시 저장하거나 예약할 수 없습니다.", exception.ErrNotRootThread)
+	}
+
+	if errCtx := s.resolveAttachments(ctx, req); errCtx != nil {
+		return nil, errCtx
+	}
+
+	thread, err := s.threadRepo.CreateThread(ctx, req, status)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 생성 실패. 존재하지 않는 사용자입니다.", err)
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 생성 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
 		default:
 			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Repository에서 문제가 발생했습니다.", err)
 		}
 	}
 
-	txns := make([]model.PrismaTransaction, 0)
-	if req.ParentThread != nil {
-		txns = append(txns, s.threadRepo.LinkParentThread(ctx, thread.ID, *req.ParentThread))
-	} else if req.NextThread != nil {
-		txns = append(txns, s.threadRepo.LinkNextThread(ctx, thread.ID, *req.NextThread))
-	} else if req.PrevThread != nil {
-		txns = append(txns, s.threadRepo.LinkPrevThread(ctx, thread.ID, *req.NextThread))
+	if err := s.threadRepo.LinkThread(ctx, thread.ID, req); err != nil {
+		if _, uniqueErr := model.IsErrUniqueConstraint(err); uniqueErr {
+			return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 쓰레드 생성 실패. 이미 다른 쓰레드에 첨부된 파일입니다.", exception.ErrAlreadyAttached)
+		}
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Reposioty에서 문제가 발생했습니다.", err)
 	}
 
-	if err := s.threadRepo.RunTransaction(ctx, txns); err != nil {
-		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Reposioty에서 문제가 발생했습니다.", err)
+	// 임시 저장 글은 목록, 캐시, 알림 어디에도 노출되지 않도록 발행될 때 처리함
+	if status == model.ThreadStatusPublished {
+		s.onThreadPublished(ctx, thread, parent)
+	}
+
+	return s.threadDetail(ctx, thread, "쓰레드 생성")
+}
+
+func (s *ThreadService) ListThread(ctx context.Context, viewerID string, pageNumber, pageSize int) ([]dto.ThreadResponse, *exception.ErrResponseCtx) {
+	threadList, errCtx := s.listThread(ctx, pageNumber, pageSize)
+	if errCtx != nil {
+		return nil, errCtx
 	}
 
-	utils.ClearCacheByPattern(s.redisCache, ctx, "thread:list:*")
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, viewerID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
 
-	return thread, nil
+	hidden := hiddenUserSet(relations.Blocked, relations.Muted)
+	filteredList := make([]dto.ThreadResponse, 0, len(threadList))
+	for _, thread := range threadList {
+		if _, isHidden := hidden[thread.Author.ID]; !isHidden {
+			// 미리보기는 비동기로 채워지므로 목록 캐시에 넣지 않고 조회할 때마다 붙임
+			thread.Previews = s.previewService.Previews(ctx, thread.Links)
+			filteredList = append(filteredList, thread)
+		}
+	}
+
+	bookmarked, errCtx := s.bookmarkedThreadSet(ctx, viewerID, filteredList)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	for i := range filteredList {
+		_, filteredList[i].Bookmarked = bookmarked[filteredList[i].ID]
+	}
+	return filteredList, nil
 }
 
-func (s *ThreadService) ListThread(ctx context.Context, pageNumber, pageSize int) ([]dto.ThreadResponse, *exception.ErrResponseCtx) {
-	threadList, err := s.listThreadFromCache(ctx, pageNumber, pageSize)
+// 북마크 여부는 조회하는 유저마다 다르므로 공유 캐시에 넣지 않고 페이지 단위로 한 번에 조회함
+func (s *ThreadService) bookmarkedThreadSet(ctx context.Context, viewerID string, threadList []dto.ThreadResponse) (map[int]struct{}, *exception.ErrResponseCtx) {
+	bookmarked := make(map[int]struct{})
+	if viewerID == "" || len(threadList) == 0 {
+		return bookmarked, nil
+	}
+
+	threadIDs := make([]int, 0, len(threadList))
+	for _, thread := range threadList {
+		threadIDs = append(threadIDs, thread.ID)
+	}
+	bookmarkedIDs, err := s.bookmarkRepo.ListBookmarkedThreadIDs(ctx, viewerID, threadIDs)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 북마크 정보를 불러오지 못했습니다.", err)
+	}
+	for _, threadID := range bookmarkedIDs {
+		bookmarked[threadID] = struct{}{}
+	}
+	return bookmarked, nil
+}
+
+// 모든 유저가 공유하는 캐시를 사용하므로 조회하는 유저에 따라 달라지는 처리는 여기서 하지 않음
+func (s *ThreadService) listThread(ctx context.Context, pageNumber, pageSize int) ([]dto.ThreadResponse, *exception.ErrResponseCtx) {
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultThreadPageSize
+	}
+
+	// 조회 도중 무효화되면 이전 세대 키에 저장되어 다시 읽히지 않도록 세대 번호는 처음에 한 번만 읽음
+	generation, err := s.cacheStore.Generation(ctx, threadListCacheTag)
 	if err != nil {
 		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
 	}
-	if threadList != nil {
-		return threadList, nil
+
+	var threadList []dto.ThreadResponse
+	if err := s.cacheLoader.Load(ctx, threadListCacheKey(generation, pageNumber, pageSize), cacheTTL(config.Envs.CacheThreadListTTL), &threadList, func(ctx context.Context) (interface{}, time.Duration, error) {
+		return s.loadThreadList(ctx, pageNumber, pageSize)
+	}); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 목록을 불러오지 못했습니다.", err)
 	}
+	return threadList, nil
+}
 
-	listThreadFromRepo, err := s.threadRepo.ListThread(ctx, pageNumber, pageSize)
+/*
+캐시가 없을 때 하나의 요청에서만 실행됨.
+고정 쓰레드도 페이지 크기에 포함되도록, 고정 쓰레드 뒤에 나머지 쓰레드가 이어지는 하나의 목록으로 보고 페이지를 나눔.
+고정 쓰레드 수에 따라 모든 페이지의 범위가 달라지므로, 고정 쓰레드가 있으면 페이지와 관계없이 가장 먼저 고정이 끝나는 시점을 캐시 만료 시점으로 돌려줌.
+*/
+func (s *ThreadService) loadThreadList(ctx context.Context, pageNumber, pageSize int) ([]dto.ThreadResponse, time.Duration, error) {
+	pinnedThread, err := s.threadRepo.ListPinnedThread(ctx)
 	if err != nil {
-		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
+		return nil, 0, err
 	}
 
-	var listThread []dto.ThreadResponse
-	for _, thread := range listThreadFromRepo {
-		imgUrl, _ := thread.ImgURL()
-		threadDTO := dto.ThreadResponse{
-			ID:        thread.ID,
-			UserID:    thread.UserID,
-			Handle:    "",
-			Title:     thread.Title,
-			Content:   thread.Content,
-			ImgURL:    imgUrl,
-			Views:     thread.Views,
-			Likes:     thread.Likes,
-			Dislikes:  thread.Dislikes,
-			CreatedAt: thread.CreatedAt,
-			UpdatedAt: thread.UpdatedAt,
-		}
-		user, err := s.threadRepo.GetUserByID(ctx, threadDTO.UserID)
+	offset := (pageNumber - 1) * pageSize
+	listThreadFromRepo := make([]model.ThreadModel, 0, pageSize)
+	if offset < len(pinnedThread) {
+		listThreadFromRepo = append(listThreadFromRepo, pinnedThread[offset:min(offset+pageSize, len(pinnedThread))]...)
+	}
+	if remaining := pageSize - len(listThreadFromRepo); remaining > 0 {
+		unpinnedThread, err := s.threadRepo.ListThread(ctx, max(offset-len(pinnedThread), 0), remaining)
 		if err != nil {
-			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
+			return nil, 0, err
 		}
-		threadDTO.Handle = user.Handle
-		listThread = append(listThread, threadDTO)
+		listThreadFromRepo = append(listThreadFromRepo, unpinnedThread...)
 	}
 
-	if err := s.setListThreadToCache(ctx, pageNumber, pageSize, listThread, 5*time.Minute); err != nil {
-		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시에 저장하지 못했습니다.", err)
+	listThread, err := mapThreads(ctx, s, listThreadFromRepo, threadResponse)
+	if err != nil {
+		return nil, 0, err
 	}
+	return listThread, pinExpiryTTL(pinnedThread), nil
+}
 
-	return listThread, nil
+// 설정된 목록 캐시 시간보다 먼저 고정이 끝나는 쓰레드가 있으면 그 시점까지만 캐시함
+func pinExpiryTTL(pinnedThread []model.ThreadModel) time.Duration {
+	var ttl time.Duration
+	for _, thread := range pinnedThread {
+		if pinnedUntil, ok := thread.PinnedUntil(); ok && (ttl == 0 || time.Until(pinnedUntil) < ttl) {
+			ttl = time.Until(pinnedUntil)
+		}
+	}
+	if listTTL := time.Duration(config.Envs.CacheThreadListTTL) * time.Second; ttl > listTTL {
+		return listTTL
+	}
+	return ttl
 }
 
-func (s *ThreadService) ListThreadByHandle(ctx context.Context, handle string) ([]model.ThreadModel, *exception.ErrResponseCtx) {
-	threadList, err := s.listThreadByHandleFromCache(ctx, handle)
+func (s *ThreadService) ListThreadByHandle(ctx context.Context, viewerID, handle string) ([]dto.ThreadResponse, *exception.ErrResponseCtx) {
+	threadList, errCtx := s.listThreadByHandle(ctx, handle)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, viewerID)
 	if err != nil {
-		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 차단 정보를 불러오지 못했습니다.", err)
 	}
-	if len(threadList) > 0 {
+	if len(relations.Blocked) == 0 {
 		return threadList, nil
 	}
 
-	threadList, err = s.threadRepo.ListThreadByHandle(ctx, handle)
+	// 유저 페이지를 직접 방문한 경우이므로 뮤트는 무시하고, 차단한 유저의 쓰레드만 숨김
+	author, err := s.relationRepo.GetUserByHandle(ctx, handle)
 	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if _, isBlocked := hiddenUserSet(relations.Blocked)[author.ID]; isBlocked {
+		return make([]dto.ThreadResponse, 0), nil
+	}
+	return threadList, nil
+}
+
+func (s *ThreadService) listThreadByHandle(ctx context.Context, handle string) ([]dto.ThreadResponse, *exception.ErrResponseCtx) {
+	var threadList []dto.ThreadResponse
+	if err := s.cacheLoader.Load(ctx, threadListByHandleCacheKey(handle), cacheTTL(config.Envs.CacheThreadHandleTTL), &threadList, func(ctx context.Context) (interface{}, time.Duration, error) {
+		threads, err := s.threadRepo.ListThreadByHandle(ctx, handle)
+		if err != nil {
+			return nil, 0, err
+		}
+		// 모두 같은 작성자이므로 로더가 한 번만 조회함
+		responses, err := mapThreads(ctx, s, threads, threadResponse)
+		return responses, 0, err
+	}); err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 사용자입니다.", err)
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
 		default:
-			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 목록을 불러오지 못했습니다.", err)
 		}
 	}
+	return threadList, nil
+}
 
-	if err := s.setListThreadByHandleToCache(ctx, handle, threadList, 5*time.Minute); err != nil {
-		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시에 저장하지 못했습니다.", err)
+func (s *ThreadService) GetThreadByID(ctx context.Context, meta dto.RequestMeta, viewerID string, threadID int) (*dto.ThreadResponse, *exception.ErrResponseCtx) {
+	thread, errCtx := s.getThreadByID(ctx, threadID)
+	if errCtx != nil {
+		return nil, errCtx
 	}
 
-	return threadList, nil
-}
+	// 차단한 사용자의 글은 목록과 같이 상세 조회에서도 없는 쓰레드로 취급함
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, viewerID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
+	if _, isBlocked := hiddenUserSet(relations.Blocked)[thread.Author.ID]; isBlocked {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
 
-func (s *ThreadService) GetThreadByID(ctx context.Context, threadID int) (*model.ThreadModel, *exception.ErrResponseCtx) {
-	if err := s.IncrementViews(ctx, threadID); err != nil {
+	if err := s.RecordView(ctx, meta, viewerID, thread); err != nil {
 		return nil, err
 	}
 
+	// 목록과 같이 미리보기와 북마크 여부는 상세 캐시에 넣지 않고 조회할 때마다 붙임
+	thread.Previews = s.previewService.Previews(ctx, thread.Links)
+	bookmarked, errCtx := s.bookmarkedThreadSet(ctx, viewerID, []dto.ThreadResponse{*thread})
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	_, thread.Bookmarked = bookmarked[thread.ID]
+	return thread, nil
+}
+
+func (s *ThreadService) getThreadByID(ctx context.Context, threadID int) (*dto.ThreadResponse, *exception.ErrResponseCtx) {
 	thread, errs := s.getThreadFromCache(ctx, threadID)
 	if len(errs) > 0 {
 		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시하는 과정에서 문제가 발생했습니다.", errs)
@@ -142,42 +315,240 @@ func (s *ThreadService) GetThreadByID(ctx context.Context, threadID int) (*model
 		return thread, nil
 	}
 
-	thread, err := s.threadRepo.GetThreadByID(ctx, threadID)
+	threadFromRepo, err := s.threadRepo.GetThreadByID(ctx, threadID)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", err)
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
 		default:
 			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
 		}
 	}
 
-	if err := s.setThreadToCache(ctx, thread, 5*time.Minute); err != nil {
+	if _, hidden := threadFromRepo.HiddenAt(); hidden || threadFromRepo.Status != model.ThreadStatusPublished {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
+
+	thread, errCtx := s.threadDetail(ctx, threadFromRepo, "쓰레드 조회")
+	if errCtx != nil {
+		return nil, errCtx
+	}
+
+	if err := s.setThreadToCache(ctx, thread, jitteredTTL(config.Envs.CacheThreadTTL)); err != nil {
 		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 캐시에 저장하지 못했습니다.", err)
 	}
 
 	return thread, nil
 }
 
-func (s *ThreadService) CommentsByID(ctx context.Context, threadID int) ([]model.ThreadModel, *exception.ErrResponseCtx) {
+func (s *ThreadService) ListAttachments(ctx context.Context, threadID int) ([]dto.AttachmentResponse, *exception.ErrResponseCtx) {
+	// 첨부는 쓰레드 생성 이후 바뀌지 않으므로 쓰레드 삭제 시점까지 캐시해도 됨
+	cacheKey := threadAttachmentsCacheKey(threadID)
+	var attachments []dto.AttachmentResponse
+	if err := cache.GetJSON(ctx, s.cacheStore, cacheKey, &attachments); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 첨부 파일 조회 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	if attachments != nil {
+		return attachments, nil
+	}
+
+	attachmentList, err := s.threadRepo.ListAttachments(ctx, threadID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 첨부 파일 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	attachments = attachmentResponses(attachmentList)
+	if err := cache.SetJSON(ctx, s.cacheStore, cacheKey, attachments, jitteredTTL(config.Envs.CacheAttachmentTTL)); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 첨부 파일 조회 실패. 캐시에 저장하지 못했습니다.", err)
+	}
+	return attachments, nil
+}
+
+// 같은 본문이면 렌더링 결과도 같으므로 본문 해시와 렌더러 버전을 키로 캐시함
+func (s *ThreadService) RenderContent(ctx context.Context, content string) (*dto.RenderedContent, *exception.ErrResponseCtx) {
+	cacheKey := "thread:content:" + markdown.CacheKey(content)
+
+	var rendered *dto.RenderedContent
+	if err := cache.GetJSON(ctx, s.cacheStore, cacheKey, &rendered); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 본문 렌더링 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	if rendered != nil {
+		return rendered, nil
+	}
+
+	result, err := s.renderer.Render(content)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 본문 렌더링 실패. Markdown을 변환하지 못했습니다.", err)
+	}
+
+	rendered = &dto.RenderedContent{
+		ContentHTML: result.HTML,
+		Mentions:    result.Mentions,
+		Hashtags:    result.Hashtags,
+		Links:       result.Links,
+	}
+	if err := cache.SetJSON(ctx, s.cacheStore, cacheKey, rendered, jitteredTTL(config.Envs.CacheContentTTL)); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 본문 렌더링 실패. 캐시에 저장하지 못했습니다.", err)
+	}
+	return rendered, nil
+}
+
+func (s *ThreadService) ListDrafts(ctx context.Context, userID string) ([]dto.DraftResponse, *exception.ErrResponseCtx) {
+	drafts, err := s.threadRepo.ListDrafts(ctx, userID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 임시 저장 글 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+
+	draftList := make([]dto.DraftResponse, 0, len(drafts))
+	for i := range drafts {
+		draftList = append(draftList, draftResponse(&drafts[i]))
+	}
+	return draftList, nil
+}
+
+// 자동 저장은 자주 호출되므로 보낸 필드만 덮어씀
+func (s *ThreadService) SaveDraft(ctx context.Context, req *dto.SaveDraftRequest) (*dto.DraftResponse, *exception.ErrResponseCtx) {
+	if _, errCtx := s.getDraft(ctx, req.UserID, req.ThreadID, "임시 저장"); errCtx != nil {
+		return nil, errCtx
+	}
+	return s.updateDraft(ctx, req.UserID, req.ThreadID, "임시 저장", dto.DraftUpdateEntity{
+		Title:   req.Title,
+		Content: req.Content,
+	})
+}
+
+func (s *ThreadService) ScheduleDraft(ctx context.Context, req *dto.ScheduleDraftRequest) (*dto.DraftResponse, *exception.ErrResponseCtx) {
+	if !req.PublishAt.After(time.Now()) {
+		return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 발행 예약 실패. 예약 시각은 현재 이후여야 합니다.", exception.ErrInvalidParameter)
+	}
+	if _, errCtx := s.getDraft(ctx, req.UserID, req.ThreadID, "발행 예약"); errCtx != nil {
+		return nil, errCtx
+	}
+	status := model.ThreadStatusScheduled
+	return s.updateDraft(ctx, req.UserID, req.ThreadID, "발행 예약", dto.DraftUpdateEntity{
+		Status:    &status,
+		PublishAt: &req.PublishAt,
+	})
+}
+
+func (s *ThreadService) UnscheduleDraft(ctx context.Context, req *dto.DraftRequest) (*dto.DraftResponse, *exception.ErrResponseCtx) {
+	if _, errCtx := s.getDraft(ctx, req.UserID, req.ThreadID, "발행 예약 취소"); errCtx != nil {
+		return nil, errCtx
+	}
+	status := model.ThreadStatusDraft
+	return s.updateDraft(ctx, req.UserID, req.ThreadID, "발행 예약 취소", dto.DraftUpdateEntity{
+		Status: &status,
+	})
+}
+
+func (s *ThreadService) PublishDraft(ctx context.Context, req *dto.DraftRequest) (*dto.ThreadResponse, *exception.ErrResponseCtx) {
+	if _, errCtx := s.getDraft(ctx, req.UserID, req.ThreadID, "쓰레드 발행"); errCtx != nil {
+		return nil, errCtx
+	}
+
+	published, err := s.threadRepo.PublishDraft(ctx, req.ThreadID, model.ThreadStatusDraft, model.ThreadStatusScheduled)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 발행 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if !published {
+		return nil, exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 쓰레드 발행 실패. 이미 발행된 쓰레드입니다.", exception.ErrNotDraft)
+	}
+
+	thread, err := s.threadRepo.GetThreadByID(ctx, req.ThreadID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 발행 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	s.onThreadPublished(ctx, thread, nil)
+	return s.threadDetail(ctx, thread, "쓰레드 발행")
+}
+
+// 예약 시각이 지난 글을 발행함. 발행 여부는 DB의 상태 조건으로 판단하므로 여러 인스턴스에서 동시에 돌아도 한 번만 발행됨
+func (s *ThreadService) PublishDueDrafts(ctx context.Context) (int, error) {
+	dueDrafts, err := s.threadRepo.ListDueDrafts(ctx, time.Now(), draftSchedulerBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	publishedCount := 0
+	for _, draft := range dueDrafts {
+		published, err := s.threadRepo.PublishDraft(ctx, draft.ID, model.ThreadStatusScheduled)
+		if err != nil {
+			log.Printf("thread: failed to publish scheduled thread %d: %v", draft.ID, err)
+			continue
+		}
+		if !published {
+			continue
+		}
+
+		thread, err := s.threadRepo.GetThreadByID(ctx, draft.ID)
+		if err != nil {
+			log.Printf("thread: failed to load published thread %d: %v", draft.ID, err)
+			continue
+		}
+		s.onThreadPublished(ctx, thread, nil)
+		publishedCount++
+	}
+	return publishedCount, nil
+}
+
+// 예약 발행 시각이 지난 임시 저장 글을 주기적으로 발행함
+func (s *ThreadService) Run(ctx context.Context) {
+	ticker := time.NewTicker(draftSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		published, err := s.PublishDueDrafts(ctx)
+		if err != nil {
+			log.Printf("thread: draft scheduler failed: %v", err)
+		}
+		if published > 0 {
+			log.Printf("thread: published %d scheduled threads", published)
+		}
+	}
+}
+
+func (s *ThreadService) CommentsByID(ctx context.Context, viewerID string, threadID int) ([]dto.CommentResponse, *exception.ErrResponseCtx) {
 	comments, err := s.threadRepo.CommentsByID(ctx, threadID)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", err)
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
 		default:
 			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. Repository에서 문제가 발생했습니다.", err)
 		}
 	}
-	return comments, nil
+
+	relations, err := loadViewerRelations(ctx, s.redisCache, s.relationRepo, viewerID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 차단 정보를 불러오지 못했습니다.", err)
+	}
+
+	hidden := hiddenUserSet(relations.Blocked)
+	filteredComments := make([]model.ThreadModel, 0, len(comments))
+	for _, comment := range comments {
+		if _, isHidden := hidden[comment.UserID]; !isHidden {
+			filteredComments = append(filteredComments, comment)
+		}
+	}
+
+	commentList, err := mapThreads(ctx, s, filteredComments, commentResponse)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 조회 실패. 답글을 불러오지 못했습니다.", err)
+	}
+	return commentList, nil
 }
 
-func (s *ThreadService) RemoveThreadByID(ctx context.Context, userID string, threadID int) *exception.ErrResponseCtx {
+func (s *ThreadService) RemoveThreadByID(ctx context.Context, meta dto.RequestMeta, userID string, threadID int) *exception.ErrResponseCtx {
 	thread, err := s.threadRepo.GetThreadByID(ctx, threadID)
 	if err != nil {
 		switch err {
 		case model.ErrNotFound:
-			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 삭제 실패. 존재하지 않는 쓰레드입니다.", err)
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 삭제 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
 		default:
 			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 삭제 실패. Repository에서 문제가 발생했습니다.", err)
 		}
@@ -194,23 +565,223 @@ func (s *ThreadService) RemoveThreadByID(ctx context.Context, userID string, thr
 		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 삭제 실패. 쓰레드를 삭제할 수 없습니다.", err)
 	}
 
-	utils.ClearCacheByPattern(s.redisCache, ctx, "thread:list:*")
+	s.invalidateThreadCache(ctx, thread)
+	s.publishThreadDeleted(ctx, thread)
+
+	s.auditService.Record(meta, dto.AuditEntry{
+		Event:      model.AuditEventThreadDelete,
+		ActorID:    &userID,
+		TargetType: dto.AuditTargetThread,
+		TargetID:   strconv.Itoa(threadID),
+		Diff:       map[string]interface{}{"title": dto.AuditChange{From: thread.Title, To: nil}},
+	})
 
 	return nil
 }
 
-func (s *ThreadService) IncrementViews(ctx context.Context, threadID int) *exception.ErrResponseCtx {
-	return s.incrementInteraction(ctx, threadID, "views")
+/*
+새로고침이나 봇 요청으로 조회수가 부풀지 않도록, 같은 시간 구간(VIEW_WINDOW_MINUTES) 안에서는 한 조회자를 한 번만 셈.
+조회자는 로그인한 경우 유저 ID, 아니면 IP와 User-Agent를 해시한 값으로 구분하고, 구간마다 조회자별 키(thread:{id}:viewer:{조회자}:{구간})를 SETNX로 남겨 처음 본 조회인지 판단함.
+중복 여부와 상관없이 모든 조회는 rawViews로, 처음 본 조회만 views로 올리며 둘 다 기존 인터렉션 파이프라인으로 DB에 반영함.
+작성자 본인의 조회는 어느 쪽에도 세지 않음.
+*/
+func (s *ThreadService) RecordView(ctx context.Context, meta dto.RequestMeta, viewerID string, thread *dto.ThreadResponse) *exception.ErrResponseCtx {
+	if viewerID != "" && viewerID == thread.Author.ID {
+		return nil
+	}
+
+	if err := s.incrementInteraction(ctx, thread.ID, "rawViews"); err != nil {
+		return err
+	}
+
+	viewer := viewerFingerprint(meta, viewerID)
+	window := time.Duration(config.Envs.ViewWindowMinutes) * time.Minute
+	cacheKey := fmt.Sprintf("thread:%d:viewer:%s:%d", thread.ID, viewer, time.Now().Unix()/int64(window.Seconds()))
+
+	firstView, err := s.cacheStore.SetNX(ctx, cacheKey, []byte("1"), window)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 인터렉션 증가 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	if !firstView {
+		return nil
+	}
+	return s.incrementInteraction(ctx, thread.ID, "views")
 }
 
-func (s *ThreadService) IncrementLikes(ctx context.Context, threadID int) *exception.ErrResponseCtx {
+func (s *ThreadService) IncrementLikes(ctx context.Context, userID string, threadID int) *exception.ErrResponseCtx {
+	if errCtx := s.checkReactionAllowed(ctx, userID, threadID); errCtx != nil {
+		return errCtx
+	}
 	return s.incrementInteraction(ctx, threadID, "likes")
 }
 
-func (s *ThreadService) IncrementDislikes(ctx context.Context, threadID int) *exception.ErrResponseCtx {
+func (s *ThreadService) IncrementDislikes(ctx context.Context, userID string, threadID int) *exception.ErrResponseCtx {
+	if errCtx := s.checkReactionAllowed(ctx, userID, threadID); errCtx != nil {
+		return errCtx
+	}
 	return s.incrementInteraction(ctx, threadID, "dislikes")
 }
 
+/*
+첨부 파일은 직접 업로드한 파일의 ID로만 받음.
+기존 클라이언트가 보내는 imgUrl은 바인딩할 때 safeurl로 검증한 값을 그대로 두고,
+imgUrl 없이 이미지를 첨부하면 imgUrl만 읽는 클라이언트를 위해 첫 번째 이미지 첨부의 주소를 imgUrl에 채워둠.
+*/
+func (s *ThreadService) resolveAttachments(ctx context.Context, req *dto.CreateThreadRequest) *exception.ErrResponseCtx {
+	if len(req.Attachments) == 0 {
+		return nil
+	}
+
+	uploadIDs := make([]string, 0, len(req.Attachments))
+	requested := make(map[string]struct{}, len(req.Attachments))
+	for _, attachment := range req.Attachments {
+		if _, duplicated := requested[attachment.UploadID]; duplicated {
+			return exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 쓰레드 생성 실패. 같은 파일을 중복해서 첨부할 수 없습니다.", exception.ErrInvalidParameter)
+		}
+		requested[attachment.UploadID] = struct{}{}
+		uploadIDs = append(uploadIDs, attachment.UploadID)
+	}
+
+	uploads, err := s.uploadRepo.ListUploadsByIDs(ctx, uploadIDs)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if len(uploads) != len(uploadIDs) {
+		return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 생성 실패. 존재하지 않는 파일입니다.", model.ErrNotFound)
+	}
+
+	uploadByID := make(map[string]model.UploadModel, len(uploads))
+	for _, upload := range uploads {
+		if upload.UserID != req.UserID {
+			return exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 쓰레드 생성 실패. 직접 업로드한 파일만 첨부할 수 있습니다.", exception.ErrUploadNotOwned)
+		}
+		uploadByID[upload.ID] = upload
+	}
+
+	attachedIDs, err := s.uploadRepo.ListAttachedUploadIDs(ctx, uploadIDs)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if len(attachedIDs) > 0 {
+		return exception.GenerateErrorCtx(fiber.StatusConflict, "❌ 쓰레드 생성 실패. 이미 다른 쓰레드에 첨부된 파일입니다.", exception.ErrAlreadyAttached)
+	}
+
+	if req.ImgUrl != nil {
+		return nil
+	}
+	for _, attachment := range req.Attachments {
+		upload := uploadByID[attachment.UploadID]
+		if _, isImage := upload.Width(); isImage {
+			imgURL := uploadURL(upload.ID)
+			req.ImgUrl = &imgURL
+			break
+		}
+	}
+	return nil
+}
+
+func (s *ThreadService) onThreadPublished(ctx context.Context, thread *model.ThreadModel, parent *model.ThreadModel) {
+	s.invalidateThreadCache(ctx, thread)
+
+	// 응답을 만들면서 본문 렌더링 캐시가 미리 채워지고, 링크 미리보기도 첫 조회 전에 가져오도록 큐에 넣어둠
+	published, errCtx := s.threadDetail(ctx, thread, "쓰레드 발행")
+	if errCtx != nil {
+		log.Printf("thread: failed to build published thread %d: %s %v", thread.ID, errCtx.Message, errCtx.Detail)
+		return
+	}
+	s.publishThreadCreated(ctx, published, parent)
+	s.previewService.Enqueue(published.Links)
+}
+
+// 다른 사람의 임시 저장 글은 존재 여부도 알 수 없도록 없는 글과 같이 응답함
+func (s *ThreadService) getDraft(ctx context.Context, userID string, threadID int, action string) (*model.ThreadModel, *exception.ErrResponseCtx) {
+	thread, err := s.threadRepo.GetDraftByID(ctx, threadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 임시 저장 글입니다.", action), err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+		}
+	}
+	if thread.UserID != userID {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, fmt.Sprintf("❌ %s 실패. 존재하지 않는 임시 저장 글입니다.", action), model.ErrNotFound)
+	}
+	if thread.Status == model.ThreadStatusPublished {
+		return nil, exception.GenerateErrorCtx(fiber.StatusConflict, fmt.Sprintf("❌ %s 실패. 이미 발행된 쓰레드입니다.", action), exception.ErrNotDraft)
+	}
+	return thread, nil
+}
+
+func (s *ThreadService) updateDraft(ctx context.Context, userID string, threadID int, action string, update dto.DraftUpdateEntity) (*dto.DraftResponse, *exception.ErrResponseCtx) {
+	updated, err := s.threadRepo.UpdateDraft(ctx, threadID, update)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, fmt.Sprintf("❌ %s 실패. Repository에서 문제가 발생했습니다.", action), err)
+	}
+	// 확인한 뒤 수정하기 전에 스케줄러가 먼저 발행한 경우
+	if !updated {
+		return nil, exception.GenerateErrorCtx(fiber.StatusConflict, fmt.Sprintf("❌ %s 실패. 이미 발행된 쓰레드입니다.", action), exception.ErrNotDraft)
+	}
+
+	draft, errCtx := s.getDraft(ctx, userID, threadID, action)
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	response := draftResponse(draft)
+	return &response, nil
+}
+
+func (s *ThreadService) getReplyTarget(ctx context.Context, userID string, parentID int) (*model.ThreadModel, *exception.ErrResponseCtx) {
+	parent, err := s.threadRepo.GetThreadByID(ctx, parentID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 생성 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+	if _, hidden := parent.HiddenAt(); hidden || parent.Status != model.ThreadStatusPublished {
+		return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 생성 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
+	if _, locked := parent.LockedAt(); locked {
+		return nil, exception.GenerateErrorCtx(fiber.StatusLocked, "❌ 쓰레드 생성 실패. 잠긴 쓰레드에는 답글을 달 수 없습니다.", exception.ErrThreadLocked)
+	}
+
+	blocked, err := s.relationRepo.IsBlocked(ctx, parent.UserID, userID)
+	if err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 생성 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if blocked {
+		return nil, exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 쓰레드 생성 실패. 작성자가 차단한 사용자입니다.", exception.ErrBlockedByUser)
+	}
+	return parent, nil
+}
+
+func (s *ThreadService) checkReactionAllowed(ctx context.Context, userID string, threadID int) *exception.ErrResponseCtx {
+	thread, err := s.threadRepo.GetThreadByID(ctx, threadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 인터렉션 증가 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+		default:
+			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 인터렉션 증가 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+	if thread.Status != model.ThreadStatusPublished {
+		return exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 인터렉션 증가 실패. 존재하지 않는 쓰레드입니다.", exception.ErrThreadNotFound)
+	}
+
+	blocked, err := s.relationRepo.IsBlocked(ctx, thread.UserID, userID)
+	if err != nil {
+		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 인터렉션 증가 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if blocked {
+		return exception.GenerateErrorCtx(fiber.StatusForbidden, "❌ 쓰레드 인터렉션 증가 실패. 작성자가 차단한 사용자입니다.", exception.ErrBlockedByUser)
+	}
+	return nil
+}
+
 /*
 이 함수 로직을 조금 까다롭게 짜서 주석을 조금 남겨봄..
 쓰레드 인터렉션은 이렇게 정의됨. -> 조회수, 좋아요, 싫어요, 공유 등등....
@@ -223,81 +794,112 @@ Redis 캐시 전략은 Key 값을 각각 인터렉션 별로 구분해서 저장
 */
 func (s *ThreadService) incrementInteraction(ctx context.Context, threadID int, interactionField string) *exception.ErrResponseCtx {
 	cachePattern := fmt.Sprintf("thread:%d:%s", threadID, interactionField)
-	threadItrAmount, err := s.redisCache.Incr(ctx, cachePattern).Result()
+	threadItrAmount, err := s.cacheStore.Incr(ctx, cachePattern)
 	if err != nil {
 		return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 인터렉션 증가 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
 	}
+	s.publishEvent(ctx, RealtimeThreadChannel(threadID), dto.EventThreadCounter, threadID, dto.CounterPayload{
+		Field:  interactionField,
+		Amount: threadItrAmount,
+	})
 
 	// 일정 수준 인터렉션 값이 올라가면 DB 트랜잭션에 쿼리 하나 저장
 	if threadItrAmount%config.Envs.RedisInteractionAmount == 0 {
-		txn := s.incrementInteractionMapper(ctx, interactionField, threadID, int(threadItrAmount))
-		s.txnsItr = append(s.txnsItr, txn)
+		s.txnsItr = append(s.txnsItr, dto.InteractionDeltaEntity{
+			ThreadID: threadID,
+			Field:    interactionField,
+			Amount:   int(threadItrAmount),
+		})
 		return nil
 	}
 
 	// 일정 트랜잭션 수에 도달하면 한번에 DB Push
 	if len(s.txnsItr) >= int(config.Envs.RedisInteractionCount) {
-		if err := s.threadRepo.RunTransaction(ctx, s.txnsItr); err != nil {
+		if err := s.threadRepo.ApplyInteractions(ctx, s.txnsItr); err != nil {
 			return exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 쓰레드 인터렉션 증가 실패. Reposioty에서 문제가 발생했습니다.", err)
 		}
 
 		// DB Push가 마무리 되었다면 트랜잭션 초기화
-		s.txnsItr = make([]model.PrismaTransaction, 0)
+		s.txnsItr = make([]dto.InteractionDeltaEntity, 0)
 	}
 
 	return nil
 }
 
-func (s *ThreadService) incrementInteractionMapper(ctx context.Context, interaction string, threadID int, amount int) model.PrismaTransaction {
-	userInteractions := []struct {
-		field         string
-		incrementFunc func(context.Context, int, int) model.ThreadUniqueTxResult
-	}{
-		{"views", s.threadRepo.IncrementViews},
-		{"likes", s.threadRepo.IncrementLikes},
-		{"dislikes", s.threadRepo.IncrementDislikes},
+// 구독자가 차단한 작성자의 글을 스트림에서 걸러낼 수 있도록 작성자 ID를 함께 보냄
+func (s *ThreadService) publishThreadCreated(ctx context.Context, thread *dto.ThreadResponse, parent *model.ThreadModel) {
+	event := func(eventType dto.RealtimeEventType, threadID int) dto.RealtimeEvent {
+		return dto.RealtimeEvent{
+			Type:      eventType,
+			ThreadID:  threadID,
+			AuthorID:  thread.Author.ID,
+			Payload:   thread,
+			CreatedAt: time.Now(),
+		}
 	}
 
-	for _, itraction := range userInteractions {
-		if itraction.field == interaction {
-			return itraction.incrementFunc(ctx, threadID, amount)
-		}
+	if parent == nil {
+		publishRealtimeEvent(s.redisCache, ctx, RealtimeBoardChannel(), event(dto.EventThreadCreated, thread.ID))
+		return
 	}
 
-	return nil
+	publishRealtimeEvent(s.redisCache, ctx, RealtimeThreadChannel(parent.ID), event(dto.EventThreadReplied, parent.ID))
+	if parent.UserID != thread.Author.ID {
+		publishRealtimeEvent(s.redisCache, ctx, RealtimeUserChannel(parent.UserID), event(dto.EventNotificationReply, parent.ID))
+	}
 }
 
-func (s *ThreadService) listThreadFromCache(ctx context.Context, pageNumber, pageSize int) ([]dto.ThreadResponse, error) {
-	var threadList []dto.ThreadResponse
-	err := utils.GetCache(s.redisCache, ctx, fmt.Sprintf("thread:list:page:%d:size:%d", pageNumber, pageSize), &threadList)
-	return threadList, err
+func (s *ThreadService) publishThreadDeleted(ctx context.Context, thread *model.ThreadModel) {
+	s.publishEvent(ctx, RealtimeThreadChannel(thread.ID), dto.EventThreadDeleted, thread.ID, nil)
+	if parentID, ok := thread.ParentThread(); ok {
+		s.publishEvent(ctx, RealtimeThreadChannel(parentID), dto.EventThreadDeleted, thread.ID, nil)
+	} else {
+		s.publishEvent(ctx, RealtimeBoardChannel(), dto.EventThreadDeleted, thread.ID, nil)
+	}
 }
 
-func (s *ThreadService) setListThreadToCache(ctx context.Context, pageNumber, pageSize int, threadList []dto.ThreadResponse, ttl time.Duration) error {
-	return utils.SetCache(s.redisCache, ctx, fmt.Sprintf("thread:list:page:%d:size:%d", pageNumber, pageSize), threadList, ttl)
+// 비로그인 조회자는 IP와 User-Agent를 그대로 남기지 않도록 해시해서 구분함
+func viewerFingerprint(meta dto.RequestMeta, viewerID string) string {
+	if viewerID != "" {
+		return "user:" + viewerID
+	}
+	hash := sha256.Sum256([]byte(meta.IP + "|" + meta.UserAgent))
+	return "anon:" + hex.EncodeToString(hash[:16])
 }
 
-func (s *ThreadService) listThreadByHandleFromCache(ctx context.Context, handle string) ([]model.ThreadModel, error) {
-	var threadList []model.ThreadModel
-	err := utils.GetCache(s.redisCache, ctx, fmt.Sprintf("thread:list:handle:%s", handle), &threadList)
-	return threadList, err
+func (s *ThreadService) publishEvent(ctx context.Context, channel string, eventType dto.RealtimeEventType, threadID int, payload interface{}) {
+	publishRealtimeEvent(s.redisCache, ctx, channel, dto.RealtimeEvent{
+		Type:      eventType,
+		ThreadID:  threadID,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	})
 }
 
-func (s *ThreadService) setListThreadByHandleToCache(ctx context.Context, handle string, threadList []model.ThreadModel, ttl time.Duration) error {
-	return utils.SetCache(s.redisCache, ctx, fmt.Sprintf("thread:list:handle:%s", handle), threadList, ttl)
+// 쓰레드 변경은 이미 DB에 반영되었으므로 캐시를 비우지 못해도 요청은 실패시키지 않고, TTL이 지나면 맞춰지도록 로그만 남김
+func (s *ThreadService) invalidateThreadCache(ctx context.Context, thread *model.ThreadModel) {
+	var handle string
+	if authors, err := loadAuthors(ctx, s.userRepo, []string{thread.UserID}); err == nil {
+		handle = authors[thread.UserID].Handle
+	} else {
+		log.Printf("thread: failed to load author of thread %d for cache invalidation: %v", thread.ID, err)
+	}
+	if err := invalidateThreadCache(ctx, s.cacheStore, thread, handle); err != nil {
+		log.Printf("thread: failed to invalidate cache of thread %d: %v", thread.ID, err)
+	}
 }
 
-func (s *ThreadService) getThreadFromCache(ctx context.Context, threadID int) (*model.ThreadModel, []error) {
+func (s *ThreadService) getThreadFromCache(ctx context.Context, threadID int) (*dto.ThreadResponse, []error) {
 	var (
-		thread    *model.ThreadModel
+		thread    *dto.ThreadResponse
 		errs      []error
 		itrFields = []struct {
 			fieldName string
-			getter    func(*redis.Client, context.Context, string, interface{}) error
+			getter    func(context.Context, cache.Cache, string, interface{}) error
 		}{
-			{"views", utils.GetCache},
-			{"likes", utils.GetCache},
-			{"dislikes", utils.GetCache},
+			{"views", cache.GetJSON},
+			{"likes", cache.GetJSON},
+			{"dislikes", cache.GetJSON},
 		}
 		itrAmount = map[string]int{
 			"views":    0,
@@ -307,13 +909,13 @@ func (s *ThreadService) getThreadFromCache(ctx context.Context, threadID int) (*
 	)
 
 	// Cache 값이 없다면,
-	if err := utils.GetCache(s.redisCache, ctx, fmt.Sprintf("thread:%d", threadID), &thread); err == nil && thread == nil {
+	if err := cache.GetJSON(ctx, s.cacheStore, threadCacheKey(threadID), &thread); err == nil && thread == nil {
 		return nil, nil
 	}
 
 	for _, field := range itrFields {
 		if amount, exists := itrAmount[field.fieldName]; exists {
-			if err := field.getter(s.redisCache, ctx, fmt.Sprintf("thread:%d:%s", threadID, field.fieldName), &amount); err == nil {
+			if err := field.getter(ctx, s.cacheStore, fmt.Sprintf("thread:%d:%s", threadID, field.fieldName), &amount); err == nil {
 				updatedThread, err := s.threadConversion(ctx, thread, field.fieldName, amount)
 				if err != nil {
 					errs = append(errs, err)
@@ -328,8 +930,8 @@ func (s *ThreadService) getThreadFromCache(ctx context.Context, threadID int) (*
 	return thread, errs
 }
 
-func (s *ThreadService) threadConversion(ctx context.Context, thread *model.ThreadModel, itrField string, amount int) (*model.ThreadModel, error) {
-	fieldToSetter := map[string]func(context.Context, *model.ThreadModel, string, int) (*model.ThreadModel, error){
+func (s *ThreadService) threadConversion(ctx context.Context, thread *dto.ThreadResponse, itrField string, amount int) (*dto.ThreadResponse, error) {
+	fieldToSetter := map[string]func(context.Context, *dto.ThreadResponse, string, int) (*dto.ThreadResponse, error){
 		"views":    s.setThreadField,
 		"likes":    s.setThreadField,
 		"dislikes": s.setThreadField,
@@ -342,7 +944,7 @@ func (s *ThreadService) threadConversion(ctx context.Context, thread *model.Thre
 	return thread, exception.ErrInvalidParameter
 }
 
-func (s *ThreadService) setThreadField(ctx context.Context, thread *model.ThreadModel, field string, value int) (*model.ThreadModel, error) {
+func (s *ThreadService) setThreadField(ctx context.Context, thread *dto.ThreadResponse, field string, value int) (*dto.ThreadResponse, error) {
 	if thread == nil {
 		return nil, exception.ErrMissingParams
 	}
@@ -367,6 +969,22 @@ func (s *ThreadService) setThreadField(ctx context.Context, thread *model.Thread
 	return thread, nil
 }
 
-func (s *ThreadService) setThreadToCache(ctx context.Context, thread *model.ThreadModel, ttl time.Duration) error {
-	return utils.SetCache(s.redisCache, ctx, fmt.Sprintf("thread:%d", thread.ID), thread, ttl)
+func (s *ThreadService) setThreadToCache(ctx context.Context, thread *dto.ThreadResponse, ttl time.Duration) error {
+	return cache.SetJSON(ctx, s.cacheStore, threadCacheKey(thread.ID), thread, ttl)
+}
+
+func draftResponse(thread *model.ThreadModel) dto.DraftResponse {
+	response := dto.DraftResponse{
+		ID:          thread.ID,
+		Title:       thread.Title,
+		Content:     thread.Content,
+		Status:      string(thread.Status),
+		Attachments: attachmentResponses(thread.Attachments()),
+		CreatedAt:   thread.CreatedAt,
+		UpdatedAt:   thread.UpdatedAt,
+	}
+	if publishAt, ok := thread.PublishAt(); ok {
+		response.PublishAt = &publishAt
+	}
+	return response
 }
@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+func createThread(t *testing.T, service *ThreadService, req *dto.CreateThreadRequest) *dto.ThreadResponse {
+	t.Helper()
+
+	thread, errCtx := service.CreateThread(context.Background(), req)
+	if errCtx != nil {
+		t.Fatalf("create thread %q: %s %v", req.Title, errCtx.Message, errCtx.Detail)
+	}
+	return thread
+}
+
+func listThreadIDs(t *testing.T, service *ThreadService, viewerID string) []int {
+	t.Helper()
+
+	threads, errCtx := service.ListThread(context.Background(), viewerID, 1, 10)
+	if errCtx != nil {
+		t.Fatalf("list thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	ids := make([]int, 0, len(threads))
+	for _, thread := range threads {
+		ids = append(ids, thread.ID)
+	}
+	return ids
+}
+
+func TestCreateThread(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	service := env.threadService()
+	ctx := context.Background()
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "**first** thread"})
+	if thread.Status != string(model.ThreadStatusPublished) || thread.Author.Handle != "alice" {
+		t.Errorf("created thread = %+v", thread)
+	}
+
+	found, errCtx := service.GetThreadByID(ctx, testMeta, alice.ID, thread.ID)
+	if errCtx != nil {
+		t.Fatalf("get thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if found.Title != "hello" || found.Author.ID != alice.ID || found.ContentHTML == "" {
+		t.Errorf("thread = %+v", found)
+	}
+
+	// 상세 캐시에는 모델이 아닌 응답 형식이 버전이 붙은 키로 저장됨
+	cached, err := env.redis.Get(threadCacheKey(thread.ID))
+	if err != nil || !strings.Contains(cached, `"author"`) || strings.Contains(cached, `"userID"`) {
+		t.Errorf("cached thread = %s, %v, want response payload", cached, err)
+	}
+
+	if _, errCtx := service.CreateThread(ctx, &dto.CreateThreadRequest{UserID: "missing", Title: "ghost", Content: "ghost"}); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("unknown author: errCtx = %+v, want 404", errCtx)
+	}
+	if _, errCtx := service.CreateThread(ctx, &dto.CreateThreadRequest{UserID: alice.ID, Title: "re", Content: "re", ParentThread: intPtr(9999)}); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("reply to unknown thread: errCtx = %+v, want 404", errCtx)
+	}
+}
+
+func TestCreateThreadWithAttachments(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	service := env.threadService()
+	ctx := context.Background()
+
+	width, height := 640, 480
+	upload, err := env.store.CreateUpload(ctx, dto.UploadEntity{
+		ID:          utils.GenerateUUID(),
+		UserID:      alice.ID,
+		Filename:    "cat.png",
+		ContentType: "image/png",
+		Size:        1024,
+		Width:       &width,
+		Height:      &height,
+		BlobKey:     "uploads/cat.png",
+	})
+	if err != nil {
+		t.Fatalf("create upload: %v", err)
+	}
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{
+		UserID:      alice.ID,
+		Title:       "cat",
+		Content:     "look",
+		Attachments: []dto.AttachmentRequest{{UploadID: upload.ID, AltText: "a cat"}},
+	})
+	attachments, errCtx := service.ListAttachments(ctx, thread.ID)
+	if errCtx != nil {
+		t.Fatalf("list attachments: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if len(attachments) != 1 || attachments[0].UploadID != upload.ID || attachments[0].AltText != "a cat" {
+		t.Errorf("attachments = %+v", attachments)
+	}
+	if thread.ImgURL == "" || len(thread.Attachments) != 1 {
+		t.Errorf("created thread = %+v, want imgUrl filled from the image attachment", thread)
+	}
+
+	// 기존 클라이언트가 보내는 imgUrl도 그대로 저장됨
+	imgURL := "https://example.com/cover.png"
+	legacy := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "legacy", Content: "legacy", ImgUrl: &imgURL})
+	if legacy.ImgURL != imgURL {
+		t.Errorf("imgUrl = %q, want %q", legacy.ImgURL, imgURL)
+	}
+
+	// 이미 첨부된 파일은 다른 쓰레드에 다시 첨부할 수 없음
+	_, errCtx = service.CreateThread(ctx, &dto.CreateThreadRequest{
+		UserID:      alice.ID,
+		Title:       "again",
+		Content:     "again",
+		Attachments: []dto.AttachmentRequest{{UploadID: upload.ID}},
+	})
+	if errCtx == nil || errCtx.StatusCode != fiber.StatusConflict {
+		t.Errorf("reused upload: errCtx = %+v, want 409", errCtx)
+	}
+}
+
+func TestListThread(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	service := env.threadService()
+
+	first := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "first", Content: "first"})
+	createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "draft", Content: "draft", IsDraft: true})
+	createThread(t, service, &dto.CreateThreadRequest{UserID: bob.ID, Title: "reply", Content: "reply", ParentThread: &first.ID})
+
+	// 답글과 임시 저장 글은 목록에 보이지 않음
+	if ids := listThreadIDs(t, service, ""); fmt.Sprint(ids) != fmt.Sprint([]int{first.ID}) {
+		t.Fatalf("thread list = %v, want [%d]", ids, first.ID)
+	}
+
+	// 목록이 캐시된 뒤에 작성한 쓰레드도 세대 번호가 올라가서 바로 보여야 함
+	second := createThread(t, service, &dto.CreateThreadRequest{UserID: bob.ID, Title: "second", Content: "second"})
+	if ids := listThreadIDs(t, service, ""); fmt.Sprint(ids) != fmt.Sprint([]int{first.ID, second.ID}) {
+		t.Fatalf("thread list after create = %v, want [%d %d]", ids, first.ID, second.ID)
+	}
+
+	// 차단한 유저의 쓰레드는 조회하는 유저에게만 숨겨짐
+	if err := env.store.CreateRelation(context.Background(), alice.ID, bob.ID, model.RelationTypeBlock); err != nil {
+		t.Fatalf("block: %v", err)
+	}
+	if ids := listThreadIDs(t, service, alice.ID); fmt.Sprint(ids) != fmt.Sprint([]int{first.ID}) {
+		t.Errorf("thread list for blocker = %v, want [%d]", ids, first.ID)
+	}
+	if ids := listThreadIDs(t, service, ""); len(ids) != 2 {
+		t.Errorf("thread list for anonymous viewer = %v, want 2 threads", ids)
+	}
+}
+
+func TestListThreadWithPins(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	service := env.threadService()
+	ctx := context.Background()
+
+	first := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "first", Content: "first"})
+	second := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "second", Content: "second"})
+	pinned := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "pinned", Content: "pinned"})
+	pinnedUntil := time.Now().Add(time.Minute)
+	if err := env.store.SetThreadPinned(ctx, pinned.ID, &pinnedUntil); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+
+	pageIDs := func(pageNumber int) []int {
+		threads, errCtx := service.ListThread(ctx, "", pageNumber, 2)
+		if errCtx != nil {
+			t.Fatalf("list thread page %d: %s %v", pageNumber, errCtx.Message, errCtx.Detail)
+		}
+		ids := make([]int, 0, len(threads))
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	// 고정 쓰레드도 페이지 크기에 포함되고, 밀려난 쓰레드는 다음 페이지에 나옴
+	if ids := pageIDs(1); fmt.Sprint(ids) != fmt.Sprint([]int{pinned.ID, first.ID}) {
+		t.Errorf("page 1 = %v, want [%d %d]", ids, pinned.ID, first.ID)
+	}
+	if ids := pageIDs(2); fmt.Sprint(ids) != fmt.Sprint([]int{second.ID}) {
+		t.Errorf("page 2 = %v, want [%d]", ids, second.ID)
+	}
+
+	// 고정이 끝나면 무효화 없이도 모든 페이지의 캐시가 함께 만료됨
+	expired := time.Now().Add(-time.Second)
+	if err := env.store.SetThreadPinned(ctx, pinned.ID, &expired); err != nil {
+		t.Fatalf("expire pin: %v", err)
+	}
+	env.redis.FastForward(time.Minute)
+	if ids := pageIDs(1); fmt.Sprint(ids) != fmt.Sprint([]int{first.ID, second.ID}) {
+		t.Errorf("page 1 after pin expired = %v, want [%d %d]", ids, first.ID, second.ID)
+	}
+	if ids := pageIDs(2); fmt.Sprint(ids) != fmt.Sprint([]int{pinned.ID}) {
+		t.Errorf("page 2 after pin expired = %v, want [%d]", ids, pinned.ID)
+	}
+}
+
+func TestThreadAuthors(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	service := env.threadService()
+	ctx := context.Background()
+
+	first := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "first", Content: "first"})
+	createThread(t, service, &dto.CreateThreadRequest{UserID: bob.ID, Title: "second", Content: "second"})
+	createThread(t, service, &dto.CreateThreadRequest{UserID: bob.ID, Title: "reply", Content: "reply", ParentThread: &first.ID})
+
+	threads, errCtx := service.ListThread(ctx, "", 1, 10)
+	if errCtx != nil {
+		t.Fatalf("list thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	for _, thread := range threads {
+		want := map[int]string{first.ID: "alice"}[thread.ID]
+		if want == "" {
+			want = "bob"
+		}
+		if thread.Author.Handle != want {
+			t.Errorf("thread %d author = %+v, want %q", thread.ID, thread.Author, want)
+		}
+	}
+
+	byHandle, errCtx := service.ListThreadByHandle(ctx, "", "bob")
+	if errCtx != nil {
+		t.Fatalf("list thread by handle: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if len(byHandle) != 2 {
+		t.Fatalf("bob's threads = %d, want 2", len(byHandle))
+	}
+	for _, thread := range byHandle {
+		if thread.Author.Handle != "bob" || thread.Author.Name != bob.Name {
+			t.Errorf("thread %d author = %+v, want bob", thread.ID, thread.Author)
+		}
+	}
+
+	comments, errCtx := service.CommentsByID(ctx, "", first.ID)
+	if errCtx != nil {
+		t.Fatalf("comments: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if len(comments) != 1 || comments[0].Author.Handle != "bob" || comments[0].ContentHTML == "" {
+		t.Errorf("comments = %+v, want one rendered reply by bob", comments)
+	}
+
+	found, errCtx := service.GetThreadByID(ctx, testMeta, "", first.ID)
+	if errCtx != nil || found.Author.Handle != "alice" {
+		t.Errorf("thread author = %+v, %v, want alice", found, errCtx)
+	}
+	authors, err := loadAuthors(ctx, env.store, []string{"missing"})
+	if err != nil || authors["missing"].ID != "missing" || authors["missing"].Handle != "" {
+		t.Errorf("missing author = %+v, %v, want ID only", authors["missing"], err)
+	}
+}
+
+func TestRemoveThread(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	service := env.threadService()
+	ctx := context.Background()
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "hello"})
+	reply := createThread(t, service, &dto.CreateThreadRequest{UserID: bob.ID, Title: "re", Content: "re", ParentThread: &thread.ID})
+	if ids := listThreadIDs(t, service, ""); len(ids) != 1 {
+		t.Fatalf("thread list = %v, want 1 thread", ids)
+	}
+
+	if errCtx := service.RemoveThreadByID(ctx, testMeta, bob.ID, thread.ID); errCtx == nil || errCtx.StatusCode != fiber.StatusForbidden {
+		t.Errorf("removing another user's thread: errCtx = %+v, want 403", errCtx)
+	}
+	if errCtx := service.RemoveThreadByID(ctx, testMeta, alice.ID, thread.ID); errCtx != nil {
+		t.Fatalf("remove thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	if _, errCtx := service.GetThreadByID(ctx, testMeta, alice.ID, thread.ID); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("removed thread: errCtx = %+v, want 404", errCtx)
+	}
+	if _, err := env.store.GetThreadByID(ctx, reply.ID); err != model.ErrNotFound {
+		t.Errorf("reply of removed thread still exists: %v", err)
+	}
+	if ids := listThreadIDs(t, service, ""); len(ids) != 0 {
+		t.Errorf("thread list after remove = %v, want empty", ids)
+	}
+	if errCtx := service.RemoveThreadByID(ctx, testMeta, alice.ID, thread.ID); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("removing twice: errCtx = %+v, want 404", errCtx)
+	}
+
+	logs := env.waitAuditLog(t, dto.AuditLogFilter{Event: auditEvent(model.AuditEventThreadDelete), ActorID: alice.ID})
+	if len(logs) != 1 {
+		t.Errorf("thread delete audit logs = %d, want 1", len(logs))
+	}
+}
+
+func TestRecordViewCountsEachViewerOnce(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	service := env.threadService()
+	ctx := context.Background()
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "hello"})
+	for _, viewerID := range []string{bob.ID, bob.ID, alice.ID} {
+		if _, errCtx := service.GetThreadByID(ctx, testMeta, viewerID, thread.ID); errCtx != nil {
+			t.Fatalf("get thread: %s %v", errCtx.Message, errCtx.Detail)
+		}
+	}
+
+	// 비로그인 조회자는 IP와 User-Agent로 구분함
+	otherMeta := dto.RequestMeta{IP: "198.51.100.7", UserAgent: testMeta.UserAgent}
+	for _, meta := range []dto.RequestMeta{testMeta, testMeta, otherMeta} {
+		if _, errCtx := service.GetThreadByID(ctx, meta, "", thread.ID); errCtx != nil {
+			t.Fatalf("get thread: %s %v", errCtx.Message, errCtx.Detail)
+		}
+	}
+
+	// 작성자 본인의 조회는 세지 않고, 같은 조회자의 재조회는 rawViews에만 반영됨
+	assertCounter(t, env, fmt.Sprintf("thread:%d:rawViews", thread.ID), "5")
+	assertCounter(t, env, fmt.Sprintf("thread:%d:views", thread.ID), "3")
+}
+
+func TestIncrementLikes(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	bob := env.registerUser(t, "bob")
+	carol := env.registerUser(t, "carol")
+	service := env.threadService()
+	ctx := context.Background()
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "hello"})
+	if _, errCtx := service.GetThreadByID(ctx, testMeta, "", thread.ID); errCtx != nil {
+		t.Fatalf("get thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	for i := 0; i < 3; i++ {
+		if errCtx := service.IncrementLikes(ctx, bob.ID, thread.ID); errCtx != nil {
+			t.Fatalf("like: %s %v", errCtx.Message, errCtx.Detail)
+		}
+	}
+	if errCtx := service.IncrementDislikes(ctx, bob.ID, thread.ID); errCtx != nil {
+		t.Fatalf("dislike: %s %v", errCtx.Message, errCtx.Detail)
+	}
+
+	// DB에 반영되기 전이라도 캐시된 쓰레드에는 누적된 인터렉션 값이 합쳐져서 보여야 함
+	found, errCtx := service.GetThreadByID(ctx, testMeta, "", thread.ID)
+	if errCtx != nil {
+		t.Fatalf("get thread: %s %v", errCtx.Message, errCtx.Detail)
+	}
+	if found.Likes != 3 || found.Dislikes != 1 {
+		t.Errorf("likes = %d, dislikes = %d, want 3 and 1", found.Likes, found.Dislikes)
+	}
+
+	if err := env.store.CreateRelation(ctx, alice.ID, carol.ID, model.RelationTypeBlock); err != nil {
+		t.Fatalf("block: %v", err)
+	}
+	if errCtx := service.IncrementLikes(ctx, carol.ID, thread.ID); errCtx == nil || errCtx.StatusCode != fiber.StatusForbidden {
+		t.Errorf("like from blocked user: errCtx = %+v, want 403", errCtx)
+	}
+	if errCtx := service.IncrementLikes(ctx, bob.ID, 9999); errCtx == nil || errCtx.StatusCode != fiber.StatusNotFound {
+		t.Errorf("like on unknown thread: errCtx = %+v, want 404", errCtx)
+	}
+}
+
+func TestApplyInteractions(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	service := env.threadService()
+	ctx := context.Background()
+
+	thread := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "hello", Content: "hello"})
+	err := env.store.ApplyInteractions(ctx, []dto.InteractionDeltaEntity{
+		{ThreadID: thread.ID, Field: "views", Amount: 10},
+		{ThreadID: thread.ID, Field: "likes", Amount: 3},
+	})
+	if err != nil {
+		t.Fatalf("apply interactions: %v", err)
+	}
+
+	// 하나라도 반영할 수 없으면 트랜잭션과 같이 아무것도 반영되지 않아야 함
+	err = env.store.ApplyInteractions(ctx, []dto.InteractionDeltaEntity{
+		{ThreadID: thread.ID, Field: "views", Amount: 10},
+		{ThreadID: 9999, Field: "views", Amount: 10},
+	})
+	if err != model.ErrNotFound {
+		t.Errorf("apply to unknown thread: err = %v, want ErrNotFound", err)
+	}
+
+	stored, err := env.store.GetThreadByID(ctx, thread.ID)
+	if err != nil {
+		t.Fatalf("get thread: %v", err)
+	}
+	if stored.Views != 10 || stored.Likes != 3 {
+		t.Errorf("views = %d, likes = %d, want 10 and 3", stored.Views, stored.Likes)
+	}
+}
+
+func TestPublishDueDrafts(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.registerUser(t, "alice")
+	service := env.threadService()
+	ctx := context.Background()
+
+	publishAt := time.Now().Add(time.Hour)
+	scheduled := createThread(t, service, &dto.CreateThreadRequest{UserID: alice.ID, Title: "later", Content: "later", PublishAt: &publishAt})
+	if ids := listThreadIDs(t, service, ""); len(ids) != 0 {
+		t.Fatalf("scheduled thread is listed before publishing: %v", ids)
+	}
+
+	// 예약 시각이 지난 것처럼 만들기 위해 저장소에서 예약 시각을 과거로 옮김
+	past := time.Now().Add(-time.Minute)
+	status := model.ThreadStatusScheduled
+	if _, err := env.store.UpdateDraft(ctx, scheduled.ID, dto.DraftUpdateEntity{Status: &status, PublishAt: &past}); err != nil {
+		t.Fatalf("move publishAt: %v", err)
+	}
+
+	published, err := service.PublishDueDrafts(ctx)
+	if err != nil {
+		t.Fatalf("publish due drafts: %v", err)
+	}
+	if published != 1 {
+		t.Errorf("published = %d, want 1", published)
+	}
+	if ids := listThreadIDs(t, service, ""); fmt.Sprint(ids) != fmt.Sprint([]int{scheduled.ID}) {
+		t.Errorf("thread list = %v, want [%d]", ids, scheduled.ID)
+	}
+}
+
+func assertCounter(t *testing.T, env *testEnv, key, want string) {
+	t.Helper()
+
+	got, err := env.redis.Get(key)
+	if err != nil {
+		t.Fatalf("get %s: %v", key, err)
+	}
+	if got != want {
+		t.Errorf("%s = %s, want %s", key, got, want)
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/internal/config"
+	"github.com/kitae0522/gommunity/internal/dto"
+	"github.com/kitae0522/gommunity/internal/model"
+	"github.com/kitae0522/gommunity/internal/repository"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/imaging"
+	"github.com/kitae0522/gommunity/pkg/storage"
+	"github.com/kitae0522/gommunity/pkg/utils"
+)
+
+const (
+	uploadCleanupInterval = time.Hour
+	uploadCleanupBatch    = 100
+	uploadFilenameLength  = 255
+	uploadReservationTTL  = 10 * time.Minute
+)
+
+var uploadContentTypes = []struct {
+	contentType string
+	extension   string
+	isImage     bool
+}{
+	{"image/jpeg", "jpg", true},
+	{"image/png", "png", true},
+	{"image/gif", "gif", true},
+	{"application/pdf", "pdf", false},
+	{"text/plain", "txt", false},
+	{"application/zip", "zip", false},
+}
+
+type UploadService struct {
+	uploadRepo *repository.UploadRepository
+	blobStore  storage.BlobStore
+	redisCache *redis.Client
+}
+
+func NewUploadService(repo *repository.UploadRepository, blobStore storage.BlobStore, rdconn *redis.Client) *UploadService {
+	return &UploadService{
+		uploadRepo: repo,
+		blobStore:  blobStore,
+		redisCache: rdconn,
+	}
+}
+
+func (s *UploadService) UploadFile(ctx context.Context, req *dto.UploadFileRequest) (*dto.UploadResponse, *exception.ErrResponseCtx) {
+	if int64(len(req.Data)) > config.Envs.UploadMaxBytes {
+		return nil, exception.GenerateErrorCtx(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("❌ 파일 업로드 실패. 파일은 %dMB를 넘을 수 없습니다.", config.Envs.UploadMaxBytes/1024/1024), exception.ErrFileTooLarge)
+	}
+
+	// 클라이언트가 보낸 Content-Type이나 확장자는 믿지 않고 실제 내용으로 형식을 판별
+	detected := mimetype.Detect(req.Data)
+	contentType, extension, isImage := "", "", false
+	for _, allowed := range uploadContentTypes {
+		if detected.Is(allowed.contentType) {
+			contentType, extension, isImage = allowed.contentType, allowed.extension, allowed.isImage
+			break
+		}
+	}
+	if contentType == "" {
+		return nil, exception.GenerateErrorCtx(fiber.StatusUnsupportedMediaType, "❌ 파일 업로드 실패. 이미지(JPEG, PNG, GIF)와 PDF, TXT, ZIP 파일만 업로드할 수 있습니다.", exception.ErrUnsupportedMediaType)
+	}
+
+	uploadID := utils.GenerateUUID()
+	entity := dto.UploadEntity{
+		ID:          uploadID,
+		UserID:      req.UserID,
+		Filename:    sanitizeFilename(req.Filename, extension),
+		ContentType: contentType,
+		Size:        len(req.Data),
+		BlobKey:     fmt.Sprintf("uploads/%s/%s.%s", req.UserID, uploadID, extension),
+	}
+
+	data := req.Data
+	var thumbnail []byte
+	if isImage {
+		processed, errCtx := processImage(req.Data, contentType)
+		if errCtx != nil {
+			return nil, errCtx
+		}
+		data, thumbnail = processed.Data, processed.Thumbnail
+		thumbnailKey := fmt.Sprintf("uploads/%s/%s_thumb.%s", req.UserID, uploadID, uploadExtension(processed.ThumbnailContentType))
+		entity.Size = len(processed.Data)
+		entity.Width, entity.Height = &processed.Width, &processed.Height
+		entity.ThumbnailKey, entity.ThumbnailContentType = &thumbnailKey, &processed.ThumbnailContentType
+	}
+
+	release, errCtx := s.reserveQuota(ctx, req.UserID, int64(entity.Size))
+	if errCtx != nil {
+		return nil, errCtx
+	}
+	// 업로드 레코드가 저장되었거나 실패한 뒤에는 예약이 필요 없으므로 반환할 때 해제함
+	defer release()
+
+	if err := s.blobStore.Put(ctx, entity.BlobKey, data, entity.ContentType); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 업로드 실패. 저장소에 저장하지 못했습니다.", err)
+	}
+	if entity.ThumbnailKey != nil {
+		if err := s.blobStore.Put(ctx, *entity.ThumbnailKey, thumbnail, *entity.ThumbnailContentType); err != nil {
+			s.deleteBlobs(ctx, entity.BlobKey)
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 업로드 실패. 저장소에 저장하지 못했습니다.", err)
+		}
+	}
+
+	upload, err := s.uploadRepo.CreateUpload(ctx, entity)
+	if err != nil {
+		s.deleteBlobs(ctx, uploadBlobKeys(entity.BlobKey, entity.ThumbnailKey)...)
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 파일 업로드 실패. 존재하지 않는 사용자입니다.", exception.ErrUserNotFound)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 업로드 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	response := uploadResponse(upload)
+	return &response, nil
+}
+
+/*
+동시에 올린 파일이 모두 한도 검사를 통과하지 않도록, 검사 전에 Redis에 사용량을 먼저 예약(INCRBY)함.
+DB에 저장된 사용량과 아직 저장 중인 예약량을 합쳐서 한도를 넘으면 예약을 되돌리고 거부함.
+예약을 해제하기 전에 서버가 종료되어도 한도가 계속 줄어들지 않도록 예약 키에는 만료 시간을 둠.
+*/
+func (s *UploadService) reserveQuota(ctx context.Context, userID string, size int64) (func(), *exception.ErrResponseCtx) {
+	cacheKey := uploadReservationKey(userID)
+	var reserved *redis.IntCmd
+	if _, err := s.redisCache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		reserved = pipe.IncrBy(ctx, cacheKey, size)
+		pipe.Expire(ctx, cacheKey, uploadReservationTTL)
+		return nil
+	}); err != nil {
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 업로드 실패. 캐시하는 과정에서 문제가 발생했습니다.", err)
+	}
+	// 요청이 취소되어도 예약은 되돌려야 하므로 요청 ctx를 사용하지 않음
+	release := func() {
+		if err := s.redisCache.DecrBy(context.Background(), cacheKey, size).Err(); err != nil {
+			log.Printf("upload: failed to release quota reservation of %s: %v", userID, err)
+		}
+	}
+
+	usage, err := s.uploadRepo.GetStorageUsage(ctx, userID)
+	if err != nil {
+		release()
+		return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 업로드 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	if usage+reserved.Val() > config.Envs.UploadQuotaBytes {
+		release()
+		return nil, exception.GenerateErrorCtx(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("❌ 파일 업로드 실패. 저장 공간 한도(%dMB)를 초과했습니다.", config.Envs.UploadQuotaBytes/1024/1024), exception.ErrQuotaExceeded)
+	}
+	return release, nil
+}
+
+func uploadReservationKey(userID string) string {
+	return fmt.Sprintf("upload:reserved:%s", userID)
+}
+
+func (s *UploadService) OpenUpload(ctx context.Context, uploadID string, thumbnail bool) (*dto.UploadBlob, *exception.ErrResponseCtx) {
+	upload, err := s.uploadRepo.GetUploadByID(ctx, uploadID)
+	if err != nil {
+		switch err {
+		case model.ErrNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 파일 조회 실패. 존재하지 않는 파일입니다.", err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 조회 실패. Repository에서 문제가 발생했습니다.", err)
+		}
+	}
+
+	blob := dto.UploadBlob{
+		ContentType: upload.ContentType,
+		Filename:    upload.Filename,
+	}
+	key := upload.BlobKey
+	_, blob.Inline = upload.Width()
+	if thumbnail {
+		thumbnailKey, ok := upload.ThumbnailKey()
+		if !ok {
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 파일 조회 실패. 썸네일이 없는 파일입니다.", model.ErrNotFound)
+		}
+		key = thumbnailKey
+		blob.ContentType, _ = upload.ThumbnailContentType()
+		blob.Inline = true
+	}
+
+	reader, err := s.blobStore.Get(ctx, key)
+	if err != nil {
+		switch err {
+		case storage.ErrBlobNotFound:
+			return nil, exception.GenerateErrorCtx(fiber.StatusNotFound, "❌ 파일 조회 실패. 저장소에 파일이 없습니다.", err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 파일 조회 실패. 저장소에서 문제가 발생했습니다.", err)
+		}
+	}
+	blob.Reader = reader
+	return &blob, nil
+}
+
+func (s *UploadService) GetStorageUsage(ctx context.Context, userID string) (int64, *exception.ErrResponseCtx) {
+	usage, err := s.uploadRepo.GetStorageUsage(ctx, userID)
+	if err != nil {
+		return 0, exception.GenerateErrorCtx(fiber.StatusInternalServerError, "❌ 저장 공간 조회 실패. Repository에서 문제가 발생했습니다.", err)
+	}
+	return usage, nil
+}
+
+/*
+업로드만 하고 쓰레드에 첨부하지 않은 파일, 쓰레드가 삭제되어 첨부가 사라진 파일을 정리함.
+작성 중인 글의 파일이 지워지지 않도록 업로드 후 UploadOrphanTTLHours가 지난 것만 대상으로 함.
+저장소 파일을 먼저 지우고 DB 레코드를 지워서, 중간에 실패해도 다음 주기에 다시 시도되도록 함.
+*/
+func (s *UploadService) CleanupOrphanUploads(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-time.Duration(config.Envs.UploadOrphanTTLHours) * time.Hour)
+	cursor, removed := "", 0
+
+	for {
+		uploads, err := s.uploadRepo.ListStaleUploads(ctx, cutoff, cursor, uploadCleanupBatch)
+		if err != nil {
+			return removed, err
+		}
+		if len(uploads) == 0 {
+			return removed, nil
+		}
+
+		uploadIDs := make([]string, 0, len(uploads))
+		for _, upload := range uploads {
+			uploadIDs = append(uploadIDs, upload.ID)
+		}
+		attachedIDs, err := s.uploadRepo.ListAttachedUploadIDs(ctx, uploadIDs)
+		if err != nil {
+			return removed, err
+		}
+		attached := make(map[string]struct{}, len(attachedIDs))
+		for _, uploadID := range attachedIDs {
+			attached[uploadID] = struct{}{}
+		}
+
+		for _, upload := range uploads {
+			if _, ok := attached[upload.ID]; ok {
+				continue
+			}
+			thumbnailKey, hasThumbnail := upload.ThumbnailKey()
+			keys := []string{upload.BlobKey}
+			if hasThumbnail {
+				keys = append(keys, thumbnailKey)
+			}
+			if err := s.deleteBlobsStrict(ctx, keys...); err != nil {
+				log.Printf("upload: failed to delete blobs of %s: %v", upload.ID, err)
+				continue
+			}
+			if err := s.uploadRepo.DeleteUpload(ctx, upload.ID); err != nil && err != model.ErrNotFound {
+				return removed, err
+			}
+			removed++
+		}
+
+		if len(uploads) < uploadCleanupBatch {
+			return removed, nil
+		}
+		cursor = uploads[len(uploads)-1].ID
+	}
+}
+
+// 주기적으로 고아 파일을 정리함
+func (s *UploadService) Run(ctx context.Context) {
+	ticker := time.NewTicker(uploadCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		removed, err := s.CleanupOrphanUploads(ctx)
+		if err != nil {
+			log.Printf("upload: orphan cleanup failed: %v", err)
+		}
+		if removed > 0 {
+			log.Printf("upload: removed %d orphan uploads", removed)
+		}
+	}
+}
+
+func (s *UploadService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			log.Printf("upload: failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+func (s *UploadService) deleteBlobsStrict(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func processImage(data []byte, contentType string) (*imaging.Processed, *exception.ErrResponseCtx) {
+	processed, err := imaging.Process(data, contentType, imaging.Options{
+		MaxDimension:  int(config.Envs.UploadMaxDimension),
+		MaxPixels:     int(config.Envs.UploadMaxPixels),
+		ThumbnailSize: int(config.Envs.UploadThumbnailSize),
+		JPEGQuality:   90,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, imaging.ErrDimensionTooLarge):
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, fmt.Sprintf("❌ 파일 업로드 실패. 이미지의 가로, 세로는 %dpx를 넘을 수 없습니다.", config.Envs.UploadMaxDimension), err)
+		case errors.Is(err, imaging.ErrTooManyPixels):
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. 이미지 해상도가 너무 큽니다.", err)
+		case errors.Is(err, imaging.ErrTooManyFrames):
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. 프레임이 너무 많은 GIF입니다.", err)
+		default:
+			return nil, exception.GenerateErrorCtx(fiber.StatusBadRequest, "❌ 파일 업로드 실패. 이미지를 읽을 수 없습니다.", exception.ErrInvalidImage)
+		}
+	}
+	return processed, nil
+}
+
+// 다운로드 시 Content-Disposition에 쓰이므로 경로와 제어 문자를 제거하고 실제 형식의 확장자로 맞춤
+func sanitizeFilename(filename, extension string) string {
+	base := strings.TrimSuffix(filepath.Base(strings.ReplaceAll(filename, "\\", "/")), filepath.Ext(filename))
+	base = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == '/' {
+			return -1
+		}
+		return r
+	}, base)
+	base = strings.TrimSpace(base)
+	if base == "" || base == "." {
+		base = "file"
+	}
+
+	runes := []rune(base)
+	if limit := uploadFilenameLength - len(extension) - 1; len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + "." + extension
+}
+
+func uploadExtension(contentType string) string {
+	for _, allowed := range uploadContentTypes {
+		if allowed.contentType == contentType {
+			return allowed.extension
+		}
+	}
+	return "bin"
+}
+
+func uploadBlobKeys(blobKey string, thumbnailKey *string) []string {
+	if thumbnailKey == nil {
+		return []string{blobKey}
+	}
+	return []string{blobKey, *thumbnailKey}
+}
+
+func uploadURL(uploadID string) string {
+	return "/api/uploads/" + uploadID
+}
+
+func uploadResponse(upload *model.UploadModel) dto.UploadResponse {
+	response := dto.UploadResponse{
+		ID:          upload.ID,
+		URL:         uploadURL(upload.ID),
+		Filename:    upload.Filename,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		CreatedAt:   upload.CreatedAt,
+	}
+	if _, ok := upload.ThumbnailKey(); ok {
+		response.ThumbnailURL = uploadURL(upload.ID) + "/thumbnail"
+	}
+	if width, ok := upload.Width(); ok {
+		response.Width = &width
+	}
+	if height, ok := upload.Height(); ok {
+		response.Height = &height
+	}
+	return response
+}
+
+func attachmentResponses(attachments []model.AttachmentModel) []dto.AttachmentResponse {
+	responses := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		upload := uploadResponse(attachment.Upload())
+		altText, _ := attachment.AltText()
+		responses = append(responses, dto.AttachmentResponse{
+			ID:           attachment.ID,
+			UploadID:     attachment.UploadID,
+			Position:     attachment.Position,
+			AltText:      altText,
+			URL:          upload.URL,
+			ThumbnailURL: upload.ThumbnailURL,
+			Filename:     upload.Filename,
+			ContentType:  upload.ContentType,
+			Size:         upload.Size,
+			Width:        upload.Width,
+			Height:       upload.Height,
+		})
+	}
+	return responses
+}
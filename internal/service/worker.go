@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// 서버가 떠 있는 동안 백그라운드에서 도는 작업. ctx가 취소되면 하던 일을 정리하고 반환해야 함
+type Worker interface {
+	Run(ctx context.Context)
+}
+
+/*
+workers를 각각 고루틴으로 실행하고, 모두 반환할 때까지 기다리는 함수를 돌려줌.
+서버를 내릴 때는 요청 처리를 먼저 끝낸 뒤(app.Shutdown) ctx를 취소하고 wait를 호출해야
+요청 중에 쌓인 감사 로그까지 저장됨.
+*/
+func StartWorkers(ctx context.Context, workers ...Worker) (wait func()) {
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(worker Worker) {
+			defer wg.Done()
+			worker.Run(ctx)
+		}(worker)
+	}
+	return wg.Wait
+}
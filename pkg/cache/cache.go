@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+// 락을 잡았을 때만 반환되며, 자신이 건 락일 때만 해제함
+type Unlock func()
+
+/*
+서비스가 Redis에 직접 묶이지 않도록 캐시 동작을 인터페이스로 분리함.
+Redis, 프로세스 내 LRU, 둘을 겹친 2단 캐시가 같은 인터페이스를 구현하므로 테스트에서는 메모리 캐시로 바꿔 끼울 수 있음.
+*/
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+
+	// 태그별 세대 번호. 번호를 키에 넣어두고 올리는 것으로 여러 키를 한 번에 무효화함
+	Generation(ctx context.Context, tag string) (int64, error)
+	BumpGeneration(ctx context.Context, tags ...string) error
+
+	// 락을 잡지 못하면 nil을 반환함
+	Lock(ctx context.Context, key string, ttl time.Duration) (Unlock, error)
+
+	// 운영 중 관리자가 패턴으로 비울 때만 사용함
+	Purge(ctx context.Context, pattern string) (int64, error)
+}
+
+// 캐시가 없으면 ptr을 건드리지 않고 nil을 반환함
+func GetJSON(ctx context.Context, c Cache, key string, ptr interface{}) error {
+	data, err := c.Get(ctx, key)
+	if err == ErrCacheMiss {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, ptr)
+}
+
+func SetJSON(ctx context.Context, c Cache, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, data, ttl)
+}
+
+func generationKey(tag string) string {
+	return "cache:gen:" + tag
+}
@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+const testTTL = 50 * time.Millisecond
+
+// advance는 구현이 보는 시계를 ttl 이상 흘려보냄. 메모리 캐시는 실제 시간을, miniredis는 FastForward를 사용함
+type cacheFactory func(t *testing.T) (c Cache, advance func(time.Duration))
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func cacheFactories() map[string]cacheFactory {
+	return map[string]cacheFactory{
+		"memory": func(t *testing.T) (Cache, func(time.Duration)) {
+			return NewMemoryCache(0), time.Sleep
+		},
+		"redis": func(t *testing.T) (Cache, func(time.Duration)) {
+			mr, client := newTestRedis(t)
+			return NewRedisCache(client), mr.FastForward
+		},
+		"tiered": func(t *testing.T) (Cache, func(time.Duration)) {
+			mr, client := newTestRedis(t)
+			return NewTieredCache(client, 0, time.Minute), func(d time.Duration) {
+				time.Sleep(d)
+				mr.FastForward(d)
+			}
+		},
+	}
+}
+
+/*
+Cache 인터페이스의 구현이 서로 같은 의미로 동작하는지 확인하는 공통 테스트.
+서비스 테스트는 메모리 캐시로, 운영은 Redis나 2단 캐시로 돌기 때문에 셋의 동작이 달라지면 테스트가 운영을 보장하지 못함.
+*/
+func TestCacheContract(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, c Cache, advance func(time.Duration))
+	}{
+		{"get missing key", testGetMiss},
+		{"set and delete", testSetDelete},
+		{"ttl expiry", testTTLExpiry},
+		{"setnx", testSetNX},
+		{"incr keeps ttl", testIncrKeepsTTL},
+		{"generation", testGeneration},
+		{"lock token", testLockToken},
+		{"purge glob", testPurgeGlob},
+	}
+	for name, factory := range cacheFactories() {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					c, advance := factory(t)
+					tt.run(t, c, advance)
+				})
+			}
+		})
+	}
+}
+
+func testGetMiss(t *testing.T, c Cache, advance func(time.Duration)) {
+	if _, err := c.Get(context.Background(), "missing"); err != ErrCacheMiss {
+		t.Errorf("Get(missing) error = %v, want %v", err, ErrCacheMiss)
+	}
+
+	var value string
+	if err := GetJSON(context.Background(), c, "missing", &value); err != nil || value != "" {
+		t.Errorf("GetJSON(missing) = %q, %v, want empty, nil", value, err)
+	}
+}
+
+func testSetDelete(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	if err := SetJSON(ctx, c, "a", "value", 0); err != nil {
+		t.Fatalf("SetJSON() error = %v", err)
+	}
+	c.Set(ctx, "b", []byte("b"), 0)
+
+	var value string
+	if err := GetJSON(ctx, c, "a", &value); err != nil || value != "value" {
+		t.Errorf("GetJSON(a) = %q, %v, want %q, nil", value, err, "value")
+	}
+
+	if err := c.Delete(ctx, "a", "b"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if _, err := c.Get(ctx, key); err != ErrCacheMiss {
+			t.Errorf("Get(%s) after Delete error = %v, want %v", key, err, ErrCacheMiss)
+		}
+	}
+}
+
+func testTTLExpiry(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	c.Set(ctx, "short", []byte("1"), testTTL)
+	c.Set(ctx, "forever", []byte("1"), 0)
+	c.Set(ctx, "extended", []byte("1"), testTTL)
+	c.Expire(ctx, "extended", time.Hour)
+
+	if _, err := c.Get(ctx, "short"); err != nil {
+		t.Fatalf("Get(short) before ttl error = %v", err)
+	}
+	advance(2 * testTTL)
+
+	if _, err := c.Get(ctx, "short"); err != ErrCacheMiss {
+		t.Errorf("Get(short) after ttl error = %v, want %v", err, ErrCacheMiss)
+	}
+	for _, key := range []string{"forever", "extended"} {
+		if _, err := c.Get(ctx, key); err != nil {
+			t.Errorf("Get(%s) after ttl error = %v, want nil", key, err)
+		}
+	}
+}
+
+func testSetNX(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	if ok, err := c.SetNX(ctx, "nx", []byte("first"), testTTL); err != nil || !ok {
+		t.Fatalf("SetNX(first) = %v, %v, want true, nil", ok, err)
+	}
+	if ok, err := c.SetNX(ctx, "nx", []byte("second"), testTTL); err != nil || ok {
+		t.Errorf("SetNX(second) = %v, %v, want false, nil", ok, err)
+	}
+	if data, _ := c.Get(ctx, "nx"); string(data) != "first" {
+		t.Errorf("Get(nx) = %q, want %q", data, "first")
+	}
+
+	advance(2 * testTTL)
+	if ok, err := c.SetNX(ctx, "nx", []byte("third"), testTTL); err != nil || !ok {
+		t.Errorf("SetNX(after ttl) = %v, %v, want true, nil", ok, err)
+	}
+}
+
+func testIncrKeepsTTL(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Incr(ctx, "counter")
+		if err != nil {
+			t.Fatalf("Incr() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Incr() = %d, want %d", got, want)
+		}
+		if want == 1 {
+			c.Expire(ctx, "counter", testTTL)
+		}
+	}
+
+	// 요청 횟수 제한처럼 첫 요청에만 TTL을 걸기 때문에 Incr가 TTL을 지우면 카운터가 영영 초기화되지 않음
+	advance(2 * testTTL)
+	if got, err := c.Incr(ctx, "counter"); err != nil || got != 1 {
+		t.Errorf("Incr() after ttl = %d, %v, want 1, nil", got, err)
+	}
+}
+
+func testGeneration(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	if gen, err := c.Generation(ctx, "thread"); err != nil || gen != 0 {
+		t.Fatalf("Generation(thread) = %d, %v, want 0, nil", gen, err)
+	}
+	c.BumpGeneration(ctx, "thread", "comment")
+	c.BumpGeneration(ctx, "thread")
+
+	want := map[string]int64{"thread": 2, "comment": 1, "other": 0}
+	for tag, wantGen := range want {
+		if gen, err := c.Generation(ctx, tag); err != nil || gen != wantGen {
+			t.Errorf("Generation(%s) = %d, %v, want %d, nil", tag, gen, err, wantGen)
+		}
+	}
+}
+
+func testLockToken(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	first, err := c.Lock(ctx, "lock", testTTL)
+	if err != nil || first == nil {
+		t.Fatalf("Lock(first) = %v, %v, want unlock, nil", first != nil, err)
+	}
+	if second, err := c.Lock(ctx, "lock", testTTL); err != nil || second != nil {
+		t.Fatalf("Lock(second) while held = %v, %v, want nil, nil", second != nil, err)
+	}
+
+	// 첫 락이 만료된 뒤 다른 요청이 잡은 락을 늦게 끝난 첫 요청이 풀면 안 됨
+	advance(2 * testTTL)
+	third, err := c.Lock(ctx, "lock", time.Minute)
+	if err != nil || third == nil {
+		t.Fatalf("Lock(after ttl) = %v, %v, want unlock, nil", third != nil, err)
+	}
+	first()
+	if again, _ := c.Lock(ctx, "lock", time.Minute); again != nil {
+		t.Fatalf("Lock() after stale unlock acquired, want held by the newer lock")
+	}
+
+	third()
+	if again, err := c.Lock(ctx, "lock", time.Minute); err != nil || again == nil {
+		t.Errorf("Lock() after unlock = %v, %v, want unlock, nil", again != nil, err)
+	}
+}
+
+func testPurgeGlob(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+	for _, key := range []string{"thread:1", "thread:2", "thread:list:1", "comment:1", "threads"} {
+		c.Set(ctx, key, []byte(key), 0)
+	}
+
+	deleted, err := c.Purge(ctx, "thread:*")
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("Purge() deleted = %d, want 3", deleted)
+	}
+
+	var remaining []string
+	for _, key := range []string{"thread:1", "thread:2", "thread:list:1", "comment:1", "threads"} {
+		if _, err := c.Get(ctx, key); err == nil {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+	if len(remaining) != 2 || remaining[0] != "comment:1" || remaining[1] != "threads" {
+		t.Errorf("remaining keys = %v, want [comment:1 threads]", remaining)
+	}
+}
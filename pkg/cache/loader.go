@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	cacheLockTTL          = 10 * time.Second
+	cacheLockWaitInterval = 50 * time.Millisecond
+	cacheLockWaitRetries  = 20
+	cacheRefreshKeyPrefix = "refresh:"
+	cacheLockKeySuffix    = ":lock"
+)
+
+// Fresh가 지나면 새로 불러오고, 그 뒤 Stale 동안은 새로 불러오는 사이에 이전 값을 그대로 내려줌
+type TTL struct {
+	Fresh  time.Duration
+	Stale  time.Duration
+	Jitter float64
+}
+
+// fresh가 0 이하이면 TTL.Fresh를 사용하고, 0보다 크면 그 시점에 캐시가 완전히 만료됨
+type LoadFunc func(ctx context.Context) (data interface{}, fresh time.Duration, err error)
+
+type cacheEnvelope struct {
+	Data       json.RawMessage `json:"data"`
+	FreshUntil time.Time       `json:"freshUntil"`
+}
+
+type Loader struct {
+	cache Cache
+	group singleflight.Group
+}
+
+func NewLoader(cache Cache) *Loader {
+	return &Loader{cache: cache}
+}
+
+/*
+캐시가 만료되는 순간 동시에 들어온 요청이 모두 DB를 조회하지 않도록 아래 순서로 처리함.
+1. 캐시가 신선하면 그대로 반환
+2. 신선하지 않지만 남아있으면 이전 값을 반환하고, 백그라운드에서 하나의 요청만 새로 불러옴
+3. 캐시가 없으면 프로세스 안에서는 singleflight로, 프로세스 사이에서는 캐시 락으로 하나의 요청만 불러오고 나머지는 결과를 기다림
+load는 백그라운드에서도 실행되므로 요청 ctx 대신 인자로 받은 ctx만 사용해야 함.
+*/
+func (l *Loader) Load(ctx context.Context, key string, ttl TTL, ptr interface{}, load LoadFunc) error {
+	envelope, err := l.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if envelope != nil {
+		if time.Now().After(envelope.FreshUntil) {
+			l.refreshAsync(key, ttl, load)
+		}
+		return json.Unmarshal(envelope.Data, ptr)
+	}
+
+	data, err, _ := l.group.Do(key, func() (interface{}, error) {
+		return l.fill(ctx, key, ttl, load)
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data.([]byte), ptr)
+}
+
+func (l *Loader) fill(ctx context.Context, key string, ttl TTL, load LoadFunc) ([]byte, error) {
+	unlock, err := l.lock(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if unlock != nil {
+		defer unlock()
+		return l.loadAndSet(ctx, key, ttl, load)
+	}
+
+	// 다른 프로세스가 채우는 중이면 잠깐 기다려보고, 끝나지 않으면 직접 불러옴
+	for i := 0; i < cacheLockWaitRetries; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(cacheLockWaitInterval):
+		}
+		envelope, err := l.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if envelope != nil {
+			return envelope.Data, nil
+		}
+	}
+	return l.loadAndSet(ctx, key, ttl, load)
+}
+
+func (l *Loader) refreshAsync(key string, ttl TTL, load LoadFunc) {
+	go l.group.Do(cacheRefreshKeyPrefix+key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), cacheLockTTL)
+		defer cancel()
+
+		unlock, err := l.lock(ctx, key)
+		if err != nil || unlock == nil {
+			return nil, err
+		}
+		defer unlock()
+
+		// 락을 잡기 전에 다른 요청이 이미 새로 불러왔으면 다시 불러오지 않음
+		envelope, err := l.get(ctx, key)
+		if err != nil || (envelope != nil && time.Now().Before(envelope.FreshUntil)) {
+			return nil, err
+		}
+		return l.loadAndSet(ctx, key, ttl, load)
+	})
+}
+
+// 락을 잡지 못하면 unlock이 nil로 반환됨
+func (l *Loader) lock(ctx context.Context, key string) (Unlock, error) {
+	return l.cache.Lock(ctx, key+cacheLockKeySuffix, cacheLockTTL)
+}
+
+func (l *Loader) loadAndSet(ctx context.Context, key string, ttl TTL, load LoadFunc) ([]byte, error) {
+	data, fresh, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// load가 만료 시점을 직접 정한 경우(고정 쓰레드 등)에는 그 시점을 넘기지 않도록 jitter를 적용하지 않고, 지난 값을 stale로도 내려주지 않음
+	expiration := fresh
+	if fresh <= 0 {
+		fresh = JitterTTL(ttl.Fresh, ttl.Jitter)
+		expiration = fresh + ttl.Stale
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(cacheEnvelope{
+		Data:       rawData,
+		FreshUntil: time.Now().Add(fresh),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := l.cache.Set(ctx, key, payload, expiration); err != nil {
+		return nil, err
+	}
+	return rawData, nil
+}
+
+func (l *Loader) get(ctx context.Context, key string) (*cacheEnvelope, error) {
+	cachedData, err := l.cache.Get(ctx, key)
+	if err == ErrCacheMiss {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// 이전 형식으로 저장된 값은 없는 캐시로 보고 새로 채움
+	var envelope cacheEnvelope
+	if err := json.Unmarshal(cachedData, &envelope); err != nil || envelope.Data == nil {
+		return nil, nil
+	}
+	return &envelope, nil
+}
+
+// 같은 시점에 만든 캐시가 한꺼번에 만료되지 않도록 TTL을 ±rate 범위에서 흔듦
+func JitterTTL(ttl time.Duration, rate float64) time.Duration {
+	if rate <= 0 || ttl <= 0 {
+		return ttl
+	}
+	delta := float64(ttl) * rate
+	return ttl + time.Duration((rand.Float64()*2-1)*delta)
+}
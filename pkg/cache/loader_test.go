@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 호출 횟수를 세고, 호출마다 그 횟수를 값으로 돌려주는 LoadFunc
+type countingLoad struct {
+	calls   int64
+	fresh   time.Duration
+	delay   time.Duration
+	release chan struct{}
+}
+
+func (l *countingLoad) load(ctx context.Context) (interface{}, time.Duration, error) {
+	call := atomic.AddInt64(&l.calls, 1)
+	if l.release != nil {
+		<-l.release
+	}
+	time.Sleep(l.delay)
+	return call, l.fresh, nil
+}
+
+func (l *countingLoad) count() int64 {
+	return atomic.LoadInt64(&l.calls)
+}
+
+func TestLoaderLoadsOnceForConcurrentMisses(t *testing.T) {
+	c := NewMemoryCache(0)
+	// 두 Loader가 같은 캐시를 바라보게 해서 프로세스 사이의 락도 함께 확인함
+	loaders := []*Loader{NewLoader(c), NewLoader(c)}
+	counter := &countingLoad{delay: 20 * time.Millisecond}
+	ttl := TTL{Fresh: time.Minute}
+
+	var wg sync.WaitGroup
+	results := make([]int64, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := loaders[i%len(loaders)].Load(context.Background(), "key", ttl, &results[i], counter.load); err != nil {
+				t.Errorf("Load() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := counter.count(); got != 1 {
+		t.Errorf("load calls = %d, want 1", got)
+	}
+	for i, result := range results {
+		if result != 1 {
+			t.Errorf("results[%d] = %d, want 1", i, result)
+		}
+	}
+}
+
+func TestLoaderServesStaleWhileRefreshing(t *testing.T) {
+	loader := NewLoader(NewMemoryCache(0))
+	counter := &countingLoad{}
+	ttl := TTL{Fresh: 50 * time.Millisecond, Stale: time.Minute}
+
+	var value int64
+	if err := loader.Load(context.Background(), "key", ttl, &value, counter.load); err != nil || value != 1 {
+		t.Fatalf("Load() = %d, %v, want 1, nil", value, err)
+	}
+	time.Sleep(2 * ttl.Fresh)
+
+	// 새로 불러오는 동안에는 load를 막아두고, 그 사이의 요청이 모두 이전 값을 바로 받는지 확인함
+	counter.release = make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var stale int64
+			if err := loader.Load(context.Background(), "key", ttl, &stale, counter.load); err != nil || stale != 1 {
+				t.Errorf("Load() while refreshing = %d, %v, want 1, nil", stale, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 백그라운드 갱신 고루틴이 모두 돌 때까지 기다린 뒤에도 load는 한 번만 불려야 함
+	waitFor(t, "refresh to start", func() bool { return counter.count() == 2 })
+	time.Sleep(ttl.Fresh)
+	if got := counter.count(); got != 2 {
+		t.Errorf("load calls while refreshing = %d, want 2", got)
+	}
+
+	close(counter.release)
+	waitFor(t, "refreshed value", func() bool {
+		var refreshed int64
+		loader.Load(context.Background(), "key", ttl, &refreshed, counter.load)
+		return refreshed == 2
+	})
+}
+
+func TestLoaderFixedFreshSkipsJitterAndStale(t *testing.T) {
+	c := NewMemoryCache(0)
+	loader := NewLoader(c)
+	fresh := 50 * time.Millisecond
+	counter := &countingLoad{fresh: fresh}
+	ttl := TTL{Fresh: time.Hour, Stale: time.Hour, Jitter: 0.5}
+
+	start := time.Now()
+	var value int64
+	if err := loader.Load(context.Background(), "key", ttl, &value, counter.load); err != nil || value != 1 {
+		t.Fatalf("Load() = %d, %v, want 1, nil", value, err)
+	}
+
+	envelope, err := loader.get(context.Background(), "key")
+	if err != nil || envelope == nil {
+		t.Fatalf("get() = %v, %v, want envelope, nil", envelope, err)
+	}
+	if until := envelope.FreshUntil.Sub(start); until < fresh || until > fresh+time.Second {
+		t.Errorf("FreshUntil = start + %v, want start + %v", until, fresh)
+	}
+
+	// 지정한 시점이 지나면 이전 값을 stale로 내려주지 않고 캐시가 완전히 비어 있어야 함
+	time.Sleep(2 * fresh)
+	if _, err := c.Get(context.Background(), "key"); err != ErrCacheMiss {
+		t.Errorf("Get() after fresh error = %v, want %v", err, ErrCacheMiss)
+	}
+	if err := loader.Load(context.Background(), "key", ttl, &value, counter.load); err != nil || value != 2 {
+		t.Errorf("Load() after fresh = %d, %v, want 2, nil", value, err)
+	}
+}
+
+func TestJitterTTL(t *testing.T) {
+	ttl := time.Minute
+	if got := JitterTTL(ttl, 0); got != ttl {
+		t.Errorf("JitterTTL(%v, 0) = %v, want %v", ttl, got, ttl)
+	}
+	for i := 0; i < 100; i++ {
+		if got := JitterTTL(ttl, 0.1); got < 54*time.Second || got > 66*time.Second {
+			t.Fatalf("JitterTTL(%v, 0.1) = %v, want within ±10%%", ttl, got)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+/*
+프로세스 안에서만 유지되는 LRU 캐시. 용량을 넘으면 가장 오래 쓰이지 않은 키부터 버림.
+만료된 키는 읽을 때 지우므로 별도의 정리 고루틴은 두지 않음.
+세대 번호는 LRU에서 밀려나면 이전 세대의 값이 다시 읽힐 수 있으므로 따로 보관함.
+*/
+type MemoryCache struct {
+	mu          sync.Mutex
+	capacity    int
+	entries     map[string]*list.Element
+	order       *list.List
+	generations map[string]int64
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity:    capacity,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		generations: make(map[string]int64),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.lookup(key, time.Now())
+	if entry == nil {
+		return nil, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, value, expiresAt(ttl))
+	return nil
+}
+
+func (c *MemoryCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lookup(key, time.Now()) != nil {
+		return false, nil
+	}
+	c.store(key, value, expiresAt(ttl))
+	return true, nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		c.remove(key)
+	}
+	return nil
+}
+
+// Redis INCR와 같이 기존 TTL은 유지하고, 키가 없으면 만료 없이 1부터 시작함
+func (c *MemoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		amount int64
+		until  time.Time
+	)
+	if entry := c.lookup(key, time.Now()); entry != nil {
+		current, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		amount, until = current, entry.expiresAt
+	}
+	amount++
+	c.store(key, []byte(strconv.FormatInt(amount, 10)), until)
+	return amount, nil
+}
+
+func (c *MemoryCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry := c.lookup(key, time.Now()); entry != nil {
+		entry.expiresAt = expiresAt(ttl)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Generation(ctx context.Context, tag string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[tag], nil
+}
+
+func (c *MemoryCache) BumpGeneration(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		c.generations[tag]++
+	}
+	return nil
+}
+
+func (c *MemoryCache) Lock(ctx context.Context, key string, ttl time.Duration) (Unlock, error) {
+	token := []byte(uuid.New().String())
+	locked, err := c.SetNX(ctx, key, token, ttl)
+	if err != nil || !locked {
+		return nil, err
+	}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if entry := c.lookup(key, time.Now()); entry != nil && string(entry.value) == string(token) {
+			c.remove(key)
+		}
+	}, nil
+}
+
+// Redis의 glob 패턴과 같은 방식으로 path.Match를 사용함
+func (c *MemoryCache) Purge(ctx context.Context, pattern string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deleted int64
+	for key := range c.entries {
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return deleted, err
+		}
+		if matched {
+			c.remove(key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (c *MemoryCache) lookup(key string, now time.Time) *memoryEntry {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(now) {
+		c.remove(key)
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return entry
+}
+
+func (c *MemoryCache) store(key string, value []byte, until time.Time) {
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value, entry.expiresAt = value, until
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: until})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		c.remove(c.order.Back().Value.(*memoryEntry).key)
+	}
+}
+
+func (c *MemoryCache) remove(key string) {
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
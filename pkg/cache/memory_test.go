@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2)
+	c.Set(ctx, "a", []byte("a"), 0)
+	c.Set(ctx, "b", []byte("b"), 0)
+
+	// a를 읽었으므로 가장 오래 쓰이지 않은 키는 b가 됨
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("c"), 0)
+
+	tests := []struct {
+		key  string
+		want error
+	}{
+		{"a", nil},
+		{"b", ErrCacheMiss},
+		{"c", nil},
+	}
+	for _, tt := range tests {
+		if _, err := c.Get(ctx, tt.key); err != tt.want {
+			t.Errorf("Get(%s) error = %v, want %v", tt.key, err, tt.want)
+		}
+	}
+}
+
+func TestMemoryCacheKeepsGenerationsOnEviction(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(1)
+	c.BumpGeneration(ctx, "thread")
+	c.Set(ctx, "a", []byte("a"), 0)
+	c.Set(ctx, "b", []byte("b"), 0)
+
+	if gen, _ := c.Generation(ctx, "thread"); gen != 1 {
+		t.Errorf("Generation(thread) = %d, want 1", gen)
+	}
+}
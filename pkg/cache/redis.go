@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const purgeScanCount = 500
+
+// 락이 만료된 뒤 다른 요청이 잡은 락을 지우지 않도록 자신이 건 값일 때만 지움
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return c.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Unlink(ctx, keys...).Err()
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// EXPIRE는 초 단위로 올림하므로 Set과 같이 밀리초 단위로 맞춤
+func (c *RedisCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.client.PExpire(ctx, key, ttl).Err()
+}
+
+func (c *RedisCache) Generation(ctx context.Context, tag string) (int64, error) {
+	generation, err := c.client.Get(ctx, generationKey(tag)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return generation, err
+}
+
+func (c *RedisCache) BumpGeneration(ctx context.Context, tags ...string) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			pipe.Incr(ctx, generationKey(tag))
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *RedisCache) Lock(ctx context.Context, key string, ttl time.Duration) (Unlock, error) {
+	token := uuid.New().String()
+	locked, err := c.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !locked {
+		return nil, err
+	}
+	return func() {
+		unlockScript.Run(context.Background(), c.client, []string{key}, token)
+	}, nil
+}
+
+// 운영 중에도 Redis를 막지 않도록 KEYS 대신 SCAN으로 조금씩 찾아서 UNLINK함
+func (c *RedisCache) Purge(ctx context.Context, pattern string) (int64, error) {
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, nextCursor, err := c.client.Scan(ctx, cursor, pattern, purgeScanCount).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			count, err := c.client.Unlink(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += count
+		}
+		if nextCursor == 0 {
+			return deleted, nil
+		}
+		cursor = nextCursor
+	}
+}
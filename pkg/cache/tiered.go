@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const invalidateChannel = "cache:invalidate"
+
+type invalidateMessage struct {
+	Origin  string   `json:"origin"`
+	Keys    []string `json:"keys,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+/*
+인스턴스마다 가진 LRU(L1) 앞에 두고 Redis(L2)를 공유하는 2단 캐시.
+L1은 localTTL 동안만 유지하고, 값을 바꾸거나 지우면 Pub/Sub으로 다른 인스턴스의 L1도 같이 비움.
+카운터, 세대 번호, 락처럼 인스턴스 사이에 정확히 맞아야 하는 값은 L1을 거치지 않고 L2에서만 다룸.
+*/
+type TieredCache struct {
+	local      *MemoryCache
+	remote     *RedisCache
+	client     *redis.Client
+	localTTL   time.Duration
+	instanceID string
+}
+
+func NewTieredCache(client *redis.Client, capacity int, localTTL time.Duration) *TieredCache {
+	c := &TieredCache{
+		local:      NewMemoryCache(capacity),
+		remote:     NewRedisCache(client),
+		client:     client,
+		localTTL:   localTTL,
+		instanceID: uuid.New().String(),
+	}
+	go c.listen(context.Background())
+	return c
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if data, err := c.local.Get(ctx, key); err == nil {
+		return data, nil
+	}
+
+	data, err := c.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.local.Set(ctx, key, data, c.localTTL)
+	return data, nil
+}
+
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.local.Set(ctx, key, value, c.localExpiry(ttl))
+	c.publish(ctx, invalidateMessage{Keys: []string{key}})
+	return nil
+}
+
+func (c *TieredCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return c.remote.SetNX(ctx, key, value, ttl)
+}
+
+func (c *TieredCache) Delete(ctx context.Context, keys ...string) error {
+	c.local.Delete(ctx, keys...)
+	if err := c.remote.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	c.publish(ctx, invalidateMessage{Keys: keys})
+	return nil
+}
+
+// 카운터는 L2에서만 올리고, 이전 값이 L1에 남지 않도록 지움
+func (c *TieredCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.local.Delete(ctx, key)
+	return c.remote.Incr(ctx, key)
+}
+
+func (c *TieredCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.remote.Expire(ctx, key, ttl)
+}
+
+func (c *TieredCache) Generation(ctx context.Context, tag string) (int64, error) {
+	return c.remote.Generation(ctx, tag)
+}
+
+func (c *TieredCache) BumpGeneration(ctx context.Context, tags ...string) error {
+	return c.remote.BumpGeneration(ctx, tags...)
+}
+
+func (c *TieredCache) Lock(ctx context.Context, key string, ttl time.Duration) (Unlock, error) {
+	return c.remote.Lock(ctx, key, ttl)
+}
+
+func (c *TieredCache) Purge(ctx context.Context, pattern string) (int64, error) {
+	c.local.Purge(ctx, pattern)
+	deleted, err := c.remote.Purge(ctx, pattern)
+	if err != nil {
+		return deleted, err
+	}
+	c.publish(ctx, invalidateMessage{Pattern: pattern})
+	return deleted, nil
+}
+
+// L1이 L2보다 오래 남지 않도록 둘 중 짧은 TTL을 사용함
+func (c *TieredCache) localExpiry(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < c.localTTL {
+		return ttl
+	}
+	return c.localTTL
+}
+
+// 무효화 메시지를 보내지 못하면 다른 인스턴스의 L1은 localTTL이 지나야 맞춰지므로 로그만 남김
+func (c *TieredCache) publish(ctx context.Context, msg invalidateMessage) {
+	msg.Origin = c.instanceID
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	if err := c.client.Publish(ctx, invalidateChannel, payload).Err(); err != nil {
+		log.Printf("cache: failed to publish invalidation: %v", err)
+	}
+}
+
+func (c *TieredCache) listen(ctx context.Context) {
+	pubsub := c.client.Subscribe(ctx, invalidateChannel)
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		var invalidate invalidateMessage
+		if err := json.Unmarshal([]byte(msg.Payload), &invalidate); err != nil || invalidate.Origin == c.instanceID {
+			continue
+		}
+		if len(invalidate.Keys) > 0 {
+			c.local.Delete(ctx, invalidate.Keys...)
+		}
+		if invalidate.Pattern != "" {
+			c.local.Purge(ctx, invalidate.Pattern)
+		}
+	}
+	log.Printf("cache: redis invalidation subscription closed")
+}
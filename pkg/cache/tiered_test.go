@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 구독이 붙기 전에 보낸 무효화 메시지는 사라지므로 두 인스턴스가 모두 구독할 때까지 기다림
+func newTieredPair(t *testing.T) (*TieredCache, *TieredCache) {
+	t.Helper()
+
+	mr, client := newTestRedis(t)
+	a := NewTieredCache(client, 0, time.Minute)
+	b := NewTieredCache(client, 0, time.Minute)
+	waitFor(t, "subscriptions", func() bool {
+		return mr.PubSubNumSub(invalidateChannel)[invalidateChannel] == 2
+	})
+	return a, b
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTieredCacheInvalidatesOtherInstances(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(ctx context.Context, c *TieredCache)
+		want   string
+	}{
+		{"set", func(ctx context.Context, c *TieredCache) { c.Set(ctx, "thread:1", []byte("new"), 0) }, "new"},
+		{"delete", func(ctx context.Context, c *TieredCache) { c.Delete(ctx, "thread:1") }, ""},
+		{"purge", func(ctx context.Context, c *TieredCache) { c.Purge(ctx, "thread:*") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			a, b := newTieredPair(t)
+			a.Set(ctx, "thread:1", []byte("old"), 0)
+
+			// b의 L1에 이전 값을 채워두고, a가 바꾼 뒤 b가 L1이 아닌 바뀐 값을 읽는지 확인함
+			if data, err := b.Get(ctx, "thread:1"); err != nil || string(data) != "old" {
+				t.Fatalf("b.Get() = %q, %v, want %q, nil", data, err, "old")
+			}
+			tt.mutate(ctx, a)
+
+			waitFor(t, "invalidation", func() bool {
+				data, _ := b.Get(ctx, "thread:1")
+				return string(data) == tt.want
+			})
+		})
+	}
+}
+
+// 자기 자신이 보낸 메시지로 방금 채운 L1을 지우면 L1을 두는 의미가 없음
+func TestTieredCacheIgnoresOwnInvalidation(t *testing.T) {
+	ctx := context.Background()
+	a, b := newTieredPair(t)
+	a.Set(ctx, "thread:1", []byte("value"), 0)
+	b.Set(ctx, "thread:2", []byte("sync"), 0)
+
+	// a가 b의 메시지를 받았다면 그보다 먼저 보낸 자신의 메시지도 이미 처리한 상태임
+	waitFor(t, "message from b", func() bool {
+		_, err := a.local.Get(ctx, "thread:2")
+		return err == ErrCacheMiss
+	})
+	if data, err := a.local.Get(ctx, "thread:1"); err != nil || string(data) != "value" {
+		t.Errorf("a.local.Get() = %q, %v, want %q, nil", data, err, "value")
+	}
+}
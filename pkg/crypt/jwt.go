@@ -9,18 +9,28 @@ import (
 	"github.com/kitae0522/gommunity/pkg/exception"
 )
 
+type TokenClaims struct {
+	UUID string
+	Role string
+	// 토큰 발급 시각 (Unix milliseconds)
+	IssuedAt int64
+}
+
 func NewToken(userRole, userID string, secretKey []byte) (string, error) {
 	expiration := time.Duration(config.Envs.JWTExpirationInSeconds) * time.Second
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"role":      userRole,
-		"uuid":      userID,
-		"expiredAt": time.Now().Add(expiration).Unix(),
+		"role":       userRole,
+		"uuid":       userID,
+		"issuedAt":   now.Unix(),
+		"issuedAtMs": now.UnixMilli(),
+		"expiredAt":  now.Add(expiration).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secretKey)
 }
 
-func ParseJWT(jwtToken string) (string, error) {
+func ParseJWT(jwtToken string) (*TokenClaims, error) {
 	token, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, exception.ErrUnexpectedSigningMethod
@@ -29,13 +39,21 @@ func ParseJWT(jwtToken string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if email, ok := claims["uuid"].(string); ok {
-			return email, nil
+		uuid, uuidOk := claims["uuid"].(string)
+		role, roleOk := claims["role"].(string)
+		if uuidOk && roleOk {
+			// issuedAtMs가 없는 이전 토큰은 초 단위 issuedAt을 사용하고, 둘 다 없으면 0으로 취급되어 토큰 폐기 시점 이전에 발급된 것으로 판단됨
+			issuedAt, ok := claims["issuedAtMs"].(float64)
+			if !ok {
+				issuedAtSec, _ := claims["issuedAt"].(float64)
+				issuedAt = issuedAtSec * 1000
+			}
+			return &TokenClaims{UUID: uuid, Role: role, IssuedAt: int64(issuedAt)}, nil
 		}
 	}
-	return "", exception.ErrInvalidTokenClaims
+	return nil, exception.ErrInvalidTokenClaims
 }
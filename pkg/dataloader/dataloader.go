@@ -0,0 +1,193 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("dataloader: key not found")
+
+const (
+	defaultWait     = 2 * time.Millisecond
+	defaultMaxBatch = 100
+)
+
+// 요청한 키 중 찾지 못한 키는 결과 map에서 빠뜨리면 ErrNotFound로 처리됨
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type Option func(*options)
+
+type options struct {
+	wait     time.Duration
+	maxBatch int
+}
+
+// 첫 Load 이후 다른 키가 모이기를 기다리는 시간
+func WithWait(wait time.Duration) Option {
+	return func(o *options) { o.wait = wait }
+}
+
+func WithMaxBatch(maxBatch int) Option {
+	return func(o *options) { o.maxBatch = maxBatch }
+}
+
+type result[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+type batch[K comparable] struct {
+	keys  []K
+	timer *time.Timer
+}
+
+/*
+하나의 요청 안에서 같은 종류의 데이터를 키 단위로 불러올 때 한 번의 조회로 모아서 처리함.
+1. 이미 불러왔거나 불러오는 중인 키는 그 결과를 그대로 사용
+2. 새 키는 대기열에 넣고, wait가 지나거나 maxBatch개가 모이면 BatchFunc를 한 번 호출
+결과를 계속 들고 있으므로 요청마다 새로 만들어 사용해야 하며, 요청 사이에 공유하면 안 됨.
+*/
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K]
+}
+
+func New[K comparable, V any](fetch BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	o := options{wait: defaultWait, maxBatch: defaultMaxBatch}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     o.wait,
+		maxBatch: o.maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res := l.enqueue(ctx, key)
+	l.mu.Unlock()
+	return res.wait(ctx)
+}
+
+// 넘겨받은 키는 wait를 기다리지 않고 바로 조회하며, 찾지 못한 키는 결과 map에서 빠짐
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	l.mu.Lock()
+	pending := make([]*result[V], len(keys))
+	for i, key := range keys {
+		pending[i] = l.enqueue(ctx, key)
+	}
+	l.flush(ctx)
+	l.mu.Unlock()
+
+	values := make(map[K]V, len(keys))
+	for i, res := range pending {
+		value, err := res.wait(ctx)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[keys[i]] = value
+	}
+	return values, nil
+}
+
+// 다른 경로로 이미 불러온 값을 넣어두어 같은 키를 다시 조회하지 않도록 함
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.results[key]; exists {
+		return
+	}
+	res := &result[V]{value: value, done: make(chan struct{})}
+	close(res.done)
+	l.results[key] = res
+}
+
+// l.mu를 잡은 상태에서 호출해야 함
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	if res, exists := l.results[key]; exists {
+		return res
+	}
+
+	res := &result[V]{done: make(chan struct{})}
+	l.results[key] = res
+	if l.pending == nil {
+		l.pending = &batch[K]{}
+		l.pending.timer = time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.flush(ctx)
+		})
+	}
+	l.pending.keys = append(l.pending.keys, key)
+	if len(l.pending.keys) >= l.maxBatch {
+		l.flush(ctx)
+	}
+	return res
+}
+
+// l.mu를 잡은 상태에서 호출해야 하며, 조회는 락을 풀고 백그라운드에서 진행함
+func (l *Loader[K, V]) flush(ctx context.Context) {
+	if l.pending == nil {
+		return
+	}
+	pending := l.pending
+	l.pending = nil
+	pending.timer.Stop()
+
+	results := make([]*result[V], len(pending.keys))
+	for i, key := range pending.keys {
+		results[i] = l.results[key]
+	}
+	go l.dispatch(ctx, pending.keys, results)
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, keys []K, results []*result[V]) {
+	values, err := l.fetch(ctx, keys)
+
+	// 실패한 키는 결과를 남기지 않아 같은 요청 안에서 다시 시도할 수 있도록 함
+	if err != nil {
+		l.mu.Lock()
+		for _, key := range keys {
+			delete(l.results, key)
+		}
+		l.mu.Unlock()
+	}
+
+	for i, key := range keys {
+		res := results[i]
+		switch value, found := values[key]; {
+		case err != nil:
+			res.err = err
+		case !found:
+			res.err = ErrNotFound
+		default:
+			res.value = value
+		}
+		close(res.done)
+	}
+}
+
+func (r *result[V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
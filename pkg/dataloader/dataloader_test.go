@@ -0,0 +1,138 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) fetch(ctx context.Context, keys []int) (map[int]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := append([]int(nil), keys...)
+	sort.Ints(batch)
+	r.batches = append(r.batches, batch)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	values := make(map[int]string, len(keys))
+	for _, key := range keys {
+		// 음수 키는 존재하지 않는 값으로 취급
+		if key >= 0 {
+			values[key] = string(rune('a' + key))
+		}
+	}
+	return values, nil
+}
+
+func (r *recorder) calls() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+func TestLoadBatchesConcurrentKeys(t *testing.T) {
+	rec := &recorder{}
+	loader := New[int, string](rec.fetch, WithWait(20*time.Millisecond))
+
+	var wg sync.WaitGroup
+	for _, key := range []int{0, 1, 2, 1} {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			value, err := loader.Load(context.Background(), key)
+			if err != nil || value != string(rune('a'+key)) {
+				t.Errorf("Load(%d) = %q, %v", key, value, err)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	calls := rec.calls()
+	if len(calls) != 1 || len(calls[0]) != 3 {
+		t.Fatalf("batches = %v, want one batch of [0 1 2]", calls)
+	}
+
+	// 이미 불러온 키는 다시 조회하지 않음
+	if _, err := loader.Load(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	if calls := rec.calls(); len(calls) != 1 {
+		t.Fatalf("batches = %v, want cached result", calls)
+	}
+}
+
+func TestLoadManySkipsMissingKeys(t *testing.T) {
+	rec := &recorder{}
+	loader := New[int, string](rec.fetch, WithWait(time.Hour))
+	loader.Prime(3, "primed")
+
+	values, err := loader.LoadMany(context.Background(), []int{0, -1, 3, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0] != "a" || values[3] != "primed" {
+		t.Fatalf("values = %v", values)
+	}
+	if calls := rec.calls(); len(calls) != 1 || len(calls[0]) != 2 {
+		t.Fatalf("batches = %v, want one batch of [-1 0]", calls)
+	}
+
+	if _, err := loader.Load(context.Background(), -1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Load(-1) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLoadRetriesAfterError(t *testing.T) {
+	rec := &recorder{err: errors.New("db down")}
+	loader := New[int, string](rec.fetch, WithWait(time.Millisecond))
+
+	if _, err := loader.Load(context.Background(), 1); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Load error = %v, want fetch error", err)
+	}
+
+	rec.mu.Lock()
+	rec.err = nil
+	rec.mu.Unlock()
+	if value, err := loader.Load(context.Background(), 1); err != nil || value != "b" {
+		t.Fatalf("Load after recovery = %q, %v", value, err)
+	}
+	if calls := rec.calls(); len(calls) != 2 {
+		t.Fatalf("batches = %v, want retried fetch", calls)
+	}
+}
+
+func TestMaxBatchFlushesEarly(t *testing.T) {
+	rec := &recorder{}
+	loader := New[int, string](rec.fetch, WithWait(time.Hour), WithMaxBatch(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, key := range []int{0, 1} {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			if _, err := loader.Load(ctx, key); err != nil {
+				t.Errorf("Load(%d): %v", key, err)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if calls := rec.calls(); len(calls) != 1 {
+		t.Fatalf("batches = %v, want flush at max batch size", calls)
+	}
+}
@@ -0,0 +1,137 @@
+package exception
+
+import "net/http"
+
+// 응답의 code 필드. 클라이언트가 분기에 사용하므로 한 번 정한 값은 바꾸지 않음
+type Code string
+
+const (
+	CodeBadRequest           Code = "BAD_REQUEST"
+	CodeUnauthorized         Code = "UNAUTHORIZED"
+	CodeForbidden            Code = "FORBIDDEN"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeMethodNotAllowed     Code = "METHOD_NOT_ALLOWED"
+	CodeConflict             Code = "CONFLICT"
+	CodePayloadTooLarge      Code = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeTooManyRequests      Code = "TOO_MANY_REQUESTS"
+	CodeInternal             Code = "INTERNAL_ERROR"
+
+	CodeInvalidParameter        Code = "INVALID_PARAMETER"
+	CodeMissingParameter        Code = "MISSING_PARAMETER"
+	CodeValidationFailed        Code = "VALIDATION_FAILED"
+	CodeInvalidCredentials      Code = "AUTH_INVALID_CREDENTIALS"
+	CodeWrongPassword           Code = "AUTH_WRONG_PASSWORD"
+	CodePasswordConfirmMismatch Code = "AUTH_PASSWORD_CONFIRM_MISMATCH"
+	CodeTokenInvalid            Code = "AUTH_TOKEN_INVALID"
+	CodeTokenRevoked            Code = "AUTH_TOKEN_REVOKED"
+	CodeForbiddenRole           Code = "AUTH_FORBIDDEN_ROLE"
+	CodeUserNotFound            Code = "USER_NOT_FOUND"
+	CodeHandleTaken             Code = "USER_HANDLE_TAKEN"
+	CodeEmailTaken              Code = "USER_EMAIL_TAKEN"
+	CodeUserBanned              Code = "USER_BANNED"
+	CodeIPBanned                Code = "USER_IP_BANNED"
+	CodeBlockedByUser           Code = "USER_BLOCKED"
+	CodeThreadNotFound          Code = "THREAD_NOT_FOUND"
+	CodeThreadLocked            Code = "THREAD_LOCKED"
+	CodeNotRootThread           Code = "THREAD_NOT_ROOT"
+	CodeNotDraft                Code = "THREAD_NOT_DRAFT"
+	CodeFileTooLarge            Code = "UPLOAD_TOO_LARGE"
+	CodeInvalidImage            Code = "UPLOAD_INVALID_IMAGE"
+	CodeQuotaExceeded           Code = "UPLOAD_QUOTA_EXCEEDED"
+	CodeAlreadyAttached         Code = "UPLOAD_ALREADY_ATTACHED"
+	CodeUploadNotOwned          Code = "UPLOAD_NOT_OWNED"
+	CodeNotParticipant          Code = "DM_NOT_PARTICIPANT"
+	CodeInvalidParticipants     Code = "DM_INVALID_PARTICIPANTS"
+	CodeMessageNotFound         Code = "DM_MESSAGE_NOT_FOUND"
+	CodeAlreadyReported         Code = "REPORT_DUPLICATED"
+	CodeInvalidStatusTransition Code = "REPORT_INVALID_TRANSITION"
+	CodeInvalidCIDR             Code = "BAN_INVALID_CIDR"
+	CodePollClosed              Code = "POLL_CLOSED"
+	CodeAlreadyVoted            Code = "POLL_ALREADY_VOTED"
+	CodeInvalidPollOption       Code = "POLL_INVALID_OPTION"
+	CodePollAlreadyExists       Code = "POLL_ALREADY_EXISTS"
+	CodeAlreadyBookmarked       Code = "BOOKMARK_DUPLICATED"
+	CodeFolderNameTaken         Code = "BOOKMARK_FOLDER_DUPLICATED"
+)
+
+// 도메인 에러의 HTTP 상태는 여기서만 정함
+var codeStatus = map[Code]int{
+	CodeBadRequest:           http.StatusBadRequest,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeForbidden:            http.StatusForbidden,
+	CodeNotFound:             http.StatusNotFound,
+	CodeMethodNotAllowed:     http.StatusMethodNotAllowed,
+	CodeConflict:             http.StatusConflict,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	CodeTooManyRequests:      http.StatusTooManyRequests,
+	CodeInternal:             http.StatusInternalServerError,
+
+	CodeInvalidParameter:        http.StatusBadRequest,
+	CodeMissingParameter:        http.StatusBadRequest,
+	CodeValidationFailed:        http.StatusBadRequest,
+	CodeInvalidCredentials:      http.StatusUnauthorized,
+	CodeWrongPassword:           http.StatusBadRequest,
+	CodePasswordConfirmMismatch: http.StatusBadRequest,
+	CodeTokenInvalid:            http.StatusUnauthorized,
+	CodeTokenRevoked:            http.StatusUnauthorized,
+	CodeForbiddenRole:           http.StatusForbidden,
+	CodeUserNotFound:            http.StatusNotFound,
+	CodeHandleTaken:             http.StatusConflict,
+	CodeEmailTaken:              http.StatusConflict,
+	CodeUserBanned:              http.StatusForbidden,
+	CodeIPBanned:                http.StatusForbidden,
+	CodeBlockedByUser:           http.StatusForbidden,
+	CodeThreadNotFound:          http.StatusNotFound,
+	CodeThreadLocked:            http.StatusLocked,
+	CodeNotRootThread:           http.StatusBadRequest,
+	CodeNotDraft:                http.StatusConflict,
+	CodeFileTooLarge:            http.StatusRequestEntityTooLarge,
+	CodeInvalidImage:            http.StatusBadRequest,
+	CodeQuotaExceeded:           http.StatusRequestEntityTooLarge,
+	CodeAlreadyAttached:         http.StatusConflict,
+	CodeUploadNotOwned:          http.StatusForbidden,
+	CodeNotParticipant:          http.StatusNotFound,
+	CodeInvalidParticipants:     http.StatusBadRequest,
+	CodeMessageNotFound:         http.StatusNotFound,
+	CodeAlreadyReported:         http.StatusConflict,
+	CodeInvalidStatusTransition: http.StatusConflict,
+	CodeInvalidCIDR:             http.StatusBadRequest,
+	CodePollClosed:              http.StatusConflict,
+	CodeAlreadyVoted:            http.StatusConflict,
+	CodeInvalidPollOption:       http.StatusBadRequest,
+	CodePollAlreadyExists:       http.StatusConflict,
+	CodeAlreadyBookmarked:       http.StatusConflict,
+	CodeFolderNameTaken:         http.StatusConflict,
+}
+
+// 도메인 에러가 아닌 원인(Repository 에러 등)은 호출한 쪽이 정한 상태로 코드를 정함
+var statusCode = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+}
+
+func StatusOf(code Code) int {
+	if status, exists := codeStatus[code]; exists {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func codeOfStatus(status int) Code {
+	if code, exists := statusCode[status]; exists {
+		return code
+	}
+	if status < http.StatusInternalServerError {
+		return CodeBadRequest
+	}
+	return CodeInternal
+}
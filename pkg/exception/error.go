@@ -1,12 +1,29 @@
 package exception
 
+import "errors"
+
+// 원인이 도메인 에러라면 넘겨받은 상태 대신 에러 코드에 매핑된 상태를 사용함
 func GenerateErrorCtx(statusCode int, errMessage string, err interface{}) *ErrResponseCtx {
-	return &ErrResponseCtx{
+	errCtx := &ErrResponseCtx{
 		IsError:    true,
 		StatusCode: statusCode,
+		Code:       codeOfStatus(statusCode),
 		Message:    errMessage,
-		Error:      getErrorDetail(err),
+		Detail:     getErrorDetail(err),
+	}
+
+	cause, ok := err.(error)
+	if !ok {
+		return errCtx
+	}
+	errCtx.cause = cause
+
+	var domainErr *DomainError
+	if errors.As(cause, &domainErr) {
+		errCtx.Code = domainErr.Code
+		errCtx.StatusCode = StatusOf(domainErr.Code)
 	}
+	return errCtx
 }
 
 func getErrorDetail(err interface{}) interface{} {
@@ -1,29 +1,110 @@
 package exception
 
-import "errors"
-
+/*
+클라이언트가 메시지 문구 대신 분기할 수 있도록 에러마다 코드를 붙임.
+서비스는 아래의 도메인 에러를 넘기기만 하고, HTTP 상태는 code.go의 매핑 한 곳에서 정함.
+*/
 var (
-	ErrUnauthorizedRequest      = errors.New("unauthorized request")
-	ErrInvalidParameter         = errors.New("invalid parameter")
-	ErrIncorrectConfirmPassword = errors.New("incorrect confirm password")
-	ErrWrongPassword            = errors.New("wrong password")
-	ErrUnableToDeleteUser       = errors.New("unable to delete user")
-	ErrUnexpectedSigningMethod  = errors.New("unexpected signing method")
-	ErrInvalidTokenClaims       = errors.New("invalid token claims")
-	ErrMissingParams            = errors.New("missing params")
-	ErrStructConversion         = errors.New("struct conversion error")
+	ErrUnauthorizedRequest      = newDomainError(CodeUnauthorized, "unauthorized request")
+	ErrInvalidToken             = newDomainError(CodeTokenInvalid, "invalid token")
+	ErrInvalidParameter         = newDomainError(CodeInvalidParameter, "invalid parameter")
+	ErrValidation               = newDomainError(CodeValidationFailed, "validation failed")
+	ErrIncorrectConfirmPassword = newDomainError(CodePasswordConfirmMismatch, "incorrect confirm password")
+	ErrInvalidCredentials       = newDomainError(CodeInvalidCredentials, "invalid credentials")
+	ErrWrongPassword            = newDomainError(CodeWrongPassword, "wrong password")
+	ErrUnableToDeleteUser       = newDomainError(CodeInternal, "unable to delete user")
+	ErrUnexpectedSigningMethod  = newDomainError(CodeTokenInvalid, "unexpected signing method")
+	ErrInvalidTokenClaims       = newDomainError(CodeTokenInvalid, "invalid token claims")
+	ErrMissingParams            = newDomainError(CodeMissingParameter, "missing params")
+	ErrStructConversion         = newDomainError(CodeInternal, "struct conversion error")
+	ErrUserNotFound             = newDomainError(CodeUserNotFound, "user not found")
+	ErrHandleTaken              = newDomainError(CodeHandleTaken, "handle already taken")
+	ErrEmailTaken               = newDomainError(CodeEmailTaken, "email already taken")
+	ErrThreadNotFound           = newDomainError(CodeThreadNotFound, "thread not found")
+	ErrNotParticipant           = newDomainError(CodeNotParticipant, "not a participant of conversation")
+	ErrInvalidParticipants      = newDomainError(CodeInvalidParticipants, "invalid participants")
+	ErrMessageNotFound          = newDomainError(CodeMessageNotFound, "message not found in conversation")
+	ErrBlockedByUser            = newDomainError(CodeBlockedByUser, "blocked by user")
+	ErrForbiddenRole            = newDomainError(CodeForbiddenRole, "forbidden role")
+	ErrAlreadyReported          = newDomainError(CodeAlreadyReported, "already reported")
+	ErrInvalidStatusTransition  = newDomainError(CodeInvalidStatusTransition, "invalid status transition")
+	ErrThreadLocked             = newDomainError(CodeThreadLocked, "thread is locked")
+	ErrNotRootThread            = newDomainError(CodeNotRootThread, "not a root thread")
+	ErrFileTooLarge             = newDomainError(CodeFileTooLarge, "file too large")
+	ErrUnsupportedMediaType     = newDomainError(CodeUnsupportedMediaType, "unsupported media type")
+	ErrInvalidImage             = newDomainError(CodeInvalidImage, "invalid image")
+	ErrQuotaExceeded            = newDomainError(CodeQuotaExceeded, "storage quota exceeded")
+	ErrAlreadyAttached          = newDomainError(CodeAlreadyAttached, "upload already attached")
+	ErrUploadNotOwned           = newDomainError(CodeUploadNotOwned, "upload not owned")
+	ErrTokenRevoked             = newDomainError(CodeTokenRevoked, "token revoked")
+	ErrUserBanned               = newDomainError(CodeUserBanned, "user banned")
+	ErrIPBanned                 = newDomainError(CodeIPBanned, "ip banned")
+	ErrInvalidCIDR              = newDomainError(CodeInvalidCIDR, "invalid cidr")
+	ErrPollClosed               = newDomainError(CodePollClosed, "poll closed")
+	ErrAlreadyVoted             = newDomainError(CodeAlreadyVoted, "already voted")
+	ErrInvalidPollOption        = newDomainError(CodeInvalidPollOption, "invalid poll option")
+	ErrPollAlreadyExists        = newDomainError(CodePollAlreadyExists, "poll already exists")
+	ErrNotDraft                 = newDomainError(CodeNotDraft, "thread is not a draft")
+	ErrAlreadyBookmarked        = newDomainError(CodeAlreadyBookmarked, "already bookmarked")
+	ErrFolderNameTaken          = newDomainError(CodeFolderNameTaken, "bookmark folder name taken")
 )
 
+// 패키지 변수로만 만들어서 errors.Is로 비교함
+type DomainError struct {
+	Code    Code
+	message string
+}
+
+func newDomainError(code Code, message string) *DomainError {
+	return &DomainError{Code: code, message: message}
+}
+
+func (e *DomainError) Error() string {
+	return e.message
+}
+
 type ErrValidateResult struct {
-	IsError bool
-	Field   string
-	Tag     string
-	Value   interface{}
+	IsError bool        `json:"isError"`
+	Field   string      `json:"field"`
+	Tag     string      `json:"tag"`
+	Value   interface{} `json:"value,omitempty"`
+	Message string      `json:"message"`
 }
 
+/*
+서비스, 컨트롤러, 미들웨어가 그대로 반환하는 에러이자 응답 본문.
+Error 필드는 기존 응답의 "error" 키를 유지하기 위해 Detail로 이름만 바꿨으며, 원인 에러는 Unwrap으로 꺼낼 수 있음.
+*/
 type ErrResponseCtx struct {
 	IsError    bool        `json:"isError"`
 	StatusCode int         `json:"statusCode"`
+	Code       Code        `json:"code"`
 	Message    string      `json:"message"`
-	Error      interface{} `json:"error"`
+	Detail     interface{} `json:"error"`
+	cause      error
+}
+
+func (e *ErrResponseCtx) Error() string {
+	return e.Message
+}
+
+func (e *ErrResponseCtx) Unwrap() error {
+	return e.cause
+}
+
+// 코드와 상태는 그대로 두고 응답에 내려줄 상세 정보(검증 결과, 정지 사유 등)만 바꿈
+func (e *ErrResponseCtx) WithDetail(detail interface{}) *ErrResponseCtx {
+	e.Detail = detail
+	return e
+}
+
+// RFC 7807 (application/problem+json) 형식. code와 error는 확장 필드로 함께 내려줌
+type ProblemDetail struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail"`
+	Instance string      `json:"instance,omitempty"`
+	Code     Code        `json:"code"`
+	Error    interface{} `json:"error,omitempty"`
 }
@@ -0,0 +1,59 @@
+package exception
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kitae0522/gommunity/pkg/i18n"
+)
+
+const problemContentType = "application/problem+json"
+
+// 라우트 없음, 본문 크기 초과 등 컨트롤러에 닿기 전에 생긴 에러의 메시지
+var statusMessages = map[int]string{
+	http.StatusNotFound:              "❌ 요청한 경로를 찾을 수 없습니다.",
+	http.StatusMethodNotAllowed:      "❌ 허용되지 않은 메소드입니다.",
+	http.StatusRequestEntityTooLarge: "❌ 요청 본문이 너무 큽니다.",
+	http.StatusUnsupportedMediaType:  "❌ 지원하지 않는 Content-Type입니다.",
+	http.StatusTooManyRequests:       "❌ 요청이 너무 많습니다. 잠시 후 다시 시도해주세요.",
+}
+
+/*
+컨트롤러와 미들웨어는 에러를 그대로 반환하고, 응답 형식은 여기서 한 번에 정함.
+메시지는 요청한 언어로 바꾸며, Accept 헤더가 application/problem+json을 원하면 RFC 7807 형식으로, 그 외에는 기존 응답 형식으로 내려줌.
+*/
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	errCtx := *FromError(err)
+	errCtx.Message = i18n.T(ctx, errCtx.Message)
+	if ctx.Accepts(fiber.MIMEApplicationJSON, problemContentType) == problemContentType {
+		return ctx.Status(errCtx.StatusCode).JSON(ProblemDetail{
+			Type:     "about:blank",
+			Title:    http.StatusText(errCtx.StatusCode),
+			Status:   errCtx.StatusCode,
+			Detail:   errCtx.Message,
+			Instance: ctx.OriginalURL(),
+			Code:     errCtx.Code,
+			Error:    errCtx.Detail,
+		}, problemContentType)
+	}
+	return ctx.Status(errCtx.StatusCode).JSON(&errCtx)
+}
+
+func FromError(err error) *ErrResponseCtx {
+	var errCtx *ErrResponseCtx
+	if errors.As(err, &errCtx) {
+		return errCtx
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		message, exists := statusMessages[fiberErr.Code]
+		if !exists {
+			message = "❌ 요청을 처리하지 못했습니다."
+		}
+		return GenerateErrorCtx(fiberErr.Code, message, fiberErr.Message)
+	}
+	return GenerateErrorCtx(http.StatusInternalServerError, "❌ 서버에서 요청을 처리하지 못했습니다.", err)
+}
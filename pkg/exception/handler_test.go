@@ -0,0 +1,104 @@
+package exception
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGenerateErrorCtxUsesDomainStatus(t *testing.T) {
+	errCtx := GenerateErrorCtx(fiber.StatusBadRequest, "❌ 로그인 실패.", fmt.Errorf("login: %w", ErrInvalidCredentials))
+	if errCtx.StatusCode != fiber.StatusUnauthorized || errCtx.Code != CodeInvalidCredentials {
+		t.Fatalf("errCtx = %+v, want 401 %s", errCtx, CodeInvalidCredentials)
+	}
+	if !errors.Is(errCtx, ErrInvalidCredentials) {
+		t.Error("errCtx does not unwrap to its cause")
+	}
+
+	errCtx = GenerateErrorCtx(fiber.StatusNotFound, "❌ 조회 실패.", errors.New("record not found"))
+	if errCtx.StatusCode != fiber.StatusNotFound || errCtx.Code != CodeNotFound {
+		t.Fatalf("errCtx = %+v, want 404 %s", errCtx, CodeNotFound)
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/thread", func(ctx *fiber.Ctx) error {
+		return GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", ErrThreadNotFound)
+	})
+	app.Get("/fail", func(ctx *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+
+	tests := []struct {
+		path, accept string
+		status       int
+		contentType  string
+		code         Code
+	}{
+		{"/thread", "", fiber.StatusNotFound, fiber.MIMEApplicationJSON, CodeThreadNotFound},
+		{"/thread", problemContentType, fiber.StatusNotFound, problemContentType, CodeThreadNotFound},
+		{"/missing", "", fiber.StatusNotFound, fiber.MIMEApplicationJSON, CodeNotFound},
+		{"/fail", "application/json, application/problem+json;q=0.5", fiber.StatusInternalServerError, fiber.MIMEApplicationJSON, CodeInternal},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", tt.path, err)
+		}
+
+		var body struct {
+			Code   Code `json:"code"`
+			Status int  `json:"status"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("GET %s: decode body: %v", tt.path, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status || body.Code != tt.code {
+			t.Errorf("GET %s (Accept %q) = %d %s, want %d %s", tt.path, tt.accept, resp.StatusCode, body.Code, tt.status, tt.code)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != tt.contentType {
+			t.Errorf("GET %s (Accept %q) Content-Type = %q, want %q", tt.path, tt.accept, contentType, tt.contentType)
+		}
+		if tt.contentType == problemContentType && body.Status != tt.status {
+			t.Errorf("GET %s problem status = %d, want %d", tt.path, body.Status, tt.status)
+		}
+	}
+}
+
+func TestErrorHandlerTranslatesMessage(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/thread", func(ctx *fiber.Ctx) error {
+		return GenerateErrorCtx(fiber.StatusNotFound, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", ErrThreadNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/thread", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en-US,en;q=0.9")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body ErrResponseCtx
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if want := "❌ Thread lookup failed. The thread does not exist."; body.Message != want {
+		t.Errorf("message = %q, want %q", body.Message, want)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentLanguage); got != "en" {
+		t.Errorf("Content-Language = %q, want en", got)
+	}
+}
@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ko"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+)
+
+const (
+	Korean  = "ko"
+	English = "en"
+)
+
+//go:embed locales/*.json
+var catalogFiles embed.FS
+
+/*
+응답 메시지는 gettext처럼 한국어 원문을 그대로 키로 사용함.
+서비스와 컨트롤러는 지금처럼 한국어로 메시지를 만들고, 응답을 내려주기 직전에 요청한 언어의 카탈로그(locales/{locale}.json)에서 원문을 찾아 바꿈.
+"❌ {0} 실패. {1}"처럼 {n}이 들어간 키는 패턴으로 비교하며, 패턴에서 뽑아낸 값(기능 이름, 실패 사유 등)도 다시 번역함.
+한국어는 원문이므로 메시지 카탈로그 없이 검증 메시지와 단위만 가지고 있음.
+*/
+var (
+	universal *ut.UniversalTranslator
+	patterns  = make(map[string][]pattern)
+	supported = []language.Tag{language.Korean, language.English}
+	matcher   = language.NewMatcher(supported)
+)
+
+var placeholder = regexp.MustCompile(`\{\d+\}`)
+
+type pattern struct {
+	key     string
+	re      *regexp.Regexp
+	literal int
+}
+
+func init() {
+	universal = ut.New(ko.New(), ko.New(), en.New())
+
+	names, err := fs.Glob(catalogFiles, "locales/*.json")
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range names {
+		if err := loadCatalog(name); err != nil {
+			panic(fmt.Sprintf("i18n: failed to load %s: %v", name, err))
+		}
+	}
+	if err := universal.VerifyTranslations(); err != nil {
+		panic(fmt.Sprintf("i18n: incomplete plural translations: %v", err))
+	}
+
+	// 고정된 글자가 많은 패턴일수록 구체적이므로 먼저 비교함
+	for _, localePatterns := range patterns {
+		sort.SliceStable(localePatterns, func(i, j int) bool {
+			return localePatterns[i].literal > localePatterns[j].literal
+		})
+	}
+}
+
+func loadCatalog(name string) error {
+	data, err := catalogFiles.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := universal.ImportByReader(ut.FormatJSON, bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	var entries []struct {
+		Locale string `json:"locale"`
+		Key    string `json:"key"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if placeholder.MatchString(entry.Key) {
+			patterns[entry.Locale] = append(patterns[entry.Locale], newPattern(entry.Key))
+		}
+	}
+	return nil
+}
+
+func newPattern(key string) pattern {
+	parts := placeholder.Split(key, -1)
+	literal := 0
+	for i, part := range parts {
+		literal += len(part)
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return pattern{
+		key:     key,
+		re:      regexp.MustCompile("^" + strings.Join(parts, "(.+?)") + "$"),
+		literal: literal,
+	}
+}
+
+/*
+카탈로그에 없는 메시지나 값(사용자가 입력한 사유, 숫자 등)은 그대로 돌려줌.
+{n}이 들어간 문자열은 패턴 키와 같더라도 채울 값이 없으므로 번역하지 않음.
+*/
+func Translate(locale, message string) string {
+	trans, found := universal.GetTranslator(locale)
+	if !found || placeholder.MatchString(message) {
+		return message
+	}
+	if translated, err := trans.T(message); err == nil {
+		return translated
+	}
+
+	for _, p := range patterns[locale] {
+		matches := p.re.FindStringSubmatch(message)
+		if matches == nil {
+			continue
+		}
+		params := matches[1:]
+		for i, param := range params {
+			params[i] = Translate(locale, param)
+		}
+		if translated, err := trans.T(p.key, params...); err == nil {
+			return translated
+		}
+	}
+	return message
+}
+
+// 지원하지 않는 언어만 요청한 경우에는 한국어를 사용함
+func Negotiate(preferences ...string) string {
+	_, index := language.MatchStrings(matcher, preferences...)
+	return supported[index].String()
+}
+
+/*
+요청의 언어는 아래 순서로 정하고, 같은 요청 안에서는 처음 정한 값을 계속 사용함.
+1. lang 쿼리 (ex. ?lang=en)
+2. lang 쿠키 (클라이언트에 저장해둔 사용자 설정)
+3. Accept-Language 헤더
+*/
+func Locale(ctx *fiber.Ctx) string {
+	if locale, ok := ctx.Locals("locale").(string); ok {
+		return locale
+	}
+
+	locale := Negotiate(ctx.Query("lang"), ctx.Cookies("lang"), ctx.Get(fiber.HeaderAcceptLanguage))
+	ctx.Locals("locale", locale)
+	ctx.Set(fiber.HeaderContentLanguage, locale)
+	ctx.Vary(fiber.HeaderAcceptLanguage)
+	return locale
+}
+
+func T(ctx *fiber.Ctx, message string) string {
+	return Translate(Locale(ctx), message)
+}
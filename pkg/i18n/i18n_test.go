@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		locale, message, want string
+	}{
+		{English, "✅ 로그인 완료", "✅ Login completed"},
+		{English, "❌ 쓰레드 조회 실패. 존재하지 않는 쓰레드입니다.", "❌ Thread lookup failed. The thread does not exist."},
+		{English, "❌ 파일 업로드 실패. 파일은 10MB를 넘을 수 없습니다.", "❌ File upload failed. Files cannot exceed 10MB."},
+		{English, "❌ 정지된 계정입니다. 사유: 광고 (영구 정지)", "❌ This account is suspended. Reason: 광고 (permanently)"},
+		{English, "❌ 알 수 없는 메시지", "❌ 알 수 없는 메시지"},
+		{English, "❌ {0} 실패. {1}", "❌ {0} 실패. {1}"},
+		{Korean, "✅ 로그인 완료", "✅ 로그인 완료"},
+		{"fr", "✅ 로그인 완료", "✅ 로그인 완료"},
+	}
+	for _, tt := range tests {
+		if got := Translate(tt.locale, tt.message); got != tt.want {
+			t.Errorf("Translate(%s, %q) = %q, want %q", tt.locale, tt.message, got, tt.want)
+		}
+	}
+}
+
+// universal-translator는 {n}이 번호 순서대로 한 번씩만 나온다고 가정하므로 번역문도 같은 규칙을 지켜야 함
+func TestCatalogPlaceholders(t *testing.T) {
+	names, err := catalogFiles.ReadDir("locales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		data, err := catalogFiles.ReadFile("locales/" + name.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []struct {
+			Key   string `json:"key"`
+			Trans string `json:"trans"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			t.Fatalf("%s: %v", name.Name(), err)
+		}
+
+		for _, entry := range entries {
+			keyParams := len(placeholder.FindAllString(entry.Key, -1))
+			params := placeholder.FindAllString(entry.Trans, -1)
+			if keyParams > 0 && len(params) > keyParams {
+				t.Errorf("%s: %q uses %d params, key has %d", name.Name(), entry.Trans, len(params), keyParams)
+			}
+			for i := 1; i < len(params); i++ {
+				if params[i] <= params[i-1] {
+					t.Errorf("%s: %q params are out of order", name.Name(), entry.Trans)
+				}
+			}
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(Locale(ctx))
+	})
+
+	tests := []struct {
+		query, cookie, acceptLanguage, want string
+	}{
+		{"", "", "", Korean},
+		{"", "", "en-US,en;q=0.9", English},
+		{"", "", "fr-FR, en;q=0.5, ko;q=0.8", Korean},
+		{"", "", "fr-FR", Korean},
+		{"", "en", "ko-KR", English},
+		{"ko", "en", "en-US", Korean},
+	}
+	for _, tt := range tests {
+		target := "/"
+		if tt.query != "" {
+			target += "?lang=" + tt.query
+		}
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+		}
+		if tt.acceptLanguage != "" {
+			req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+		}
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := make([]byte, 8)
+		n, _ := resp.Body.Read(body)
+		resp.Body.Close()
+
+		if got := string(body[:n]); got != tt.want {
+			t.Errorf("query %q, cookie %q, Accept-Language %q = %s, want %s", tt.query, tt.cookie, tt.acceptLanguage, got, tt.want)
+		}
+		if got := resp.Header.Get(fiber.HeaderContentLanguage); got != tt.want {
+			t.Errorf("Content-Language = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestTranslateRule(t *testing.T) {
+	type payload struct {
+		Title   string   `validate:"required"`
+		Handle  string   `validate:"min=1"`
+		Content string   `validate:"max=3"`
+		Options []string `validate:"min=2"`
+		Status  string   `validate:"oneof=OPEN CLOSED"`
+		Code    string   `validate:"alphanum"`
+	}
+	err := validator.New().Struct(payload{Content: "long", Options: []string{"a"}, Status: "x", Code: "-"})
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("err = %v, want ValidationErrors", err)
+	}
+
+	want := map[string]map[string]string{
+		English: {
+			"Title":   "Title is required",
+			"Handle":  "Handle must be at least 1 character",
+			"Content": "Content must be at most 3 characters",
+			"Options": "Options must be at least 2 items",
+			"Status":  "Status must be one of [OPEN CLOSED]",
+			"Code":    "Code failed on the 'alphanum' rule",
+		},
+		Korean: {
+			"Title":   "Title은(는) 필수 항목입니다.",
+			"Handle":  "Handle은(는) 최소 1자 이상이어야 합니다.",
+			"Content": "Content은(는) 최대 3자까지 허용됩니다.",
+			"Options": "Options은(는) 최소 2개 이상이어야 합니다.",
+		},
+	}
+	for locale, messages := range want {
+		for _, fe := range fieldErrs {
+			expected, exists := messages[fe.Field()]
+			if !exists {
+				continue
+			}
+			if got := TranslateRule(locale, fe.Tag(), fe.Field(), fe.Param(), fe.Kind()); got != expected {
+				t.Errorf("%s %s = %q, want %q", locale, fe.Field(), got, expected)
+			}
+		}
+	}
+
+	if got := TranslateRule("fr", "required", "title", "", reflect.String); !strings.HasPrefix(got, "title") {
+		t.Errorf("unsupported locale = %q, want fallback translation", got)
+	}
+}
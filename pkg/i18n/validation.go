@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"reflect"
+	"strconv"
+)
+
+/*
+검증 메시지는 validation.{tag} 키로 찾고, 카탈로그에 없는 태그는 validation.default를 사용함.
+문자열 길이나 목록 개수 제한은 단위(unit.character, unit.item)를 언어의 복수형 규칙에 맞춰 붙임. (ex. 1 character, 8 characters)
+validator의 규칙뿐만 아니라 본문을 읽는 과정에서 생긴 오류(type, unknown, body)도 같은 방식으로 번역함.
+*/
+func TranslateRule(locale, tag, field, param string, kind reflect.Kind) string {
+	trans, found := universal.GetTranslator(locale)
+	if !found {
+		trans = universal.GetFallback()
+	}
+
+	if unit, ok := lengthUnit(tag, kind); ok {
+		if num, err := strconv.ParseFloat(param, 64); err == nil {
+			if counted, err := trans.C(unit, num, 0, trans.FmtNumber(num, 0)); err == nil {
+				param = counted
+			}
+		}
+	}
+
+	if message, err := trans.T("validation."+tag, field, param); err == nil {
+		return message
+	}
+	message, _ := trans.T("validation.default", field, tag)
+	return message
+}
+
+func lengthUnit(tag string, kind reflect.Kind) (string, bool) {
+	switch tag {
+	case "min", "max", "len", "gt", "gte", "lt", "lte", "maxcontent":
+	default:
+		return "", false
+	}
+
+	switch kind {
+	case reflect.String:
+		return "unit.character", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "unit.item", true
+	}
+	return "", false
+}
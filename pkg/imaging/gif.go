@@ -0,0 +1,73 @@
+package imaging
+
+import (
+	"errors"
+)
+
+var errMalformedGIF = errors.New("malformed gif")
+
+/*
+GIF 전체를 디코딩하기 전에 블록 구조만 따라가며 프레임(Image Descriptor) 수를 셈.
+프레임 데이터는 하위 블록 길이만 보고 건너뛰므로 LZW 압축을 풀지 않음.
+limit를 넘는 순간 더 읽지 않고 그때까지 센 값을 반환함.
+*/
+func countGIFFrames(data []byte, limit int) (int, error) {
+	// Header(6) + Logical Screen Descriptor(7)
+	if len(data) < 13 {
+		return 0, errMalformedGIF
+	}
+	offset := 13 + colorTableSize(data[10])
+
+	frames := 0
+	for frames <= limit {
+		if offset >= len(data) {
+			return 0, errMalformedGIF
+		}
+		switch data[offset] {
+		case 0x21: // Extension: 라벨 다음에 하위 블록이 이어짐
+			next, err := skipSubBlocks(data, offset+2)
+			if err != nil {
+				return 0, err
+			}
+			offset = next
+		case 0x2C: // Image Descriptor(10) + Local Color Table + LZW 최소 코드 크기(1) + 하위 블록
+			if offset+10 > len(data) {
+				return 0, errMalformedGIF
+			}
+			next, err := skipSubBlocks(data, offset+10+colorTableSize(data[offset+9])+1)
+			if err != nil {
+				return 0, err
+			}
+			offset = next
+			frames++
+		case 0x3B: // Trailer
+			return frames, nil
+		default:
+			return 0, errMalformedGIF
+		}
+	}
+	return frames, nil
+}
+
+// 최상위 비트가 색상표 유무, 하위 3비트가 크기(2^(n+1)개, 색상당 3바이트)
+func colorTableSize(packed byte) int {
+	if packed&0x80 == 0 {
+		return 0
+	}
+	return 3 << ((packed & 0x07) + 1)
+}
+
+// 길이가 0인 블록(Block Terminator)이 나올 때까지 건너뛰고 다음 블록의 위치를 반환함
+func skipSubBlocks(data []byte, offset int) (int, error) {
+	for {
+		if offset >= len(data) {
+			return 0, errMalformedGIF
+		}
+		size := int(data[offset])
+		offset++
+		if size == 0 {
+			return offset, nil
+		}
+		offset += size
+	}
+}
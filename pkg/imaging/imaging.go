@@ -0,0 +1,176 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrDimensionTooLarge = errors.New("image dimension too large")
+	ErrTooManyFrames     = errors.New("too many animation frames")
+	ErrTooManyPixels     = errors.New("too many pixels")
+)
+
+const maxGIFFrames = 300
+
+var contentTypeToFormat = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+/*
+MaxPixels는 디코딩에 쓰는 메모리를 제한하기 위한 전체 픽셀 수 상한.
+JPEG, PNG는 가로×세로, GIF는 가로×세로×프레임 수로 계산함.
+*/
+type Options struct {
+	MaxDimension  int
+	MaxPixels     int
+	ThumbnailSize int
+	JPEGQuality   int
+}
+
+type Processed struct {
+	Data                 []byte
+	Width                int
+	Height               int
+	Thumbnail            []byte
+	ThumbnailContentType string
+}
+
+/*
+업로드된 이미지를 다시 인코딩해서 저장용 바이트와 썸네일을 만듦.
+디코딩 후 픽셀만 다시 인코딩하므로 EXIF(GPS 등), PNG 텍스트 청크, GIF 주석 같은 메타데이터는 모두 제거됨.
+EXIF를 지우면 회전 정보도 사라지므로 JPEG은 Orientation 태그를 먼저 픽셀에 적용한 뒤 인코딩함.
+*/
+func Process(data []byte, contentType string, opts Options) (*Processed, error) {
+	format, ok := contentTypeToFormat[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// 큰 이미지를 통째로 디코딩하기 전에 헤더만 읽어 크기를 확인
+	config, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if decodedFormat != format {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width > opts.MaxDimension || config.Height > opts.MaxDimension {
+		return nil, ErrDimensionTooLarge
+	}
+	// 디코딩한 이미지를 RGBA로 한 번 더 복사하므로 가로, 세로가 각각 제한 안이어도 전체 픽셀 수를 따로 제한함
+	frames := 1
+	if format == "gif" {
+		if frames, err = countGIFFrames(data, maxGIFFrames); err != nil {
+			return nil, err
+		}
+		if frames > maxGIFFrames {
+			return nil, ErrTooManyFrames
+		}
+	}
+	if config.Width*config.Height*frames > opts.MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	switch format {
+	case "jpeg":
+		return processJPEG(data, opts)
+	case "png":
+		return processPNG(data, opts)
+	default:
+		return processGIF(data, opts)
+	}
+}
+
+func processJPEG(data []byte, opts Options) (*Processed, error) {
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	img := applyOrientation(toRGBA(decoded), jpegOrientation(data))
+
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: opts.JPEGQuality}); err != nil {
+		return nil, err
+	}
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, Fit(img, opts.ThumbnailSize), &jpeg.Options{Quality: opts.JPEGQuality}); err != nil {
+		return nil, err
+	}
+
+	return &Processed{
+		Data:                 encoded.Bytes(),
+		Width:                img.Rect.Dx(),
+		Height:               img.Rect.Dy(),
+		Thumbnail:            thumbnail.Bytes(),
+		ThumbnailContentType: "image/jpeg",
+	}, nil
+}
+
+func processPNG(data []byte, opts Options) (*Processed, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return nil, err
+	}
+	var thumbnail bytes.Buffer
+	if err := png.Encode(&thumbnail, Fit(img, opts.ThumbnailSize)); err != nil {
+		return nil, err
+	}
+
+	return &Processed{
+		Data:                 encoded.Bytes(),
+		Width:                img.Bounds().Dx(),
+		Height:               img.Bounds().Dy(),
+		Thumbnail:            thumbnail.Bytes(),
+		ThumbnailContentType: "image/png",
+	}, nil
+}
+
+func processGIF(data []byte, opts Options) (*Processed, error) {
+	// 프레임 수와 전체 픽셀 수는 Process에서 블록 구조만 읽어 미리 확인함
+	animation, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// 애니메이션은 유지하고, 주석/애플리케이션 확장 블록은 EncodeAll에서 다시 쓰지 않으므로 제거됨
+	var encoded bytes.Buffer
+	if err := gif.EncodeAll(&encoded, animation); err != nil {
+		return nil, err
+	}
+	var thumbnail bytes.Buffer
+	if err := png.Encode(&thumbnail, Fit(animation.Image[0], opts.ThumbnailSize)); err != nil {
+		return nil, err
+	}
+
+	return &Processed{
+		Data:                 encoded.Bytes(),
+		Width:                animation.Config.Width,
+		Height:               animation.Config.Height,
+		Thumbnail:            thumbnail.Bytes(),
+		ThumbnailContentType: "image/png",
+	}, nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Rect, src, bounds.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,170 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var testOptions = Options{MaxDimension: 100, MaxPixels: 100 * 100, ThumbnailSize: 16, JPEGQuality: 90}
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 10), G: uint8(y * 10), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T, width, height, frames int) []byte {
+	t.Helper()
+	animation := &gif.GIF{LoopCount: 0}
+	for i := 0; i < frames; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.Black, color.White})
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// SOI 바로 뒤에 Orientation=6(시계 방향 90도)과 GPS 흔적을 담은 APP1(Exif) 세그먼트를 끼워 넣음
+func withExif(jpegData []byte) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = append(tiff, 0x00, 0x01)                                     // IFD 항목 수
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01) // Orientation, SHORT, 1개
+	tiff = append(tiff, 0x00, 0x06, 0x00, 0x00)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00)
+	tiff = append(tiff, []byte("GPS 37.5665N 126.9780E")...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, app1...)
+	return append(out, jpegData[2:]...)
+}
+
+// IHDR 청크 뒤에 tEXt 청크를 끼워 넣음
+func withTextChunk(pngData []byte, text string) []byte {
+	chunk := make([]byte, 8, 12+len(text))
+	binary.BigEndian.PutUint32(chunk, uint32(len(text)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	ihdrEnd := 8 + 8 + 13 + 4
+	out := append([]byte{}, pngData[:ihdrEnd]...)
+	out = append(out, chunk...)
+	return append(out, pngData[ihdrEnd:]...)
+}
+
+func TestProcessStripsMetadata(t *testing.T) {
+	t.Run("jpeg exif", func(t *testing.T) {
+		data := withExif(encodeJPEG(t, testImage(20, 10)))
+		if jpegOrientation(data) != 6 {
+			t.Fatal("test image has no orientation tag")
+		}
+
+		processed, err := Process(data, "image/jpeg", testOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(processed.Data, []byte("Exif")) || bytes.Contains(processed.Data, []byte("GPS")) {
+			t.Error("processed jpeg still contains EXIF data")
+		}
+		// Orientation을 픽셀에 적용했으므로 가로, 세로가 바뀜
+		if processed.Width != 10 || processed.Height != 20 {
+			t.Errorf("size = %dx%d, want 10x20", processed.Width, processed.Height)
+		}
+	})
+
+	t.Run("png text chunk", func(t *testing.T) {
+		data := withTextChunk(encodePNG(t, testImage(20, 10)), "Comment\x00secret location")
+		if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+			t.Fatalf("test image is not a valid png: %v", err)
+		}
+
+		processed, err := Process(data, "image/png", testOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(processed.Data, []byte("tEXt")) || bytes.Contains(processed.Data, []byte("secret location")) {
+			t.Error("processed png still contains the text chunk")
+		}
+	})
+}
+
+func TestProcessLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		opts        Options
+		want        error
+	}{
+		{"jpeg within limits", encodeJPEG(t, testImage(100, 50)), "image/jpeg", testOptions, nil},
+		{"jpeg too wide", encodeJPEG(t, testImage(101, 10)), "image/jpeg", testOptions, ErrDimensionTooLarge},
+		{"jpeg too many pixels", encodeJPEG(t, testImage(80, 80)), "image/jpeg", Options{MaxDimension: 100, MaxPixels: 80*80 - 1, ThumbnailSize: 16}, ErrTooManyPixels},
+		{"png too many pixels", encodePNG(t, testImage(80, 80)), "image/png", Options{MaxDimension: 100, MaxPixels: 80*80 - 1, ThumbnailSize: 16}, ErrTooManyPixels},
+		{"gif within limits", encodeGIF(t, 10, 10, 5), "image/gif", testOptions, nil},
+		{"gif too many frames", encodeGIF(t, 1, 1, maxGIFFrames+1), "image/gif", testOptions, ErrTooManyFrames},
+		{"gif too many pixels across frames", encodeGIF(t, 10, 10, 101), "image/gif", testOptions, ErrTooManyPixels},
+		{"content type mismatch", encodePNG(t, testImage(10, 10)), "image/jpeg", testOptions, ErrUnsupportedFormat},
+		{"unsupported type", []byte("BM"), "image/bmp", testOptions, ErrUnsupportedFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Process(tt.data, tt.contentType, tt.opts)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountGIFFrames(t *testing.T) {
+	data := encodeGIF(t, 4, 4, 7)
+	if frames, err := countGIFFrames(data, maxGIFFrames); err != nil || frames != 7 {
+		t.Errorf("frames = %d, %v, want 7", frames, err)
+	}
+	// limit를 넘으면 나머지 블록은 읽지 않음
+	if frames, err := countGIFFrames(data, 2); err != nil || frames != 3 {
+		t.Errorf("frames with limit = %d, %v, want 3", frames, err)
+	}
+	if _, err := countGIFFrames(data[:len(data)-10], maxGIFFrames); err == nil {
+		t.Error("truncated gif was accepted")
+	}
+}
@@ -0,0 +1,56 @@
+package imaging
+
+import "image"
+
+// 긴 변이 maxSide를 넘지 않도록 비율을 유지하며 축소. 이미 작은 이미지는 그대로 반환
+func Fit(src image.Image, maxSide int) image.Image {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	if maxSide <= 0 || (width <= maxSide && height <= maxSide) {
+		return src
+	}
+
+	dstWidth, dstHeight := maxSide, maxSide
+	if width >= height {
+		dstHeight = max(1, height*maxSide/width)
+	} else {
+		dstWidth = max(1, width*maxSide/height)
+	}
+	return resize(toRGBA(src), dstWidth, dstHeight)
+}
+
+/*
+박스 필터 축소. 결과 픽셀 하나에 대응하는 원본 영역의 평균을 사용함.
+image.RGBA는 알파가 곱해진(premultiplied) 값이라 채널별 단순 평균으로도 투명 영역 경계가 번지지 않음.
+*/
+func resize(src *image.RGBA, dstWidth, dstHeight int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := y * srcHeight / dstHeight
+		y1 := max((y+1)*srcHeight/dstHeight, y0+1)
+		for x := 0; x < dstWidth; x++ {
+			x0 := x * srcWidth / dstWidth
+			x1 := max((x+1)*srcWidth/dstWidth, x0+1)
+
+			var sum [4]uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					sum[0] += uint64(src.Pix[offset])
+					sum[1] += uint64(src.Pix[offset+1])
+					sum[2] += uint64(src.Pix[offset+2])
+					sum[3] += uint64(src.Pix[offset+3])
+					offset += 4
+				}
+			}
+
+			count := uint64((y1 - y0) * (x1 - x0))
+			offset := y*dst.Stride + x*4
+			for channel := 0; channel < 4; channel++ {
+				dst.Pix[offset+channel] = uint8(sum[channel] / count)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,178 @@
+package markdown
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+/*
+지원하는 문법은 CommonMark + GFM(표, 취소선, 자동 링크, 체크리스트)이며
+줄바꿈은 그대로 <br>로 렌더링함. Raw HTML은 goldmark 단계에서 버리고,
+렌더링 결과는 bluemonday 허용 목록으로 한 번 더 정리함.
+렌더링 규칙이 바뀌면 Version을 올려서 이전 캐시를 무효화해야 함
+*/
+const Version = 1
+
+const linkRel = "nofollow ugc"
+
+var (
+	mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@#&/])@([\p{L}\p{N}_]{1,30})`)
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@#&/])#([\p{L}\p{N}_]{1,50})`)
+)
+
+type Rendered struct {
+	HTML     string
+	Mentions []string
+	Hashtags []string
+	Links    []string
+}
+
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+func NewRenderer() *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithParserOptions(
+				parser.WithASTTransformers(util.Prioritized(&linkRelTransformer{}, 100)),
+			),
+			goldmark.WithRendererOptions(html.WithHardWraps()),
+		),
+		policy: newPolicy(),
+	}
+}
+
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireParseableURLs(true)
+	policy.AllowAttrs("rel").Matching(regexp.MustCompile(`^` + linkRel + `$`)).OnElements("a")
+	policy.RequireNoFollowOnLinks(true)
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	// 체크리스트는 읽기 전용 체크박스만 허용
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	return policy
+}
+
+// 렌더링 결과를 캐시할 때 쓰는 키. 본문이 같아도 Version이 바뀌면 다른 키가 됨
+func CacheKey(source string) string {
+	return cacheKey(Version, source)
+}
+
+func cacheKey(version int, source string) string {
+	hash := sha256.Sum256([]byte(source))
+	return fmt.Sprintf("v%d:%s", version, hex.EncodeToString(hash[:]))
+}
+
+func (r *Renderer) Render(source string) (*Rendered, error) {
+	src := []byte(source)
+	doc := r.markdown.Parser().Parse(text.NewReader(src))
+
+	var buf bytes.Buffer
+	if err := r.markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, err
+	}
+
+	plainText, links := collect(doc, src)
+	return &Rendered{
+		HTML:     r.policy.Sanitize(buf.String()),
+		Mentions: matchUnique(mentionPattern, plainText, nil),
+		Hashtags: matchUnique(hashtagPattern, plainText, strings.ToLower),
+		Links:    links,
+	}, nil
+}
+
+// 코드와 자동 링크 안의 텍스트는 멘션/해시태그로 보지 않음
+func collect(doc ast.Node, source []byte) (string, []string) {
+	var plainText strings.Builder
+	var links []string
+	seen := make(map[string]struct{})
+	addLink := func(link string) {
+		if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+			return
+		}
+		if _, ok := seen[link]; !ok {
+			seen[link] = struct{}{}
+			links = append(links, link)
+		}
+	}
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if node.Type() == ast.TypeBlock {
+			plainText.WriteByte('\n')
+		}
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			if n.AutoLinkType == ast.AutoLinkURL {
+				addLink(string(n.URL(source)))
+			}
+			plainText.WriteByte(' ')
+			return ast.WalkSkipChildren, nil
+		case *ast.Link:
+			addLink(string(n.Destination))
+		case *ast.Text:
+			plainText.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				plainText.WriteByte('\n')
+			}
+		case *ast.String:
+			plainText.Write(n.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return plainText.String(), links
+}
+
+func matchUnique(pattern *regexp.Regexp, plainText string, normalize func(string) string) []string {
+	var values []string
+	seen := make(map[string]struct{})
+	for _, match := range pattern.FindAllStringSubmatch(plainText, -1) {
+		value := match[1]
+		if normalize != nil {
+			value = normalize(value)
+		}
+		if _, ok := seen[value]; !ok {
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+type linkRelTransformer struct{}
+
+func (t *linkRelTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node.(type) {
+		case *ast.Link, *ast.AutoLink:
+			node.SetAttributeString("rel", []byte(linkRel))
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,147 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitizesHTML(t *testing.T) {
+	renderer := NewRenderer()
+
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "javascript link",
+			source:   "[click](javascript:alert(1))",
+			contains: []string{"click"},
+			excludes: []string{"javascript:", "href"},
+		},
+		{
+			name:     "javascript autolink",
+			source:   "<javascript:alert(1)>",
+			excludes: []string{`href="javascript:`},
+		},
+		{
+			name:     "raw html",
+			source:   "<script>alert(1)</script>\n\n<div onclick=\"alert(1)\">hi</div>",
+			excludes: []string{"<script", "alert(1)", "<div", "onclick"},
+		},
+		{
+			name:     "inline image onerror",
+			source:   `text <img src="x" onerror="alert(1)"> text`,
+			contains: []string{"text"},
+			excludes: []string{"onerror", "<img src=\"x\""},
+		},
+		{
+			name:     "markdown image",
+			source:   "![alt](https://example.com/a.png)",
+			contains: []string{`src="https://example.com/a.png"`, `alt="alt"`},
+		},
+		{
+			name:     "link rel",
+			source:   "[site](https://example.com)",
+			contains: []string{`href="https://example.com"`, `rel="nofollow ugc"`},
+		},
+		{
+			name:     "autolink rel",
+			source:   "visit https://example.com now",
+			contains: []string{`href="https://example.com"`, `rel="nofollow ugc"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderer.Render(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(rendered.HTML, want) {
+					t.Errorf("HTML %q does not contain %q", rendered.HTML, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(rendered.HTML, unwanted) {
+					t.Errorf("HTML %q contains %q", rendered.HTML, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCollectsMentionsAndHashtags(t *testing.T) {
+	renderer := NewRenderer()
+
+	tests := []struct {
+		name     string
+		source   string
+		mentions []string
+		hashtags []string
+		links    []string
+	}{
+		{
+			name:     "plain text",
+			source:   "hi @alice and @bob #Go #go #고양이",
+			mentions: []string{"alice", "bob"},
+			hashtags: []string{"go", "고양이"},
+		},
+		{
+			name:   "inline code",
+			source: "`@alice #go` outside",
+		},
+		{
+			name:   "code block",
+			source: "```\n@alice #go\n```",
+		},
+		{
+			name:   "indented code block",
+			source: "    @alice #go",
+		},
+		{
+			name:   "email and url fragment",
+			source: "mail me@example.com or see https://example.com/#section",
+			links:  []string{"https://example.com/#section"},
+		},
+		{
+			name:   "links",
+			source: "[a](https://a.example) [b](/relative) https://a.example <https://b.example>",
+			links:  []string{"https://a.example", "https://b.example"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderer.Render(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(rendered.Mentions) != fmt.Sprint(tt.mentions) {
+				t.Errorf("mentions = %v, want %v", rendered.Mentions, tt.mentions)
+			}
+			if fmt.Sprint(rendered.Hashtags) != fmt.Sprint(tt.hashtags) {
+				t.Errorf("hashtags = %v, want %v", rendered.Hashtags, tt.hashtags)
+			}
+			if fmt.Sprint(rendered.Links) != fmt.Sprint(tt.links) {
+				t.Errorf("links = %v, want %v", rendered.Links, tt.links)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	if !strings.HasPrefix(CacheKey("hello"), fmt.Sprintf("v%d:", Version)) {
+		t.Errorf("CacheKey = %q, want the current Version prefix", CacheKey("hello"))
+	}
+	if CacheKey("hello") != CacheKey("hello") {
+		t.Error("CacheKey is not stable for the same content")
+	}
+	if CacheKey("hello") == CacheKey("hello!") {
+		t.Error("CacheKey does not change with the content")
+	}
+	if cacheKey(Version, "hello") == cacheKey(Version+1, "hello") {
+		t.Error("cache key does not change with the renderer version")
+	}
+}
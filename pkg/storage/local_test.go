@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"uploads/abc.jpg", true},
+		{"uploads/thumbnails/abc.png", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"../secret", false},
+		{"uploads/../../secret", false},
+		{"uploads/./abc.jpg", false},
+		{"uploads//abc.jpg", false},
+		{"uploads/", false},
+		{`uploads\..\secret`, false},
+	}
+	for _, tt := range tests {
+		err := validateKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("validateKey(%q) = %v, want ErrInvalidKey", tt.key, err)
+		}
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "uploads")
+	store := NewLocalStore(root)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "images/abc.jpg", []byte("data"), "image/jpeg"); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	reader, err := store.Get(ctx, "images/abc.jpg")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "data" {
+		t.Errorf("data = %q, want %q", data, "data")
+	}
+
+	if err := store.Delete(ctx, "images/abc.jpg"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "images/abc.jpg"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("get after delete = %v, want ErrBlobNotFound", err)
+	}
+	// 없는 파일을 지워도 에러가 아님
+	if err := store.Delete(ctx, "images/abc.jpg"); err != nil {
+		t.Errorf("delete missing blob = %v, want nil", err)
+	}
+}
+
+func TestLocalStoreRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(filepath.Join(dir, "uploads"))
+	ctx := context.Background()
+
+	outside := filepath.Join(dir, "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret.txt", "images/../../secret.txt", outside} {
+		if err := store.Put(ctx, key, []byte("overwritten"), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("put %q = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("get %q = %v, want ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("delete %q = %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	if data, err := os.ReadFile(outside); err != nil || string(data) != "secret" {
+		t.Errorf("file outside the root = %q, %v, want untouched", data, err)
+	}
+}
@@ -0,0 +1,119 @@
+package unfurl
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+type pageMeta struct {
+	properties map[string]string
+	title      string
+}
+
+// Open Graph는 property, Twitter 카드와 description은 name 속성을 쓰므로 둘 다 같은 맵에 모음
+func parseMeta(r io.Reader) *pageMeta {
+	meta := &pageMeta{properties: make(map[string]string)}
+	tokenizer := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.Body:
+				return meta
+			case atom.Title:
+				inTitle = meta.title == ""
+			case atom.Meta:
+				meta.addMeta(token.Attr)
+			}
+		case html.EndTagToken:
+			switch tokenizer.Token().DataAtom {
+			case atom.Head:
+				return meta
+			case atom.Title:
+				inTitle = false
+			}
+		case html.TextToken:
+			if inTitle {
+				meta.title += string(tokenizer.Text())
+			}
+		}
+	}
+}
+
+func (m *pageMeta) addMeta(attrs []html.Attribute) {
+	var key, content string
+	for _, attr := range attrs {
+		switch strings.ToLower(attr.Key) {
+		case "property", "name":
+			key = strings.ToLower(strings.TrimSpace(attr.Val))
+		case "content":
+			content = strings.TrimSpace(attr.Val)
+		}
+	}
+	// 같은 태그가 여러 번 나오면 첫 번째 값을 사용함
+	if _, exists := m.properties[key]; key != "" && content != "" && !exists {
+		m.properties[key] = content
+	}
+}
+
+func (m *pageMeta) first(keys ...string) string {
+	for _, key := range keys {
+		if value := m.properties[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (m *pageMeta) preview(pageURL *url.URL) *Preview {
+	preview := &Preview{
+		URL:         pageURL.String(),
+		Title:       truncate(m.first("og:title", "twitter:title"), maxTitleLength),
+		Description: truncate(m.first("og:description", "twitter:description", "description"), maxDescriptionLength),
+		SiteName:    truncate(m.first("og:site_name"), maxTitleLength),
+	}
+	if preview.Title == "" {
+		preview.Title = truncate(m.title, maxTitleLength)
+	}
+	if canonical := resolveURL(pageURL, m.first("og:url")); canonical != "" {
+		preview.URL = canonical
+	}
+	preview.Image = resolveURL(pageURL, m.first("og:image:secure_url", "og:image", "twitter:image", "twitter:image:src"))
+	return preview
+}
+
+// 상대 경로를 절대 주소로 바꾸고, http(s)가 아닌 주소는 버림
+func resolveURL(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	parsed, err := base.Parse(ref)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+	return parsed.String()
+}
+
+func truncate(value string, maxLength int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:maxLength]) + "…"
+}
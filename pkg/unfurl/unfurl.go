@@ -0,0 +1,139 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL         = errors.New("invalid url")
+	ErrBlockedAddress     = errors.New("blocked address")
+	ErrTooManyRedirects   = errors.New("too many redirects")
+	ErrUnsupportedContent = errors.New("unsupported content type")
+	ErrNoPreview          = errors.New("no preview metadata")
+)
+
+type Options struct {
+	Timeout      time.Duration
+	MaxBytes     int64
+	MaxRedirects int
+	UserAgent    string
+}
+
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	Image       string
+	SiteName    string
+}
+
+type Unfurler struct {
+	client  *http.Client
+	options Options
+	allowIP func(net.IP) bool
+}
+
+func New(options Options) *Unfurler {
+	u := &Unfurler{
+		options: options,
+		allowIP: isPublicIP,
+	}
+
+	// DNS 조회 결과가 아닌 실제로 연결하는 주소를 검사해야 DNS rebinding도 막을 수 있음
+	dialer := &net.Dialer{
+		Timeout: options.Timeout,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !u.allowIP(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+
+	u.client = &http.Client{
+		Timeout: options.Timeout,
+		Transport: &http.Transport{
+			Proxy:                  nil,
+			DialContext:            dialer.DialContext,
+			TLSHandshakeTimeout:    options.Timeout,
+			ResponseHeaderTimeout:  options.Timeout,
+			MaxResponseHeaderBytes: 64 * 1024,
+			DisableKeepAlives:      true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > options.MaxRedirects {
+				return ErrTooManyRedirects
+			}
+			return u.checkURL(req.URL)
+		},
+	}
+	return u
+}
+
+func (u *Unfurler) Unfurl(ctx context.Context, rawURL string) (*Preview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if err := u.checkURL(target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	if u.options.UserAgent != "" {
+		req.Header.Set("User-Agent", u.options.UserAgent)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContent, mediaType)
+	}
+
+	// 메타 태그는 <head>에 있으므로 제한을 넘는 나머지 본문은 읽지 않음
+	meta := parseMeta(io.LimitReader(resp.Body, u.options.MaxBytes))
+	preview := meta.preview(resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" {
+		return nil, ErrNoPreview
+	}
+	return preview, nil
+}
+
+func (u *Unfurler) checkURL(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, target.Scheme)
+	}
+	if target.Hostname() == "" || target.User != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidURL, target.Redacted())
+	}
+	// 호스트 이름은 연결 시점에 검사하고, IP 주소가 그대로 적힌 경우는 요청 전에 걸러냄
+	if ip := net.ParseIP(target.Hostname()); ip != nil && !u.allowIP(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+	}
+	return nil
+}
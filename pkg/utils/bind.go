@@ -0,0 +1,317 @@
+package utils
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/i18n"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+type BindOption func(*bindOptions)
+
+type bindOptions struct {
+	disallowUnknownFields bool
+}
+
+// 본문에 DTO에 없는 필드가 있으면 거부함. 오타난 필드가 조용히 무시되면 안 되는 API(관리자 기능 등)에서 사용
+func DisallowUnknownFields() BindOption {
+	return func(options *bindOptions) {
+		options.disallowUnknownFields = true
+	}
+}
+
+/*
+요청 값을 DTO에 담고 검증함.
+1. 본문은 Content-Type에 따라 JSON(charset 등 파라미터, application/*+json 포함), x-www-form-urlencoded, multipart/form-data로 읽으며 form 필드 이름은 json 태그를 따름
+2. 경로 파라미터, 쿼리, 헤더는 params, query, reqHeader 태그가 붙은 필드에만 담음
+경로 파라미터와 쿼리는 본문보다 나중에 담으므로 본문으로 덮어쓸 수 없고, json:"-"처럼 서버가 채우는 필드는 어느 값으로도 덮어쓸 수 없음.
+값을 담는 과정에서 실패한 필드가 있으면 같은 필드가 검증 결과에 한 번 더 나오지 않도록 검증은 건너뜀.
+*/
+func Bind(ctx *fiber.Ctx, targetStruct interface{}, actionMessage string, opts ...BindOption) *exception.ErrResponseCtx {
+	var options bindOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	locale := i18n.Locale(ctx)
+
+	errs, err := bindBody(ctx, targetStruct, options, locale)
+	if err != nil {
+		errMessage := fmt.Sprintf("❌ %s 실패. 지원하지 않는 Content-Type입니다.", actionMessage)
+		return exception.GenerateErrorCtx(fiber.StatusUnsupportedMediaType, errMessage, err)
+	}
+	errs = append(errs, decodeValues(targetStruct, "params", paramValues(ctx), locale)...)
+	errs = append(errs, decodeValues(targetStruct, "query", queryValues(ctx), locale)...)
+	errs = append(errs, decodeValues(targetStruct, "reqHeader", headerValues(ctx), locale)...)
+
+	if len(errs) == 0 {
+		errs = Validate(targetStruct, locale)
+	}
+	if len(errs) > 0 {
+		errMessage := fmt.Sprintf("❌ %s 실패. 입력값을 확인해주세요.", actionMessage)
+		return exception.GenerateErrorCtx(fiber.StatusBadRequest, errMessage, exception.ErrValidation).WithDetail(errs)
+	}
+
+	return nil
+}
+
+// 본문이 없는 요청은 Content-Type과 관계없이 건너뜀
+func bindBody(ctx *fiber.Ctx, target interface{}, options bindOptions, locale string) ([]exception.ErrValidateResult, error) {
+	if len(ctx.Body()) == 0 {
+		return nil, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(string(ctx.Request().Header.ContentType()))
+	if err != nil {
+		return nil, exception.ErrUnsupportedMediaType
+	}
+
+	switch {
+	case mediaType == fiber.MIMEApplicationJSON, strings.HasSuffix(mediaType, "+json"):
+		return decodeJSON(ctx.Body(), target, options, locale), nil
+	case mediaType == fiber.MIMEApplicationForm:
+		args := ctx.Request().PostArgs()
+		lookup := func(name string) []string {
+			return byteStrings(args.PeekMulti(name))
+		}
+		keys := make([]string, 0, args.Len())
+		args.VisitAll(func(key, _ []byte) {
+			keys = append(keys, string(key))
+		})
+		return decodeForm(target, lookup, keys, options, locale), nil
+	case mediaType == fiber.MIMEMultipartForm:
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return []exception.ErrValidateResult{bindError(locale, "body", "body", err.Error(), "")}, nil
+		}
+		lookup := func(name string) []string {
+			return form.Value[name]
+		}
+		keys := make([]string, 0, len(form.Value))
+		for key := range form.Value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return decodeForm(target, lookup, keys, options, locale), nil
+	}
+	return nil, exception.ErrUnsupportedMediaType
+}
+
+func decodeJSON(body []byte, target interface{}, options bindOptions, locale string) []exception.ErrValidateResult {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if options.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	err := decoder.Decode(target)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []exception.ErrValidateResult{bindError(locale, typeErr.Field, "type", typeErr.Value, typeName(typeErr.Type))}
+	}
+	// encoding/json은 알 수 없는 필드를 별도 타입 없이 메시지로만 알려줌
+	if field, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+		return []exception.ErrValidateResult{bindError(locale, strings.Trim(field, `"`), "unknown", nil, "")}
+	}
+	return []exception.ErrValidateResult{bindError(locale, "body", "body", err.Error(), "")}
+}
+
+func decodeForm(target interface{}, lookup func(string) []string, keys []string, options bindOptions, locale string) []exception.ErrValidateResult {
+	errs := decodeValues(target, "json", lookup, locale)
+	if !options.disallowUnknownFields {
+		return errs
+	}
+
+	known := make(map[string]bool)
+	collectNames(reflect.TypeOf(target).Elem(), "json", known)
+	for _, key := range keys {
+		if !known[key] {
+			errs = append(errs, bindError(locale, key, "unknown", nil, ""))
+		}
+	}
+	return errs
+}
+
+func collectNames(rt reflect.Type, tag string, names map[string]bool) {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectNames(field.Type, tag, names)
+			continue
+		}
+		if name := tagName(field, tag); name != "" && field.IsExported() {
+			names[name] = true
+		}
+	}
+}
+
+func paramValues(ctx *fiber.Ctx) func(string) []string {
+	return func(name string) []string {
+		if value := ctx.Params(name); value != "" {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+func queryValues(ctx *fiber.Ctx) func(string) []string {
+	args := ctx.Context().QueryArgs()
+	return func(name string) []string {
+		return byteStrings(args.PeekMulti(name))
+	}
+}
+
+func headerValues(ctx *fiber.Ctx) func(string) []string {
+	return func(name string) []string {
+		if value := ctx.Get(name); value != "" {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+func byteStrings(values [][]byte) []string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = string(value)
+	}
+	return strs
+}
+
+// tag 이름이 붙은 필드에만 값을 담고, 형식이 맞지 않아 담지 못한 필드를 돌려줌
+func decodeValues(target interface{}, tag string, lookup func(string) []string, locale string) []exception.ErrValidateResult {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return decodeStruct(rv.Elem(), tag, lookup, locale)
+}
+
+func decodeStruct(rv reflect.Value, tag string, lookup func(string) []string, locale string) []exception.ErrValidateResult {
+	var errs []exception.ErrValidateResult
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, decodeStruct(rv.Field(i), tag, lookup, locale)...)
+			continue
+		}
+		name := tagName(field, tag)
+		if name == "" || !field.IsExported() {
+			continue
+		}
+		values := lookup(name)
+		if len(values) == 0 {
+			continue
+		}
+		if err := setField(rv.Field(i), values); err != nil {
+			errs = append(errs, bindError(locale, name, "type", values[0], typeName(field.Type)))
+		}
+	}
+	return errs
+}
+
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := setField(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(values[0]))
+	}
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setScalar(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setScalar(field, values[0])
+}
+
+func setScalar(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
+
+// 클라이언트가 알아볼 수 있도록 Go 타입 대신 JSON 타입 이름을 사용함
+func typeName(rt reflect.Type) string {
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	if reflect.PointerTo(rt).Implements(textUnmarshalerType) {
+		return "string"
+	}
+	return "object"
+}
+
+func bindError(locale, field, tag string, value interface{}, param string) exception.ErrValidateResult {
+	return exception.ErrValidateResult{
+		IsError: true,
+		Field:   field,
+		Tag:     tag,
+		Value:   value,
+		Message: i18n.TranslateRule(locale, tag, field, param, reflect.Invalid),
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"net"
+	"strings"
+)
+
+// CIDR 표기가 아닌 단일 IP도 허용함. (ex. 10.0.0.1 -> 10.0.0.1/32)
+func ParseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, &net.ParseError{Type: "IP address", Text: cidr}
+		}
+		if ip.To4() != nil {
+			cidr += "/32"
+		} else {
+			cidr += "/128"
+		}
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	return ipNet, err
+}
+
+func ContainsIP(cidr, ip string) bool {
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	parsedIP := net.ParseIP(ip)
+	return parsedIP != nil && ipNet.Contains(parsedIP)
+}
+
+/*
+요청을 보낸 클라이언트의 IP를 구함.
+직접 연결한 주소(remoteIP)가 trustedProxies에 없으면 헤더는 클라이언트가 마음대로 채울 수 있으므로 remoteIP를 그대로 씀.
+신뢰하는 프록시를 거쳤다면 forwarded(ex. X-Forwarded-For)를 오른쪽부터 읽어 신뢰하지 않는 첫 주소를 클라이언트로 봄.
+왼쪽 값은 클라이언트가 보낸 헤더가 그대로 이어진 것이므로 믿지 않고, IP가 아닌 값이 나오면 그 앞까지 확인한 주소를 씀.
+*/
+func ClientIP(remoteIP, forwarded string, trustedProxies []string) string {
+	client := remoteIP
+	if !isTrustedProxy(client, trustedProxies) {
+		return client
+	}
+
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !isTrustedProxy(client, trustedProxies) {
+			break
+		}
+	}
+	return client
+}
+
+func isTrustedProxy(ip string, trustedProxies []string) bool {
+	for _, cidr := range trustedProxies {
+		if ContainsIP(cidr, ip) {
+			return true
+		}
+	}
+	return false
+}
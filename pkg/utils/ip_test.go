@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestClientIP(t *testing.T) {
+	trusted := []string{"10.0.0.0/8", "192.0.2.1"}
+
+	tests := []struct {
+		name      string
+		remoteIP  string
+		forwarded string
+		want      string
+	}{
+		{"direct client ignores header", "203.0.113.7", "198.51.100.1", "203.0.113.7"},
+		{"single proxy", "10.0.0.2", "203.0.113.7", "203.0.113.7"},
+		{"spoofed leftmost entry", "10.0.0.2", "spoofed, 198.51.100.1, 203.0.113.7", "203.0.113.7"},
+		{"multiple trusted hops", "10.0.0.2", "198.51.100.1, 203.0.113.7, 192.0.2.1, 10.1.2.3", "203.0.113.7"},
+		{"invalid entry after trusted hop", "10.0.0.2", "203.0.113.7, not-an-ip, 10.1.2.3", "10.1.2.3"},
+		{"empty header", "10.0.0.2", "", "10.0.0.2"},
+		{"only trusted hops", "10.0.0.2", "10.9.9.9, 192.0.2.1", "10.9.9.9"},
+		{"ipv6 client", "10.0.0.2", " 2001:db8::1 ", "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClientIP(tt.remoteIP, tt.forwarded, trusted); got != tt.want {
+				t.Errorf("ClientIP(%q, %q) = %q, want %q", tt.remoteIP, tt.forwarded, got, tt.want)
+			}
+		})
+	}
+}
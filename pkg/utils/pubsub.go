@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func Publish(rdClient *redis.Client, ctx context.Context, channel string, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return rdClient.Publish(ctx, channel, jsonData).Err()
+}
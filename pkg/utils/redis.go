@@ -25,10 +25,3 @@ func SetCache(rdClient *redis.Client, ctx context.Context, key string, data inte
 	}
 	return rdClient.Set(ctx, key, jsonData, ttl).Err()
 }
-
-func ClearCacheByPattern(rdClient *redis.Client, ctx context.Context, pattern string) {
-	keys, _ := rdClient.Keys(ctx, pattern).Result()
-	for _, key := range keys {
-		rdClient.Del(ctx, key)
-	}
-}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+func BanCacheKey(userID string) string {
+	return fmt.Sprintf("auth:ban:%s", userID)
+}
+
+func TokenRevokedCacheKey(userID string) string {
+	return fmt.Sprintf("auth:revoked:%s", userID)
+}
+
+func IPBanListCacheKey() string {
+	return "auth:ipbans"
+}
+
+func BanMessage(reason string, expiresAt *time.Time) string {
+	until := "영구 정지"
+	if expiresAt != nil {
+		until = expiresAt.Format("2006-01-02 15:04:05 MST") + " 까지"
+	}
+	return fmt.Sprintf("❌ 정지된 계정입니다. 사유: %s (%s)", reason, until)
+}
@@ -1,70 +1,142 @@
 package utils
 
 import (
-	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
 	"github.com/kitae0522/gommunity/pkg/exception"
+	"github.com/kitae0522/gommunity/pkg/i18n"
 )
 
-var validate *validator.Validate = validator.New()
+// maxcontent에 값을 지정하지 않았을 때 허용하는 본문 글자 수
+const DefaultMaxContentLength = 20000
 
-func Validate(i interface{}) []exception.ErrValidateResult {
-	errlog := make([]exception.ErrValidateResult, 0)
-	if errs := validate.Struct(i); errs != nil {
-		for _, err := range errs.(validator.ValidationErrors) {
-			errlog = append(errlog, exception.ErrValidateResult{
-				IsError: true,
-				Field:   err.Field(),
-				Tag:     err.Tag(),
-				Value:   err.Value(),
-			})
+var validate *validator.Validate = newValidator()
+
+var handlePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{3,20}$`)
+
+/*
+모든 DTO에서 validate 태그로 사용할 수 있는 프로젝트 공통 규칙.
+- handle: 영문, 숫자, 밑줄(_)로 이루어진 3~20자
+- password: 영문과 숫자를 모두 포함한 8자 이상
+- safeurl: 호스트가 있는 http, https URL만 허용 (javascript:, data: 등은 거부)
+- maxcontent: 본문 글자 수 제한. 값을 생략하면 DefaultMaxContentLength를 사용함 (ex. maxcontent, maxcontent=2000)
+*/
+var rules = map[string]validator.Func{
+	"handle":     isHandle,
+	"password":   isStrongPassword,
+	"safeurl":    isSafeURL,
+	"maxcontent": isWithinMaxContent,
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule); err != nil {
+			panic(err)
 		}
 	}
-	return errlog
+	return v
 }
 
-func Bind(ctx *fiber.Ctx, targetStruct interface{}, actionMessage string) *exception.ErrResponseCtx {
-	var errs []exception.ErrValidateResult
-	checkList := []struct {
-		field  string
-		parser func(interface{}) error
-	}{
-		{"Params", ctx.ParamsParser},
-		{"Headers", ctx.ReqHeaderParser},
-		{"Query", ctx.QueryParser},
+// 검증 결과에는 Go 필드 이름 대신 클라이언트가 보낸 이름을 사용하고, 태그가 없는 필드만 Go 필드 이름을 사용함
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "params", "query", "reqHeader"} {
+		if name := tagName(field, tag); name != "" {
+			return name
+		}
 	}
+	return ""
+}
 
-	for _, checkItem := range checkList {
-		if err := parsePayload(targetStruct, checkItem.parser, checkItem.field); err != nil {
-			errs = append(errs, *err)
-		}
+func tagName(field reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
 	}
+	return name
+}
 
-	if ctx.GetReqHeaders()["Content-Type"] != nil && ctx.GetReqHeaders()["Content-Type"][0] == "application/json" {
-		if err := parsePayload(targetStruct, ctx.BodyParser, "Body"); err != nil {
-			errs = append(errs, *err)
+func Validate(i interface{}, locale string) []exception.ErrValidateResult {
+	errlog := make([]exception.ErrValidateResult, 0)
+	fieldErrs, ok := validate.Struct(i).(validator.ValidationErrors)
+	if !ok {
+		return errlog
+	}
+
+	for _, err := range fieldErrs {
+		path := fieldPath(err.Namespace())
+		result := exception.ErrValidateResult{
+			IsError: true,
+			Field:   path,
+			Tag:     err.Tag(),
+			Value:   err.Value(),
+			Message: i18n.TranslateRule(locale, err.Tag(), path, ruleParam(err), err.Kind()),
+		}
+		// 규칙을 어긴 비밀번호도 응답이나 로그에 남기지 않음
+		if err.Tag() == "password" {
+			result.Value = nil
 		}
+		errlog = append(errlog, result)
 	}
+	return errlog
+}
 
-	errs = append(errs, Validate(targetStruct)...)
+// Namespace는 DTO 이름부터 시작하므로 제외함 (ex. CreateThreadRequest.attachments[0].uploadID -> attachments[0].uploadID)
+func fieldPath(namespace string) string {
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+	return namespace
+}
 
-	if len(errs) > 0 {
-		errMessage := fmt.Sprintf("❌ %s 실패. Body Binding 과정에서 문제 발생", actionMessage)
-		return exception.GenerateErrorCtx(fiber.StatusBadRequest, errMessage, errs)
+func ruleParam(err validator.FieldError) string {
+	if err.Tag() == "maxcontent" {
+		return strconv.Itoa(maxContentLength(err.Param()))
 	}
+	return err.Param()
+}
 
-	return nil
+func isHandle(fl validator.FieldLevel) bool {
+	return handlePattern.MatchString(fl.Field().String())
 }
 
-func parsePayload(target interface{}, parseFunc func(interface{}) error, fieldName string) *exception.ErrValidateResult {
-	if err := parseFunc(target); err != nil {
-		return &exception.ErrValidateResult{
-			IsError: true,
-			Field:   fieldName,
-			Value:   err.Error(),
+func isStrongPassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
 		}
 	}
-	return nil
+	return utf8.RuneCountInString(password) >= 8 && hasLetter && hasDigit
+}
+
+func isSafeURL(fl validator.FieldLevel) bool {
+	parsed, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" && parsed.User == nil
+}
+
+func isWithinMaxContent(fl validator.FieldLevel) bool {
+	return utf8.RuneCountInString(fl.Field().String()) <= maxContentLength(fl.Param())
+}
+
+func maxContentLength(param string) int {
+	if limit, err := strconv.Atoi(param); err == nil && limit > 0 {
+		return limit
+	}
+	return DefaultMaxContentLength
 }